@@ -23,8 +23,11 @@ import (
 // Timerange represents a range of time a given time series is defined in:
 // it is 3-tuple of (start, end, resolution) with the following constraints:
 // start <= end
-// start = 0 mod resolution
-// end =   0 mod resolution
+// start = offset mod resolution
+// end =   offset mod resolution
+//
+// The offset is usually 0, so that slots are aligned to the epoch. A nonzero
+// offset aligns slots to some other boundary, such as local midnight.
 //
 // This range is inclusive of Start and End (i.e. [Start, End]). Start and End
 // are Unix milliseconds timestamps. Resolution is in milliseconds.
@@ -33,11 +36,12 @@ type Timerange struct {
 	start      int64
 	end        int64
 	resolution int64
+	offset     int64
 }
 
 // StartMillis returns the number of milliseconds between the epoch and the start of the timerange.
 // The start is inclusive.
-// StartMillis() is always divisible by ResolutionMillis()
+// StartMillis() - OffsetMillis() is always divisible by ResolutionMillis()
 func (tr Timerange) StartMillis() int64 {
 	return tr.start
 }
@@ -92,6 +96,12 @@ func (tr Timerange) Resolution() time.Duration {
 	return time.Duration(tr.resolution) * time.Millisecond
 }
 
+// OffsetMillis returns the alignment of the timerange's slots relative to the epoch.
+// It is always in the range [0, ResolutionMillis()).
+func (tr Timerange) OffsetMillis() int64 {
+	return tr.offset
+}
+
 // NewTimerange creates a timerange which is validated, providing error otherwise.
 func NewTimerange(start, end, resolution int64) (Timerange, error) {
 	if resolution <= 0 {
@@ -121,6 +131,22 @@ func NewSnappedTimerange(start, end, resolution int64) (Timerange, error) {
 	return Timerange{start: start, end: end, resolution: resolution}.Snap(), nil
 }
 
+// NewSnappedTimerangeWithOffset creates a new timerange whose slots are aligned
+// to the given offset (mod resolution) instead of to the epoch, and snaps it.
+func NewSnappedTimerangeWithOffset(start, end, resolution, offset int64) (Timerange, error) {
+	if resolution <= 0 {
+		return Timerange{}, fmt.Errorf("invalid resolution %d", resolution)
+	}
+	if start > end {
+		return Timerange{}, fmt.Errorf("start must be <= end (start=%d, end=%d)", start, end)
+	}
+	offset %= resolution
+	if offset < 0 {
+		offset += resolution
+	}
+	return Timerange{start: start, end: end, resolution: resolution, offset: offset}.Snap(), nil
+}
+
 func snap(n, boundary int64) int64 {
 	if n < 0 {
 		return -snap(-n, boundary)
@@ -141,8 +167,8 @@ func (tr Timerange) Snap() Timerange {
 	if tr.resolution == 0 {
 		panic("Unable to snap with resolution of 0")
 	}
-	tr.start = snap(tr.start, tr.resolution)
-	tr.end = snap(tr.end, tr.resolution)
+	tr.start = snap(tr.start-tr.offset, tr.resolution) + tr.offset
+	tr.end = snap(tr.end-tr.offset, tr.resolution) + tr.offset
 	if tr.end < tr.start {
 		tr.end = tr.start // This better preserves the invariants without having to return an error.
 	}
@@ -22,9 +22,11 @@ import (
 )
 
 // Timeseries is a single time series, identified with the associated tagset.
+// The optional name is used to display the series in place of its tagset.
 type Timeseries struct {
 	Values []float64 `json:"values"`
 	TagSet TagSet    `json:"tagset"`
+	Name   string    `json:"name,omitempty"`
 }
 
 // MarshalJSON exists to manually encode floats.
@@ -36,6 +38,14 @@ func (ts Timeseries) MarshalJSON() ([]byte, error) {
 		return nil, err
 	}
 	buffer.Write(tagset)
+	if ts.Name != "" {
+		name, err := json.Marshal(ts.Name)
+		if err != nil {
+			return nil, err
+		}
+		buffer.WriteString(`,"name":`)
+		buffer.Write(name)
+	}
 	buffer.WriteString(`,"values":[`)
 	for i, y := range ts.Values {
 		if i > 0 {
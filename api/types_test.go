@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/square/metrics/testing_support/assert"
 )
@@ -127,6 +128,14 @@ func TestTimeseries_MarshalJSON(t *testing.T) {
 			},
 			`{"tagset":{},"values":[0,1,-1,null]}`,
 		},
+		{
+			Timeseries{
+				TagSet: ParseTagSet("foo=bar"),
+				Values: []float64{2},
+				Name:   "bar \"baz\"",
+			},
+			`{"tagset":{"foo":"bar"},"name":"bar \"baz\"","values":[2]}`,
+		},
 	} {
 		a := assert.New(t).Contextf("expected=%s", suite.expected)
 		encoded, err := json.Marshal(suite.input)
@@ -134,3 +143,20 @@ func TestTimeseries_MarshalJSON(t *testing.T) {
 		a.Eq(string(encoded), suite.expected)
 	}
 }
+
+func TestTimerange_SnapWithOffset(t *testing.T) {
+	a := assert.New(t)
+	hour := int64(3600 * 1000)
+	day := 24 * hour
+	// Slots aligned to 05:00 UTC (midnight in UTC-5).
+	timerange, err := NewSnappedTimerangeWithOffset(10*day+4*hour, 13*day+7*hour, day, -19*hour)
+	a.CheckError(err)
+	a.Eq(timerange.OffsetMillis(), 5*hour)
+	a.Eq(timerange.StartMillis(), 10*day+5*hour)
+	a.Eq(timerange.EndMillis(), 13*day+5*hour)
+	a.Eq(timerange.Slots(), 4)
+	a.Eq(timerange.IndexOfTime(timerange.Start().Add(23*time.Hour)), 0)
+	shifted := timerange.ExtendBefore(40 * time.Hour)
+	a.Eq(shifted.StartMillis(), 8*day+5*hour)
+	a.Eq(shifted.OffsetMillis(), 5*hour)
+}
@@ -19,6 +19,7 @@ package aggregate
 
 import (
 	"math"
+	"sort"
 
 	"github.com/square/metrics/api"
 )
@@ -99,6 +100,29 @@ func groupBy(list api.SeriesList, tags []string, collapses bool) []group {
 	return result
 }
 
+// Partition splits the given `list` into the groups which By would aggregate, in the same order.
+// Unlike the groups used by By, each series keeps all of its tags.
+func Partition(list api.SeriesList, tags []string, collapses bool) []api.SeriesList {
+	keys := []api.TagSet{}
+	result := []api.SeriesList{}
+	for _, series := range list.Series {
+		key := filterTagSet(series, tags, collapses).TagSet
+		index := len(keys)
+		for i := range keys {
+			if groupAccepts(keys[i], key) {
+				index = i
+				break
+			}
+		}
+		if index == len(keys) {
+			keys = append(keys, key)
+			result = append(result, api.SeriesList{Series: []api.Timeseries{}})
+		}
+		result[index].Series = append(result[index].Series, series)
+	}
+	return result
+}
+
 // filterNaN removes NaN elements from the given slice (producing a copy)
 func filterNaN(array []float64) []float64 {
 	result := []float64{}
@@ -175,6 +199,64 @@ func Count(array []float64) float64 {
 	return float64(len(filterNaN(array)))
 }
 
+// Median is the middle of the values, ignoring NaN values.
+func Median(array []float64) float64 {
+	return quantile(array, 0.5)
+}
+
+// Quantile creates an aggregator for the qth quantile of the values (where q is
+// between 0 and 1), ignoring NaN values. Quantiles which fall between two
+// values are linearly interpolated.
+func Quantile(q float64) func([]float64) float64 {
+	return func(array []float64) float64 {
+		return quantile(array, q)
+	}
+}
+
+func quantile(array []float64, q float64) float64 {
+	array = filterNaN(array)
+	if len(array) == 0 {
+		// The quantile of an empty list is not well-defined
+		return math.NaN()
+	}
+	sort.Float64s(array)
+	rank := q * float64(len(array)-1)
+	lower := int(math.Floor(rank))
+	if lower >= len(array)-1 {
+		return array[len(array)-1]
+	}
+	return array[lower] + (rank-float64(lower))*(array[lower+1]-array[lower])
+}
+
+// Variance is the population variance of the values, ignoring NaN values.
+func Variance(array []float64) float64 {
+	array = filterNaN(array)
+	if len(array) == 0 {
+		// The variance of an empty list is not well-defined
+		return math.NaN()
+	}
+	mean := Mean(array)
+	sum := 0.0
+	for _, v := range array {
+		sum += (v - mean) * (v - mean)
+	}
+	return sum / float64(len(array))
+}
+
+// Stddev is the population standard deviation of the values, ignoring NaN values.
+func Stddev(array []float64) float64 {
+	return math.Sqrt(Variance(array))
+}
+
+// CountDistinct is the number of distinct values, ignoring NaN values.
+func CountDistinct(array []float64) float64 {
+	distinct := map[float64]bool{}
+	for _, v := range filterNaN(array) {
+		distinct[v] = true
+	}
+	return float64(len(distinct))
+}
+
 // applyAggregation takes an aggregation function ( [float64] => float64 ) and applies it to a given list of Timeseries
 // the list must be non-empty, or an error is returned
 func applyAggregation(group group, aggregator func([]float64) float64) api.Timeseries {
@@ -175,6 +175,26 @@ func Test_groupBy(t *testing.T) {
 	}
 }
 
+func Test_Partition(t *testing.T) {
+	a := assert.New(t)
+	list := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{1}, TagSet: api.TagSet{"dc": "A", "host": "a"}},
+			{Values: []float64{2}, TagSet: api.TagSet{"dc": "B", "host": "b"}},
+			{Values: []float64{3}, TagSet: api.TagSet{"dc": "A", "host": "c"}},
+		},
+	}
+	groups := Partition(list, []string{"dc"}, false)
+	a.EqInt(len(groups), 2)
+	if len(groups) == 2 {
+		a.Eq(groups[0].Series, []api.Timeseries{list.Series[0], list.Series[2]})
+		a.Eq(groups[1].Series, []api.Timeseries{list.Series[1]})
+	}
+	a.EqInt(len(Partition(list, nil, false)), 1)
+	a.EqInt(len(Partition(list, []string{"dc"}, true)), 3)
+	a.EqInt(len(Partition(api.SeriesList{}, nil, false)), 0)
+}
+
 func Test_applyAggregation(t *testing.T) {
 	var testGroup = group{
 		List: []api.Timeseries{
@@ -233,6 +253,30 @@ func Test_applyAggregation(t *testing.T) {
 			Min,
 			[]float64{-1, -1, 0, 2},
 		},
+		{
+			Median,
+			[]float64{0, 0.5, 2, 2.5},
+		},
+		{
+			Quantile(0.25),
+			[]float64{-0.25, -0.25, 1.5, 2},
+		},
+		{
+			Quantile(1),
+			[]float64{4, 2, 4, 4},
+		},
+		{
+			Variance,
+			[]float64{3.6875, 1.25, 2, 0.6875},
+		},
+		{
+			Stddev,
+			[]float64{math.Sqrt(3.6875), math.Sqrt(1.25), math.Sqrt(2), math.Sqrt(0.6875)},
+		},
+		{
+			CountDistinct,
+			[]float64{3, 4, 3, 3},
+		},
 	}
 
 	for _, testCase := range aggregationTestCases {
@@ -252,6 +296,22 @@ func Test_applyAggregation(t *testing.T) {
 	}
 }
 
+func Test_aggregatorsIgnoreNaN(t *testing.T) {
+	a := assert.New(t)
+	nan := math.NaN()
+	values := []float64{nan, 3, 1, nan, 2, 2}
+	a.EqFloat(Median(values), 2, epsilon)
+	a.EqFloat(Quantile(0.75)(values), 2.25, epsilon)
+	a.EqFloat(Variance(values), 0.5, epsilon)
+	a.EqFloat(CountDistinct(values), 3, epsilon)
+	for _, aggregator := range []func([]float64) float64{Median, Quantile(0.9), Variance, Stddev} {
+		if result := aggregator([]float64{nan, nan}); !math.IsNaN(result) {
+			t.Errorf("expected NaN when aggregating only NaN values but got %f", result)
+		}
+	}
+	a.EqFloat(CountDistinct([]float64{nan}), 0, epsilon)
+}
+
 func Test_AggregateBy(t *testing.T) {
 	a := assert.New(t)
 
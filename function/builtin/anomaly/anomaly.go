@@ -0,0 +1,135 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/builtin/aggregate"
+)
+
+// madScale scales the median absolute deviation so that it estimates the
+// standard deviation of normally distributed values.
+const madScale = 1.4826
+
+// ZScore is the number of standard deviations that each value deviates from
+// the mean of the values in the window before it.
+var ZScore = newRollingScore("anomaly.zscore", aggregate.Mean, aggregate.Stddev)
+
+// MAD is the number of (scaled) median absolute deviations that each value
+// deviates from the median of the values in the window before it. Unlike
+// ZScore, it is not thrown off by earlier spikes within the window.
+var MAD = newRollingScore("anomaly.mad", aggregate.Median, medianAbsoluteDeviation)
+
+// newRollingScore creates a function which scores each value against the
+// center and spread of the values in the window before it, fetching data from
+// before the timerange so that the first slots have a full window.
+func newRollingScore(name string, center func([]float64) float64, spread func([]float64) float64) function.MetricFunction {
+	return function.MakeFunction(
+		name,
+		func(context function.EvaluationContext, listExpression function.Expression, window time.Duration) (api.SeriesList, error) {
+			timerange := context.Timerange()
+			limit := int(float64(window)/float64(timerange.Resolution()) + 0.5)
+			if limit < 2 {
+				return api.SeriesList{}, fmt.Errorf("%s expects a window of at least two slots but got %s", name, window.String())
+			}
+			newContext := context.WithTimerange(timerange.ExtendBefore(time.Duration(limit) * timerange.Resolution()))
+			list, err := function.EvaluateToSeriesList(listExpression, newContext)
+			if err != nil {
+				return api.SeriesList{}, err
+			}
+			resultList := api.SeriesList{
+				Series: make([]api.Timeseries, len(list.Series)),
+			}
+			for seriesIndex, series := range list.Series {
+				values := make([]float64, len(series.Values)-limit)
+				for i := range values {
+					previous := series.Values[i : i+limit]
+					values[i] = score(series.Values[i+limit], center(previous), spread(previous))
+				}
+				resultList.Series[seriesIndex] = api.Timeseries{
+					Values: values,
+					TagSet: series.TagSet,
+					Name:   series.Name,
+				}
+			}
+			return resultList, nil
+		},
+	)
+}
+
+// Outliers keeps the series which, at some point in time, deviate from the
+// median of their group at that time by more than `tolerance` (scaled) median
+// absolute deviations of the group. When the rest of the group is identical,
+// any deviation from it is an outlier.
+var Outliers = function.MakeFunction(
+	"anomaly.outliers",
+	func(list api.SeriesList, tolerance float64, groups function.Groups) (api.SeriesList, error) {
+		if !(tolerance >= 0) {
+			return api.SeriesList{}, fmt.Errorf("anomaly.outliers expects a non-negative tolerance but got %g", tolerance)
+		}
+		result := api.SeriesList{
+			Series: []api.Timeseries{},
+		}
+		for _, group := range aggregate.Partition(list, groups.List, groups.Collapses) {
+			outlying := make([]bool, len(group.Series))
+			column := make([]float64, len(group.Series))
+			for t := range group.Series[0].Values {
+				for i, series := range group.Series {
+					column[i] = series.Values[t]
+				}
+				median := aggregate.Median(column)
+				spread := medianAbsoluteDeviation(column)
+				for i, value := range column {
+					if math.Abs(score(value, median, spread)) > tolerance {
+						outlying[i] = true
+					}
+				}
+			}
+			for i, series := range group.Series {
+				if outlying[i] {
+					result.Series = append(result.Series, series)
+				}
+			}
+		}
+		return result, nil
+	},
+)
+
+// medianAbsoluteDeviation is the median absolute deviation of the values from
+// their median, scaled to estimate their standard deviation. NaN values are
+// ignored.
+func medianAbsoluteDeviation(values []float64) float64 {
+	median := aggregate.Median(values)
+	deviations := make([]float64, len(values))
+	for i, value := range values {
+		deviations[i] = math.Abs(value - median)
+	}
+	return madScale * aggregate.Median(deviations)
+}
+
+// score is the number of spreads by which the value deviates from the center.
+// A value which equals the center has a score of zero, even if the spread is
+// zero.
+func score(value float64, center float64, spread float64) float64 {
+	if value == center {
+		return 0
+	}
+	return (value - center) / spread
+}
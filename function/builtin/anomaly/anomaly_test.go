@@ -0,0 +1,130 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomaly
+
+import (
+	"testing"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/testing_support/assert"
+
+	"golang.org/x/net/context"
+)
+
+type literal struct {
+	value function.Value
+}
+
+func (lit literal) ExpressionString(mode function.DescriptionMode) string {
+	return "<literal>"
+}
+
+func (lit literal) Evaluate(context function.EvaluationContext) (function.Value, error) {
+	return lit.value, nil
+}
+
+func testContext(t *testing.T) function.EvaluationContext {
+	timerange, err := api.NewSnappedTimerange(0, 4*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	return function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+}
+
+func TestRollingScores(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		fun    function.Function
+		values []float64 // includes the window of extra slots before the timerange
+		scores []float64
+	}{
+		{"zscore", ZScore, []float64{1, 3, 1, 3, 1, 3, 1, 3, 9}, []float64{-1, 1, -1, 1, 7}},
+		{"mad", MAD, []float64{1, 3, 1, 3, 1, 3, 1, 3, 9}, []float64{-0.6745, 0.6745, -0.6745, 0.6745, 4.7215}},
+		{"zscore after a spike", ZScore, []float64{5, 5, 5, 100, 5, 5, 5, 5, 5}, []float64{-0.5774, -0.5774, -0.5774, -0.5774, 0}},
+		{"mad after a spike", MAD, []float64{5, 5, 5, 100, 5, 5, 5, 5, 5}, []float64{0, 0, 0, 0, 0}},
+	} {
+		a := assert.New(t).Contextf("%s", test.name)
+		list := api.SeriesList{
+			Series: []api.Timeseries{{Values: test.values, TagSet: api.TagSet{"host": "a"}}},
+		}
+		ctx := testContext(t)
+		window := literal{function.NewDurationValue("2m", 2*time.Minute)}
+		resultValue, err := test.fun.Run(ctx, []function.Expression{literal{function.SeriesListValue(list)}, window}, function.Groups{})
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, convErr := resultValue.ToSeriesList(ctx.Timerange())
+		if convErr != nil {
+			t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+		}
+		a.EqInt(len(result.Series), 1)
+		a.EqFloatArray(result.Series[0].Values, test.scores, 1e-4)
+		a.Eq(result.Series[0].TagSet, api.TagSet{"host": "a"})
+	}
+}
+
+func TestRollingScores_ShortWindow(t *testing.T) {
+	list := literal{function.SeriesListValue(api.SeriesList{})}
+	window := literal{function.NewDurationValue("30s", 30*time.Second)}
+	if _, err := ZScore.Run(testContext(t), []function.Expression{list, window}, function.Groups{}); err == nil {
+		t.Errorf("expected an error for a window of one slot")
+	}
+}
+
+func TestOutliers(t *testing.T) {
+	list := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{1, 2, 3}, TagSet: api.TagSet{"dc": "west", "host": "a"}},
+			{Values: []float64{1, 2, 3}, TagSet: api.TagSet{"dc": "west", "host": "b"}},
+			{Values: []float64{1, 2, 3}, TagSet: api.TagSet{"dc": "west", "host": "c"}},
+			{Values: []float64{1, 2, 30}, TagSet: api.TagSet{"dc": "west", "host": "d"}},
+			{Values: []float64{1, 1, 1}, TagSet: api.TagSet{"dc": "east", "host": "e"}},
+			{Values: []float64{1, 1, 1.5}, TagSet: api.TagSet{"dc": "east", "host": "f"}},
+		},
+	}
+	for _, test := range []struct {
+		groups   function.Groups
+		expected []string
+	}{
+		{function.Groups{List: []string{"dc"}}, []string{"d"}},
+		// Since most of the series are identical at the second point, both of the east series deviate then.
+		{function.Groups{}, []string{"d", "e", "f"}},
+		{function.Groups{List: []string{"host"}, Collapses: true}, []string{"d"}},
+	} {
+		a := assert.New(t).Contextf("%+v", test.groups)
+		arguments := []function.Expression{literal{function.SeriesListValue(list)}, literal{function.ScalarValue(3)}}
+		resultValue, err := Outliers.Run(testContext(t), arguments, test.groups)
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, convErr := resultValue.ToSeriesList(testContext(t).Timerange())
+		if convErr != nil {
+			t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+		}
+		hosts := []string{}
+		for _, series := range result.Series {
+			hosts = append(hosts, series.TagSet["host"])
+		}
+		a.Eq(hosts, test.expected)
+	}
+	arguments := []function.Expression{literal{function.SeriesListValue(list)}, literal{function.ScalarValue(-1)}}
+	if _, err := Outliers.Run(testContext(t), arguments, function.Groups{}); err == nil {
+		t.Errorf("expected an error for a negative tolerance")
+	}
+}
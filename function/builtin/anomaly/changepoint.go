@@ -0,0 +1,219 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+)
+
+// changepointTag is the tag which names the measurement of each scalar produced
+// by anomaly.largest_changepoint.
+const changepointTag = "changepoint"
+
+// Changepoints marks the level shifts in each series: the value at the first
+// point of each new level is the difference between the mean of the new level
+// and the mean of the previous level, and all other values are zero.
+var Changepoints = function.MakeFunction(
+	"anomaly.changepoints",
+	func(list api.SeriesList, minSegment time.Duration, sensitivity float64, timerange api.Timerange) (api.SeriesList, error) {
+		minSlots, err := changepointParameters("anomaly.changepoints", minSegment, sensitivity, timerange)
+		if err != nil {
+			return api.SeriesList{}, err
+		}
+		result := api.SeriesList{
+			Series: make([]api.Timeseries, len(list.Series)),
+		}
+		for seriesIndex, series := range list.Series {
+			values := make([]float64, len(series.Values))
+			for _, shift := range levelShifts(series.Values, minSlots, sensitivity) {
+				values[shift.index] = shift.magnitude
+			}
+			result.Series[seriesIndex] = api.Timeseries{
+				Values: values,
+				TagSet: series.TagSet,
+				Name:   series.Name,
+			}
+		}
+		return result, nil
+	},
+)
+
+// LargestChangepoint finds the largest level shift (as found by
+// anomaly.changepoints) in each series. Each series gives two scalars, tagged
+// by 'changepoint': its "time" (in milliseconds since the epoch) and its
+// "magnitude". A series without a level shift has a magnitude of zero and a
+// time of NaN.
+var LargestChangepoint = function.MakeFunction(
+	"anomaly.largest_changepoint",
+	func(list api.SeriesList, minSegment time.Duration, sensitivity float64, timerange api.Timerange) (function.ScalarSet, error) {
+		minSlots, err := changepointParameters("anomaly.largest_changepoint", minSegment, sensitivity, timerange)
+		if err != nil {
+			return nil, err
+		}
+		result := function.ScalarSet{}
+		for _, series := range list.Series {
+			largest := levelShift{index: -1}
+			for _, shift := range levelShifts(series.Values, minSlots, sensitivity) {
+				if math.Abs(shift.magnitude) > math.Abs(largest.magnitude) {
+					largest = shift
+				}
+			}
+			when := math.NaN()
+			if largest.index >= 0 {
+				when = float64(timerange.StartMillis() + int64(largest.index)*timerange.ResolutionMillis())
+			}
+			for _, measure := range []struct {
+				name  string
+				value float64
+			}{
+				{"time", when},
+				{"magnitude", largest.magnitude},
+			} {
+				tagSet := series.TagSet.Clone()
+				tagSet[changepointTag] = measure.name
+				result = append(result, function.TaggedScalar{
+					TagSet: tagSet,
+					Value:  measure.value,
+				})
+			}
+		}
+		return result, nil
+	},
+)
+
+// changepointParameters checks the parameters of the change-point functions,
+// converting the minimum segment length into slots.
+func changepointParameters(name string, minSegment time.Duration, sensitivity float64, timerange api.Timerange) (int, error) {
+	if !(sensitivity > 0) {
+		return 0, fmt.Errorf("%s expects a positive sensitivity but got %g", name, sensitivity)
+	}
+	if minSegment < 0 {
+		return 0, fmt.Errorf("%s expects a non-negative minimum segment length but got %s", name, minSegment.String())
+	}
+	minSlots := int(float64(minSegment)/float64(timerange.Resolution()) + 0.5)
+	if minSlots < 1 {
+		minSlots = 1
+	}
+	return minSlots, nil
+}
+
+// levelShift is the start of a new level in a series.
+type levelShift struct {
+	index     int     // the index of the first value at the new level
+	magnitude float64 // the mean of the new level minus the mean of the previous level
+}
+
+// levelShifts segments the values into levels of at least minSlots values using
+// the PELT algorithm, which finds the segmentation minimizing the squared error
+// of each value from the mean of its segment plus a penalty for each segment.
+// The penalty grows with the noise in the values, and shrinks with the
+// sensitivity. NaN values are ignored.
+func levelShifts(values []float64, minSlots int, sensitivity float64) []levelShift {
+	indices := []int{}
+	present := []float64{}
+	for i, value := range values {
+		if !math.IsNaN(value) {
+			indices = append(indices, i)
+			present = append(present, value)
+		}
+	}
+	n := len(present)
+	noise := noiseVariance(present)
+	if n < 2*minSlots || noise == 0 {
+		return nil
+	}
+	penalty := 2 * math.Log(float64(n)) * noise / sensitivity
+
+	sums := make([]float64, n+1)
+	squares := make([]float64, n+1)
+	for i, value := range present {
+		sums[i+1] = sums[i] + value
+		squares[i+1] = squares[i] + value*value
+	}
+	// cost is the squared error of the values in [from, to) from their mean.
+	cost := func(from int, to int) float64 {
+		sum := sums[to] - sums[from]
+		return squares[to] - squares[from] - sum*sum/float64(to-from)
+	}
+
+	// best[t] is the least penalized cost of the values before t, whose last segment starts at previous[t].
+	best := make([]float64, n+1)
+	previous := make([]int, n+1)
+	best[0] = -penalty
+	candidates := []int{0}
+	for t := minSlots; t <= n; t++ {
+		best[t] = math.Inf(1)
+		for _, s := range candidates {
+			if t-s < minSlots {
+				continue
+			}
+			if total := best[s] + cost(s, t) + penalty; total < best[t] {
+				best[t] = total
+				previous[t] = s
+			}
+		}
+		// Candidates which cannot begin the last segment of an optimal segmentation are pruned.
+		remaining := candidates[:0]
+		for _, s := range candidates {
+			if t-s < minSlots || best[s]+cost(s, t) <= best[t] {
+				remaining = append(remaining, s)
+			}
+		}
+		candidates = remaining
+		if !math.IsInf(best[t], 1) {
+			candidates = append(candidates, t)
+		}
+	}
+
+	boundaries := []int{}
+	for t := n; t > 0; t = previous[t] {
+		boundaries = append([]int{previous[t]}, boundaries...)
+	}
+	boundaries = append(boundaries, n)
+	shifts := []levelShift{}
+	for i := 1; i+1 < len(boundaries); i++ {
+		before := (sums[boundaries[i]] - sums[boundaries[i-1]]) / float64(boundaries[i]-boundaries[i-1])
+		after := (sums[boundaries[i+1]] - sums[boundaries[i]]) / float64(boundaries[i+1]-boundaries[i])
+		shifts = append(shifts, levelShift{index: indices[boundaries[i]], magnitude: after - before})
+	}
+	return shifts
+}
+
+// noiseVariance robustly estimates the variance of the noise in the values from
+// the median absolute deviation of their successive differences, which is not
+// affected by level shifts. If most of the differences are zero, the mean
+// square of the differences is used instead.
+func noiseVariance(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	differences := make([]float64, len(values)-1)
+	squares := 0.0
+	for i := range differences {
+		differences[i] = values[i+1] - values[i]
+		squares += differences[i] * differences[i]
+	}
+	// The difference of two values has twice the variance of each.
+	deviation := medianAbsoluteDeviation(differences)
+	if deviation == 0 {
+		return squares / float64(len(differences)) / 2
+	}
+	return deviation * deviation / 2
+}
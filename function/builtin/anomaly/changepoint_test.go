@@ -0,0 +1,118 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomaly
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/testing_support/assert"
+
+	"golang.org/x/net/context"
+)
+
+func Test_levelShifts(t *testing.T) {
+	nan := math.NaN()
+	noisy := make([]float64, 20)
+	for i := range noisy {
+		noisy[i] = 10 + 0.1*float64(i%2*2-1)
+		if i >= 12 {
+			noisy[i] += 3
+		}
+	}
+	for _, test := range []struct {
+		name        string
+		values      []float64
+		minSlots    int
+		sensitivity float64
+		expected    []levelShift
+	}{
+		{"step", []float64{1, 1, 1, 1, 5, 5, 5, 5}, 1, 1, []levelShift{{4, 4}}},
+		{"noisy step", noisy, 3, 1, []levelShift{{12, 3}}},
+		{"step with gaps", []float64{1, nan, 1, 1, nan, 5, 5, 5}, 1, 1, []levelShift{{5, 4}}},
+		{"two steps", []float64{0, 0, 0, 0, 2, 2, 2, 2, -5, -5, -5, -5}, 1, 1, []levelShift{{8, -6}}},
+		{"two steps, sensitively", []float64{0, 0, 0, 0, 2, 2, 2, 2, -5, -5, -5, -5}, 1, 2, []levelShift{{4, 2}, {8, -7}}},
+		{"insensitive", []float64{1, 1, 1, 1, 5, 5, 5, 5}, 1, 0.01, []levelShift{}},
+		{"spike", []float64{5, 5, 5, 9, 5, 5, 5}, 2, 1, []levelShift{}},
+		{"short segments", []float64{1, 1, 5, 5, 5, 5, 5, 5}, 3, 1, []levelShift{{3, 8.0 / 3}}},
+		{"too short", []float64{1, 1, 5, 5}, 3, 1, []levelShift{}},
+		{"constant", []float64{3, 3, 3, 3, 3}, 1, 1, []levelShift{}},
+	} {
+		a := assert.New(t).Contextf("%s", test.name)
+		shifts := levelShifts(test.values, test.minSlots, test.sensitivity)
+		a.EqInt(len(shifts), len(test.expected))
+		for i := range shifts {
+			if i < len(test.expected) {
+				a.EqInt(shifts[i].index, test.expected[i].index)
+				a.EqFloat(shifts[i].magnitude, test.expected[i].magnitude, 1e-7)
+			}
+		}
+	}
+}
+
+func TestChangepointFunctions(t *testing.T) {
+	timerange, err := api.NewSnappedTimerange(0, 11*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+	list := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{0, 0, 0, 0, 2, 2, 2, 2, -5, -5, -5, -5}, TagSet: api.TagSet{"host": "a"}},
+			{Values: []float64{3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3}, TagSet: api.TagSet{"host": "b"}},
+		},
+	}
+	arguments := []function.Expression{
+		literal{function.SeriesListValue(list)},
+		literal{function.NewDurationValue("30s", 30*time.Second)},
+		literal{function.ScalarValue(2)},
+	}
+
+	a := assert.New(t)
+	changepoints, err := Changepoints.Run(ctx, arguments, function.Groups{})
+	a.CheckError(err)
+	marked, convErr := changepoints.ToSeriesList(timerange)
+	if convErr != nil {
+		t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+	}
+	a.EqInt(len(marked.Series), 2)
+	a.EqFloatArray(marked.Series[0].Values, []float64{0, 0, 0, 0, 2, 0, 0, 0, -7, 0, 0, 0}, 1e-7)
+	a.EqFloatArray(marked.Series[1].Values, make([]float64, 12), 1e-7)
+
+	largest, err := LargestChangepoint.Run(ctx, arguments, function.Groups{})
+	a.CheckError(err)
+	scalars, convErr := largest.ToScalarSet()
+	if convErr != nil {
+		t.Fatalf("error converting to scalar set: %s", convErr.WithContext("test case"))
+	}
+	a.EqInt(len(scalars), 4)
+	a.Eq(scalars[0].TagSet, api.TagSet{"host": "a", "changepoint": "time"})
+	a.EqFloat(scalars[0].Value, 240000, 1e-7)
+	a.Eq(scalars[1].TagSet, api.TagSet{"host": "a", "changepoint": "magnitude"})
+	a.EqFloat(scalars[1].Value, -7, 1e-7)
+	a.Eq(scalars[2].TagSet, api.TagSet{"host": "b", "changepoint": "time"})
+	if !math.IsNaN(scalars[2].Value) {
+		t.Errorf("expected the time of a series without changepoints to be NaN but got %g", scalars[2].Value)
+	}
+	a.EqFloat(scalars[3].Value, 0, 1e-7)
+
+	arguments[2] = literal{function.ScalarValue(0)}
+	if _, err := Changepoints.Run(ctx, arguments, function.Groups{}); err == nil {
+		t.Errorf("expected an error for a sensitivity of zero")
+	}
+}
@@ -117,3 +117,48 @@ func ThresholdByRecent(list api.SeriesList, threshold float64, summary func([]fl
 		Series: result,
 	}
 }
+
+// TopWithOther keeps the `count` series whose `summary` of their recent points is highest, like ByRecent, and replaces
+// the others with a single series which combines them with the `aggregator`. The combined series has the tags of the
+// others, but those which are not shared by every series in the list are set to the `other` sentinel value, so that it
+// is marked even when it combines a single series.
+func TopWithOther(list api.SeriesList, count int, summary func([]float64) float64, aggregator func([]float64) float64, other string, slots int) api.SeriesList {
+	sorted, _ := sortSeriesRecent(list, summary, false, slots)
+	if len(sorted) <= count {
+		return api.SeriesList{
+			Series: sorted,
+		}
+	}
+	rest := sorted[count:]
+
+	values := make([]float64, len(rest[0].Values))
+	column := make([]float64, len(rest))
+	for t := range values {
+		for i := range rest {
+			column[i] = rest[i].Values[t]
+		}
+		values[t] = aggregator(column)
+	}
+
+	tagSet := api.NewTagSet()
+	for _, series := range rest {
+		for tag := range series.TagSet {
+			tagSet[tag] = rest[0].TagSet[tag]
+		}
+	}
+	for tag, value := range tagSet {
+		for _, series := range sorted {
+			if seriesValue, ok := series.TagSet[tag]; !ok || seriesValue != value {
+				tagSet[tag] = other
+				break
+			}
+		}
+	}
+
+	return api.SeriesList{
+		Series: append(sorted[:count], api.Timeseries{
+			Values: values,
+			TagSet: tagSet,
+		}),
+	}
+}
@@ -15,12 +15,15 @@
 package filter
 
 import (
+	"fmt"
 	"math"
 	"sort"
 	"testing"
 
 	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
 	"github.com/square/metrics/function/builtin/aggregate"
+	"github.com/square/metrics/query/predicate"
 	"github.com/square/metrics/testing_support/assert"
 )
 
@@ -319,3 +322,102 @@ func TestFilterListNaN(t *testing.T) {
 	sort.Sort(array)
 	a.Eq(array.index, []int{4, 2, 5, 6, 11, 11})
 }
+
+func TestTopWithOther(t *testing.T) {
+	list := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{1, 2, 4}, TagSet: api.TagSet{"host": "a", "dc": "west"}},
+			{Values: []float64{5, 5, 5}, TagSet: api.TagSet{"host": "b", "dc": "west"}},
+			{Values: []float64{0, 1, 0}, TagSet: api.TagSet{"host": "c", "dc": "west"}},
+			{Values: []float64{2, 2, 2}, TagSet: api.TagSet{"host": "d", "dc": "west", "app": "x"}},
+		},
+	}
+	for _, test := range []struct {
+		count    int
+		slots    int
+		expected []api.Timeseries
+	}{
+		{
+			count: 1,
+			slots: 3,
+			expected: []api.Timeseries{
+				{Values: []float64{5, 5, 5}, TagSet: api.TagSet{"host": "b", "dc": "west"}},
+				{Values: []float64{3, 5, 6}, TagSet: api.TagSet{"host": "rest", "dc": "west", "app": "rest"}},
+			},
+		},
+		{
+			count: 2,
+			slots: 1,
+			expected: []api.Timeseries{
+				{Values: []float64{5, 5, 5}, TagSet: api.TagSet{"host": "b", "dc": "west"}},
+				{Values: []float64{1, 2, 4}, TagSet: api.TagSet{"host": "a", "dc": "west"}},
+				{Values: []float64{2, 3, 2}, TagSet: api.TagSet{"host": "rest", "dc": "west", "app": "rest"}},
+			},
+		},
+		{
+			count: 3,
+			slots: 3,
+			expected: []api.Timeseries{
+				{Values: []float64{5, 5, 5}, TagSet: api.TagSet{"host": "b", "dc": "west"}},
+				{Values: []float64{1, 2, 4}, TagSet: api.TagSet{"host": "a", "dc": "west"}},
+				{Values: []float64{2, 2, 2}, TagSet: api.TagSet{"host": "d", "dc": "west", "app": "x"}},
+				// A single remaining series is still marked by the sentinel.
+				{Values: []float64{0, 1, 0}, TagSet: api.TagSet{"host": "rest", "dc": "west"}},
+			},
+		},
+		{
+			count: 4,
+			slots: 3,
+			expected: []api.Timeseries{
+				{Values: []float64{5, 5, 5}, TagSet: api.TagSet{"host": "b", "dc": "west"}},
+				{Values: []float64{1, 2, 4}, TagSet: api.TagSet{"host": "a", "dc": "west"}},
+				{Values: []float64{2, 2, 2}, TagSet: api.TagSet{"host": "d", "dc": "west", "app": "x"}},
+				{Values: []float64{0, 1, 0}, TagSet: api.TagSet{"host": "c", "dc": "west"}},
+			},
+		},
+	} {
+		a := assert.New(t).Contextf("count %d over %d slots", test.count, test.slots)
+		result := TopWithOther(list, test.count, aggregate.Mean, aggregate.Sum, "rest", test.slots)
+		a.EqInt(len(result.Series), len(test.expected))
+		if len(result.Series) != len(test.expected) {
+			continue
+		}
+		for i := range result.Series {
+			a.Eq(result.Series[i].TagSet, test.expected[i].TagSet)
+			a.EqFloatArray(result.Series[i].Values, test.expected[i].Values, 1e-7)
+		}
+	}
+}
+
+func TestTag(t *testing.T) {
+	a := assert.New(t)
+	list := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{1, 2, 3}, TagSet: api.TagSet{"dc": "west"}},
+			{Values: []float64{4, 5, 6}, TagSet: api.TagSet{"dc": "east"}},
+		},
+	}
+	// The parser is given by the evaluation context, so it is stubbed here.
+	parser := func(text string) (predicate.Predicate, error) {
+		if text != "dc = 'west'" {
+			return nil, fmt.Errorf("unexpected predicate %q", text)
+		}
+		return predicate.ListMatcher{Tag: "dc", Values: []string{"west"}}, nil
+	}
+	arguments := func(text string) []function.Expression {
+		return []function.Expression{valueExpression{function.SeriesListValue(list)}, valueExpression{function.StringValue(text)}}
+	}
+	context := function.EvaluationContextBuilder{PredicateParser: parser}.Build()
+
+	result, err := Tag.Run(context, arguments("dc = 'west'"), function.Groups{})
+	a.CheckError(err)
+	if err == nil {
+		a.Eq(result, function.SeriesListValue(api.SeriesList{Series: list.Series[:1]}))
+	}
+	if _, err := Tag.Run(context, arguments("dc = "), function.Groups{}); err == nil {
+		t.Errorf("expected an error for an invalid predicate")
+	}
+	if _, err := Tag.Run(function.EvaluationContextBuilder{}.Build(), arguments("dc = 'west'"), function.Groups{}); err == nil {
+		t.Errorf("expected an error without a predicate parser")
+	}
+}
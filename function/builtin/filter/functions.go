@@ -0,0 +1,143 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/builtin/join"
+	"github.com/square/metrics/function/builtin/summary"
+)
+
+// Tag keeps the series whose tags satisfy the predicate, which is written as in
+// the where clause of a query.
+var Tag = function.MakeFunction(
+	"filter.tag",
+	func(context function.EvaluationContext, list api.SeriesList, text string) (api.SeriesList, error) {
+		matcher, err := context.ParsePredicate(text)
+		if err != nil {
+			return api.SeriesList{}, fmt.Errorf("filter.tag given invalid predicate %q: %s", text, err.Error())
+		}
+		result := api.SeriesList{
+			Series: []api.Timeseries{},
+		}
+		for _, series := range list.Series {
+			if matcher.Apply(series.TagSet) {
+				result.Series = append(result.Series, series)
+			}
+		}
+		return result, nil
+	},
+)
+
+// Comparisons are the operators which filter.where can compare summaries with.
+var Comparisons = map[string]func(float64, float64) bool{
+	"<":  func(x float64, y float64) bool { return x < y },
+	"<=": func(x float64, y float64) bool { return x <= y },
+	">":  func(x float64, y float64) bool { return x > y },
+	">=": func(x float64, y float64) bool { return x >= y },
+	"=":  func(x float64, y float64) bool { return x == y },
+	"!=": func(x float64, y float64) bool { return x != y },
+}
+
+// Where keeps the series whose summary (by the named summarize function, which
+// is given the optional duration) compares to the threshold by the operator.
+// Series whose summary is NaN are removed, except by "!=".
+var Where = function.MakeFunction(
+	"filter.where",
+	func(context function.EvaluationContext, list api.SeriesList, summaryName string, operator string, threshold float64, optionalDuration *time.Duration) (api.SeriesList, error) {
+		compare, ok := Comparisons[operator]
+		if !ok {
+			return api.SeriesList{}, fmt.Errorf("filter.where expects one of '<', '<=', '>', '>=', '=' or '!=' but got '%s'", operator)
+		}
+		if !strings.Contains(summaryName, ".") {
+			summaryName = "summarize." + summaryName
+		}
+		if !strings.HasPrefix(summaryName, "summarize.") {
+			return api.SeriesList{}, fmt.Errorf("filter.where expects a summarize function but got '%s'", summaryName)
+		}
+		summary, ok := context.RegistryGetFunction(summaryName)
+		if !ok {
+			return api.SeriesList{}, fmt.Errorf("filter.where given unknown summarize function '%s'", summaryName)
+		}
+		arguments := []function.Expression{valueExpression{function.SeriesListValue(list)}}
+		if optionalDuration != nil {
+			arguments = append(arguments, valueExpression{function.NewDurationValue(optionalDuration.String(), *optionalDuration)})
+		}
+		summaries, err := summary.Run(context, arguments, function.Groups{})
+		if err != nil {
+			return api.SeriesList{}, err
+		}
+		scalars, convErr := summaries.ToScalarSet()
+		if convErr != nil {
+			return api.SeriesList{}, convErr.WithContext(fmt.Sprintf("the result of %s in filter.where", summaryName))
+		}
+		if len(scalars) != len(list.Series) {
+			return api.SeriesList{}, fmt.Errorf("filter.where expects %s to summarize each of the %d series but it gave %d values", summaryName, len(list.Series), len(scalars))
+		}
+		result := api.SeriesList{
+			Series: []api.Timeseries{},
+		}
+		for i, series := range list.Series {
+			if compare(scalars[i].Value, threshold) {
+				result.Series = append(result.Series, series)
+			}
+		}
+		return result, nil
+	},
+)
+
+// CorrelatedWith keeps the series which are correlated (or anti-correlated)
+// with a series of the reference which they join with, so that the magnitude
+// of their Pearson correlation coefficient is at least the threshold.
+var CorrelatedWith = function.MakeFunction(
+	"filter.correlated_with",
+	func(list api.SeriesList, reference api.SeriesList, threshold float64) (api.SeriesList, error) {
+		if !(0 <= threshold && threshold <= 1) {
+			return api.SeriesList{}, fmt.Errorf("filter.correlated_with expects a threshold between 0 and 1 but got %g", threshold)
+		}
+		result := api.SeriesList{
+			Series: []api.Timeseries{},
+		}
+		for _, series := range list.Series {
+			single := api.SeriesList{Series: []api.Timeseries{series}}
+			for _, row := range join.Join([]api.SeriesList{single, reference}).Rows {
+				if math.Abs(summary.Pearson(row.Row[0].Values, row.Row[1].Values)) >= threshold {
+					result.Series = append(result.Series, series)
+					break
+				}
+			}
+		}
+		return result, nil
+	},
+)
+
+// valueExpression is an expression for a value which has already been evaluated.
+type valueExpression struct {
+	value function.Value
+}
+
+func (e valueExpression) ExpressionString(mode function.DescriptionMode) string {
+	return "<value>"
+}
+
+func (e valueExpression) Evaluate(context function.EvaluationContext) (function.Value, error) {
+	return e.value, nil
+}
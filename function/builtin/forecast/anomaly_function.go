@@ -25,15 +25,43 @@ import (
 // FunctionPeriodicAnomalyMaker makes anomaly-measurement functions that return
 // simple p-values for deviations from the predicted model. In order to make this
 // procedure mostly automatic, it performs a join on the original tagsets to
-// match them up with their predictions.
-func FunctionPeriodicAnomalyMaker(name string, model function.MetricFunction) function.MetricFunction {
-	if model.MinArguments < 2 {
-		panic("FunctionAnomalyMaker requires that the model argument take at least two parameters; series and period.")
+// match them up with their predictions. The deviations are measured separately
+// at each point of the period which the model used for each series.
+func FunctionPeriodicAnomalyMaker(name string, model PeriodicModel) function.MetricFunction {
+	return anomalyMaker(name, model.Function(), model.compute)
+}
+
+// FunctionAnomalyMaker makes anomaly-measurement functions like FunctionPeriodicAnomalyMaker, for models which
+// have no period. The deviations from the predicted model are measured across all points together.
+func FunctionAnomalyMaker(name string, model function.MetricFunction) function.MetricFunction {
+	if model.MinArguments < 1 {
+		panic("FunctionAnomalyMaker requires that the model argument take at least one parameter; series.")
 	}
+	return anomalyMaker(name, model, func(context function.EvaluationContext, arguments []function.Expression) (api.SeriesList, []int, error) {
+		predictionValue, err := model.Compute(context, arguments, function.Groups{})
+		if err != nil {
+			return api.SeriesList{}, nil, err
+		}
+		prediction, convErr := predictionValue.ToSeriesList(context.Timerange())
+		if convErr != nil {
+			return api.SeriesList{}, nil, convErr.WithContext("in anomaly function - model")
+		}
+		periods := make([]int, len(prediction.Series))
+		for i := range periods {
+			periods[i] = 1
+		}
+		return prediction, periods, nil
+	})
+}
+
+// anomalyMaker makes an anomaly-measurement function for the model, whose predictions are computed by `predict` along
+// with the period (in slots) of each predicted series.
+func anomalyMaker(name string, model function.MetricFunction, predict func(function.EvaluationContext, []function.Expression) (api.SeriesList, []int, error)) function.MetricFunction {
 	return function.MetricFunction{
 		FunctionName: name,
 		MinArguments: model.MinArguments,
 		MaxArguments: model.MaxArguments,
+		Signature:    model.Signature,
 		Compute: func(context function.EvaluationContext, arguments []function.Expression, groups function.Groups) (function.Value, error) {
 			original, err := function.EvaluateToSeriesList(arguments[0], context)
 			if err != nil {
@@ -42,19 +70,10 @@ func FunctionPeriodicAnomalyMaker(name string, model function.MetricFunction) fu
 			// TODO: improve sharing by using the `original` value as the first argument to the arguments,
 			// since the context is known to be the same and therefore it should evaluate identically.
 			// There is currently no standard "literal series list node" or similar that we could use for this purepose.
-			predictionValue, err := model.Compute(context, arguments, groups)
+			prediction, periods, err := predict(context, arguments)
 			if err != nil {
 				return nil, err // TODO: add decoration to describe it's coming from the anomaly function
 			}
-			prediction, convErr := predictionValue.ToSeriesList(context.Timerange())
-			if convErr != nil {
-				return nil, convErr.WithContext("in anomaly function - model")
-			}
-			period, err := function.EvaluateToDuration(arguments[1], context)
-			if err != nil {
-				return nil, err
-			}
-			periodSlots := int(period / context.Timerange().Resolution())
 			// Now we need to match up 'original' and 'prediction'
 			// We'll use a hashmap for now.
 			// TODO: clean this up to hog less memory
@@ -66,7 +85,7 @@ func FunctionPeriodicAnomalyMaker(name string, model function.MetricFunction) fu
 			result := make([]api.Timeseries, len(prediction.Series))
 			for i, series := range prediction.Series {
 				result[i] = series
-				result[i].Values, err = periodicStandardDeviationsFromExpected(lookup[series.TagSet.Serialize()], series.Values, periodSlots)
+				result[i].Values, err = periodicStandardDeviationsFromExpected(lookup[series.TagSet.Serialize()], series.Values, periods[i])
 				if err != nil {
 					return nil, err
 				}
@@ -77,8 +96,11 @@ func FunctionPeriodicAnomalyMaker(name string, model function.MetricFunction) fu
 	}
 }
 
-var FunctionAnomalyRollingMultiplicativeHoltWinters = FunctionPeriodicAnomalyMaker("forecast.anomaly_rolling_multiplicative_holt_winters", FunctionRollingMultiplicativeHoltWinters)
-var FunctionAnomalyRollingSeasonal = FunctionPeriodicAnomalyMaker("forecast.anomaly_rolling_seasonal", FunctionRollingSeasonal)
+var FunctionAnomalyRollingMultiplicativeHoltWinters = FunctionPeriodicAnomalyMaker("forecast.anomaly_rolling_multiplicative_holt_winters", RollingMultiplicativeHoltWintersModel)
+var FunctionAnomalyRollingSeasonal = FunctionPeriodicAnomalyMaker("forecast.anomaly_rolling_seasonal", RollingSeasonalModel)
+var FunctionAnomalyRollingAdditiveHoltWinters = FunctionPeriodicAnomalyMaker("forecast.anomaly_rolling_additive_holt_winters", RollingAdditiveHoltWintersModel)
+var FunctionAnomalySeasonalTrend = FunctionPeriodicAnomalyMaker("forecast.anomaly_seasonal_trend", SeasonalTrendModel)
+var FunctionAnomalyRollingDoubleExponentialSmoothing = FunctionAnomalyMaker("forecast.anomaly_rolling_double_exponential_smoothing", FunctionRollingDoubleExponentialSmoothing)
 
 func standardDeviationsFromExpected(correct []float64, estimate []float64) ([]float64, error) {
 	if len(correct) != len(estimate) {
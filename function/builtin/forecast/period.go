@@ -0,0 +1,156 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forecast
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+)
+
+// autoPeriod is the period argument which asks a forecasting function to detect the period of each series.
+const autoPeriod = "auto"
+
+// periodArgument is the evaluated period argument of a forecasting function.
+type periodArgument struct {
+	name  string // the name of the forecasting function
+	slots int    // the number of slots in the period, unless it is automatic
+	auto  bool
+}
+
+// evaluatePeriod evaluates the period argument of the named forecasting function. The argument may be 'auto' in place
+// of a duration, in which case the period of each series is detected by DominantPeriod.
+func evaluatePeriod(name string, context function.EvaluationContext, value function.Value) (periodArgument, error) {
+	if text, convErr := value.ToString(); convErr == nil && text == autoPeriod {
+		return periodArgument{name: name, auto: true}, nil
+	}
+	duration, convErr := value.ToDuration()
+	if convErr != nil {
+		return periodArgument{}, convErr.WithContext(fmt.Sprintf("the period of %s (which may also be 'auto')", name))
+	}
+	slots := int(duration / context.Timerange().Resolution())
+	if slots <= 0 {
+		return periodArgument{}, fmt.Errorf("%s expects the period parameter to mean at least one slot", name) // TODO: use a structured error
+	}
+	return periodArgument{name: name, slots: slots}, nil
+}
+
+// withPeriodParameter describes the parameters of the forecasting function like those of the periodic models: the first
+// is a series list, though it is taken as an expression so that it can be evaluated over extra training time, and the
+// second is a duration, though it is taken as a value so that it may also be 'auto'.
+func withPeriodParameter(fun function.MetricFunction) function.MetricFunction {
+	fun.Signature.Parameters[0].Kind = "series list"
+	fun.Signature.Parameters[1].Kind = "duration"
+	return fun
+}
+
+// of is the period of the series in slots. An automatic period which cannot be detected is one slot.
+func (p periodArgument) of(context function.EvaluationContext, series api.Timeseries) int {
+	if !p.auto {
+		return p.slots
+	}
+	slots := DominantPeriod(series.Values, len(series.Values)/2)
+	if slots == 0 {
+		context.AddNote(fmt.Sprintf("%s(%v): no period could be detected, so a period of one slot was used", p.name, series.TagSet))
+		return 1
+	}
+	return slots
+}
+
+// DominantPeriod finds the period (in slots, between 2 and maxPeriod) with the most power in the periodogram of the
+// values, after removing their linear trend. Missing values are treated as lying on the trend. The periodogram is
+// computed by a zero-padded FFT, and the power of each period is that of the nearest frequency. Since a train of
+// spikes has as much power at each harmonic as at its period, the longest multiple of the strongest period with
+// nearly as much power is chosen instead. It is 0 if the values have fewer than two full cycles of any such
+// period, or have no variation apart from their trend.
+func DominantPeriod(ys []float64, maxPeriod int) int {
+	if maxPeriod > len(ys)/2 {
+		maxPeriod = len(ys) / 2
+	}
+	if maxPeriod < 2 {
+		return 0
+	}
+	trend := Linear(ys)
+	// Padding to (at least) eight times the length makes the frequencies fine enough to tell apart nearby periods.
+	size := 1
+	for size < 8*len(ys) {
+		size *= 2
+	}
+	spectrum := make([]complex128, size)
+	energy := 0.0
+	for i, y := range ys {
+		if !math.IsNaN(y) && !math.IsNaN(trend[i]) {
+			residual := y - trend[i]
+			spectrum[i] = complex(residual, 0)
+			energy += residual * residual
+		}
+	}
+	if energy == 0 {
+		return 0
+	}
+	fft(spectrum)
+	powers := make([]float64, maxPeriod+1)
+	strongest := 0
+	for p := 2; p <= maxPeriod; p++ {
+		coefficient := spectrum[int(float64(size)/float64(p)+0.5)]
+		powers[p] = real(coefficient)*real(coefficient) + imag(coefficient)*imag(coefficient)
+		if powers[p] > powers[strongest] {
+			strongest = p
+		}
+	}
+	if powers[strongest] <= 1e-9*energy {
+		// The remaining power is only rounding error.
+		return 0
+	}
+	result := strongest
+	for p := 2 * strongest; p <= maxPeriod; p += strongest {
+		if powers[p] >= 0.9*powers[strongest] {
+			result = p
+		}
+	}
+	return result
+}
+
+// fft replaces the values (whose length must be a power of two) with their discrete Fourier transform, using the
+// iterative radix-2 Cooley-Tukey algorithm.
+func fft(values []complex128) {
+	n := len(values)
+	// Permute the values into bit-reversed order.
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j |= bit
+		if i < j {
+			values[i], values[j] = values[j], values[i]
+		}
+	}
+	for length := 2; length <= n; length <<= 1 {
+		angle := -2 * math.Pi / float64(length)
+		step := complex(math.Cos(angle), math.Sin(angle))
+		for start := 0; start < n; start += length {
+			twiddle := complex(1, 0)
+			for k := 0; k < length/2; k++ {
+				even, odd := values[start+k], twiddle*values[start+k+length/2]
+				values[start+k] = even + odd
+				values[start+k+length/2] = even - odd
+				twiddle *= step
+			}
+		}
+	}
+}
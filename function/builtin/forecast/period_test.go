@@ -0,0 +1,182 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forecast
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/testing_support/assert"
+
+	"golang.org/x/net/context"
+)
+
+func TestDominantPeriod(t *testing.T) {
+	sine := make([]float64, 120)
+	spikes := make([]float64, 120)
+	for i := range sine {
+		sine[i] = 50 + 0.5*float64(i) + 10*math.Sin(2*math.Pi*float64(i)/12)
+		if i%8 == 3 {
+			spikes[i] = 100
+		}
+	}
+	gaps := append([]float64{}, sine...)
+	for i := 0; i < len(gaps); i += 5 {
+		gaps[i] = math.NaN()
+	}
+	for _, test := range []struct {
+		name      string
+		values    []float64
+		maxPeriod int
+		expected  int
+	}{
+		{"sine", sine, 50, 12},
+		{"sine with gaps", gaps, 50, 12},
+		{"spikes", spikes, 50, 8},
+		{"limited", spikes, 6, 4},
+		{"line", []float64{1, 2, 3, 4, 5, 6, 7, 8}, 4, 0},
+		{"short", []float64{1, 5, 1}, 4, 0},
+	} {
+		if actual := DominantPeriod(test.values, test.maxPeriod); actual != test.expected {
+			t.Errorf("%s: expected a period of %d but got %d", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestEvaluatePeriod(t *testing.T) {
+	timerange, err := api.NewSnappedTimerange(0, 100*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	ctx := function.EvaluationContextBuilder{EvaluationNotes: &function.EvaluationNotes{}, Timerange: timerange, Ctx: context.Background()}.Build()
+	for _, test := range []struct {
+		value    function.Value
+		expected periodArgument
+	}{
+		{function.NewDurationValue("5m", 5*time.Minute), periodArgument{name: "test", slots: 10}},
+		{function.StringValue("auto"), periodArgument{name: "test", auto: true}},
+	} {
+		actual, err := evaluatePeriod("test", ctx, test.value)
+		if err != nil {
+			t.Errorf("unexpected error evaluating period %+v: %s", test.value, err.Error())
+		} else if actual != test.expected {
+			t.Errorf("expected period %+v to be %+v but got %+v", test.value, test.expected, actual)
+		}
+	}
+	for _, invalid := range []function.Value{
+		function.NewDurationValue("10s", 10*time.Second),
+		function.StringValue("often"),
+		// A metric named auto is a series list, not a period.
+		function.SeriesListValue(api.SeriesList{Series: []api.Timeseries{}}),
+	} {
+		if _, err := evaluatePeriod("test", ctx, invalid); err == nil {
+			t.Errorf("expected an error evaluating period %+v", invalid)
+		}
+	}
+
+	auto := periodArgument{name: "test", auto: true}
+	if slots := auto.of(ctx, api.Timeseries{Values: []float64{1, 3, 1, 3, 1, 3, 1, 3}}); slots != 2 {
+		t.Errorf("expected an automatic period of 2 slots but got %d", slots)
+	}
+	if slots := auto.of(ctx, api.Timeseries{Values: []float64{1, 1, 1, 1, 1, 1}}); slots != 1 || len(ctx.Notes()) != 1 {
+		t.Errorf("expected an undetectable automatic period to be 1 slot with a note but got %d and %v", slots, ctx.Notes())
+	}
+}
+
+type literal struct {
+	value function.Value
+}
+
+func (l literal) ExpressionString(mode function.DescriptionMode) string {
+	return "<literal>"
+}
+
+func (l literal) Evaluate(context function.EvaluationContext) (function.Value, error) {
+	return l.value, nil
+}
+
+func TestPeriodicModel(t *testing.T) {
+	a := assert.New(t)
+	values := make([]float64, 32)
+	for i := range values {
+		values[i] = []float64{0, 10, 5, 0}[i%4]
+	}
+	values[13] = 20
+	timerange, err := api.NewSnappedTimerange(0, 31*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	ctx := function.EvaluationContextBuilder{EvaluationNotes: &function.EvaluationNotes{}, Timerange: timerange, Ctx: context.Background()}.Build()
+	list := api.SeriesList{Series: []api.Timeseries{{Values: values, TagSet: api.TagSet{"host": "a"}}}}
+	arguments := []function.Expression{literal{function.SeriesListValue(list)}, literal{function.StringValue("auto")}}
+
+	prediction, periods, err := SeasonalTrendModel.compute(ctx, arguments)
+	a.CheckError(err)
+	a.Eq(periods, []int{4})
+	a.EqInt(len(prediction.Series), 1)
+
+	// The anomaly function measures the deviations with the period which the model used.
+	anomalies, err := FunctionAnomalySeasonalTrend.Run(ctx, arguments, function.Groups{})
+	a.CheckError(err)
+	if err == nil {
+		result, convErr := anomalies.ToSeriesList(timerange)
+		if convErr != nil {
+			t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+		}
+		expected, err := periodicStandardDeviationsFromExpected(values, prediction.Series[0].Values, 4)
+		a.CheckError(err)
+		a.EqFloatArray(result.Series[0].Values, expected, 1e-10)
+	}
+
+	a.EqString(FunctionRollingSeasonal.Describe().Usage, "forecast.rolling_seasonal(series list, duration, scalar, [duration]) -> series list")
+	a.EqString(FunctionDecompose.Describe().Usage, "forecast.decompose(series list, duration, [duration]) -> series list")
+}
+
+func TestFunctionDecompose(t *testing.T) {
+	a := assert.New(t)
+	values := make([]float64, 16)
+	for i := range values {
+		values[i] = float64(i) + []float64{0, 10, 5, 0}[i%4]
+	}
+	timerange, err := api.NewSnappedTimerange(0, 15*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	ctx := function.EvaluationContextBuilder{EvaluationNotes: &function.EvaluationNotes{}, Timerange: timerange, Ctx: context.Background()}.Build()
+	list := api.SeriesList{Series: []api.Timeseries{{Values: values, TagSet: api.TagSet{"host": "a"}, Name: "requests"}}}
+
+	result, err := FunctionDecompose.Run(ctx, []function.Expression{literal{function.SeriesListValue(list)}, literal{function.StringValue("auto")}}, function.Groups{})
+	a.CheckError(err)
+	if err != nil {
+		return
+	}
+	components, convErr := result.ToSeriesList(timerange)
+	if convErr != nil {
+		t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+	}
+	a.EqInt(len(components.Series), 3)
+	sum := make([]float64, len(values))
+	for _, series := range components.Series {
+		a.EqString(series.Name, "requests")
+		a.EqString(series.TagSet["host"], "a")
+		for i := range sum {
+			sum[i] += series.Values[i]
+		}
+	}
+	a.EqFloatArray(sum, values, 1e-10)
+}
@@ -158,3 +158,166 @@ func Linear(ys []float64) []float64 {
 	}
 	return estimate
 }
+
+// RollingAdditiveHoltWinters approximates the given input using the additive Holt-Winters model, in which the
+// seasonal component is added to (rather than multiplied by) the level. Unlike the multiplicative model, it
+// handles series containing zero or negative values.
+// Like RollingMultiplicativeHoltWinters, it scales 'levelLearningRate' and 'trendLearningRate' by the 'period'.
+func RollingAdditiveHoltWinters(ys []float64, period int, levelLearningRate float64, trendLearningRate float64, seasonalLearningRate float64) []float64 {
+	levelLearningRate = 1 - math.Pow(1-levelLearningRate, 1/float64(period))
+	trendLearningRate = 1 - math.Pow(1-trendLearningRate, 1/float64(period))
+	estimate := make([]float64, len(ys))
+
+	level := newWeighted(levelLearningRate)
+	trend := newWeighted(trendLearningRate)
+	season := newCycle(seasonalLearningRate, period)
+
+	// we need to initialize the season to '0':
+	for i := 0; i < period; i++ {
+		season.observe(i, 0)
+	}
+
+	for i, y := range ys {
+		oldLevel := level.get()
+		oldTrend := trend.get()
+		oldSeason := season.get(i)
+
+		if level.weight == 0 {
+			// The first value which is not NaN initializes the level.
+			level.observe(y - oldSeason)
+		} else {
+			level.boostAdd(oldTrend)
+			level.observe(y - oldSeason)
+			if math.IsNaN(y) {
+				trend.skip()
+			} else {
+				trend.observe(level.get() - oldLevel)
+			}
+			// If y is NaN, this will be NaN too, causing it to skip (as desired).
+			season.observe(i, y-(oldLevel+oldTrend))
+		}
+
+		if level.weight == 0 {
+			estimate[i] = math.NaN()
+			continue
+		}
+		estimate[i] = level.get() + season.get(i)
+	}
+	return estimate
+}
+
+// RollingDoubleExponentialSmoothing approximates the given input with a level and a trend, but no seasonality
+// (this is Holt's linear model). The learning rates are per slot, since there is no period to scale them by.
+func RollingDoubleExponentialSmoothing(ys []float64, levelLearningRate float64, trendLearningRate float64) []float64 {
+	estimate := make([]float64, len(ys))
+	level := newWeighted(levelLearningRate)
+	trend := newWeighted(trendLearningRate)
+	for i, y := range ys {
+		oldLevel := level.get()
+		if level.weight == 0 {
+			// The first value which is not NaN initializes the level.
+			level.observe(y)
+		} else {
+			level.boostAdd(trend.get())
+			level.observe(y)
+			if math.IsNaN(y) {
+				trend.skip()
+			} else {
+				trend.observe(level.get() - oldLevel)
+			}
+		}
+		if level.weight == 0 {
+			estimate[i] = math.NaN()
+			continue
+		}
+		estimate[i] = level.get()
+	}
+	return estimate
+}
+
+// Decompose splits the input into trend, seasonal and residual components which sum to it, in the style of STL
+// (using centered moving averages in place of loess smoothing). The trend is the centered moving average of the
+// deseasonalized input over one period, and the seasonal component is the mean detrended value at each point of
+// the period (excluding the edges, where the window is incomplete), adjusted so that it sums to zero over the
+// period. Missing values are ignored.
+func Decompose(ys []float64, period int) (trend []float64, seasonal []float64, residual []float64) {
+	seasonal = make([]float64, len(ys))
+	deseasonalized := make([]float64, len(ys))
+	detrended := make([]float64, len(ys))
+	// The second pass refines the trend, having removed the seasonal component found in the first pass.
+	for pass := 0; pass < 2; pass++ {
+		for i := range ys {
+			deseasonalized[i] = ys[i] - seasonal[i]
+		}
+		trend = centeredMovingAverage(deseasonalized, period)
+		for i := range ys {
+			detrended[i] = ys[i] - trend[i]
+			if (i < period/2 || i >= len(ys)-period/2) && len(ys) > 2*(period/2) {
+				// The trend is biased near the edges, where the window is incomplete.
+				detrended[i] = math.NaN()
+			}
+		}
+		seasonal = seasonalMeans(detrended, period)
+	}
+	residual = make([]float64, len(ys))
+	for i := range ys {
+		residual[i] = ys[i] - trend[i] - seasonal[i]
+	}
+	return trend, seasonal, residual
+}
+
+// centeredMovingAverage averages the values within half of the window on either side of each point. For even
+// windows, the values at both ends are given half weight, so that the window spans exactly its size.
+func centeredMovingAverage(values []float64, window int) []float64 {
+	half := window / 2
+	result := make([]float64, len(values))
+	for i := range values {
+		sum := 0.0
+		weight := 0.0
+		for j := i - half; j <= i+half; j++ {
+			if j < 0 || j >= len(values) || math.IsNaN(values[j]) {
+				continue
+			}
+			w := 1.0
+			if window%2 == 0 && (j == i-half || j == i+half) {
+				w = 0.5
+			}
+			sum += w * values[j]
+			weight += w
+		}
+		result[i] = sum / weight // NaN if there are no values in the window
+	}
+	return result
+}
+
+// seasonalMeans computes the mean of the values at each point of the period, adjusted so that they sum to zero.
+func seasonalMeans(values []float64, period int) []float64 {
+	means := make([]float64, period)
+	for r := range means {
+		sum := 0.0
+		count := 0
+		for i := r; i < len(values); i += period {
+			if !math.IsNaN(values[i]) {
+				sum += values[i]
+				count++
+			}
+		}
+		means[r] = sum / float64(count)
+	}
+	overall := 0.0
+	count := 0
+	for _, mean := range means {
+		if !math.IsNaN(mean) {
+			overall += mean
+			count++
+		}
+	}
+	if count > 0 {
+		overall /= float64(count)
+	}
+	result := make([]float64, len(values))
+	for i := range result {
+		result[i] = means[i%period] - overall
+	}
+	return result
+}
@@ -22,30 +22,140 @@ import (
 	"github.com/square/metrics/function"
 )
 
-// FunctionRollingMultiplicativeHoltWinters computes a rolling multiplicative Holt-Winters model for the data.
+// evaluateWithTraining evaluates the series over the context's timerange, extended before by the optional extra
+// training time. It also returns the number of extra slots at the start of each series.
+func evaluateWithTraining(context function.EvaluationContext, seriesExpression function.Expression, optionalExtraTrainingTime *time.Duration) (api.SeriesList, int, error) {
+	extraTrainingTime := time.Duration(0)
+	if optionalExtraTrainingTime != nil {
+		extraTrainingTime = *optionalExtraTrainingTime
+	}
+	if extraTrainingTime < 0 {
+		return api.SeriesList{}, 0, fmt.Errorf("extra training time must be non-negative, but got %s", extraTrainingTime.String()) // TODO: use structured error
+	}
+	newContext := context.WithTimerange(context.Timerange().ExtendBefore(extraTrainingTime))
+	extraSlots := newContext.Timerange().Slots() - context.Timerange().Slots()
+	seriesList, err := function.EvaluateToSeriesList(seriesExpression, newContext)
+	if err != nil {
+		return api.SeriesList{}, 0, err
+	}
+	return seriesList, extraSlots, nil
+}
+
+// A PeriodicModel forecasts the values of a series with the given period (in slots) and learning rates.
+type PeriodicModel struct {
+	Name     string // the name of the forecasting function
+	Rates    int    // the number of learning rates, which follow the series and period arguments
+	Forecast func(values []float64, period int, rates []float64) []float64
+}
+
+// Function makes the forecasting function for the model. Its arguments are the series, the period (which may be
+// 'auto'), the learning rates and optionally the extra training time.
+func (m PeriodicModel) Function() function.MetricFunction {
+	parameters := []function.Parameter{{Kind: "series list"}, {Kind: "duration"}}
+	for i := 0; i < m.Rates; i++ {
+		parameters = append(parameters, function.Parameter{Kind: "scalar"})
+	}
+	parameters = append(parameters, function.Parameter{Kind: "duration", Optional: true})
+	return function.MetricFunction{
+		FunctionName: m.Name,
+		MinArguments: 2 + m.Rates,
+		MaxArguments: 3 + m.Rates,
+		Signature:    &function.Signature{Parameters: parameters, Returns: "series list"},
+		Compute: func(context function.EvaluationContext, arguments []function.Expression, groups function.Groups) (function.Value, error) {
+			result, _, err := m.compute(context, arguments)
+			if err != nil {
+				return nil, err
+			}
+			return function.SeriesListValue(result), nil
+		},
+	}
+}
+
+// compute forecasts each series, returning the forecasts along with the period which was used for each series.
+func (m PeriodicModel) compute(context function.EvaluationContext, arguments []function.Expression) (api.SeriesList, []int, error) {
+	periodValue, err := arguments[1].Evaluate(context)
+	if err != nil {
+		return api.SeriesList{}, nil, err
+	}
+	period, err := evaluatePeriod(m.Name, context, periodValue)
+	if err != nil {
+		return api.SeriesList{}, nil, err
+	}
+	rates := make([]float64, m.Rates)
+	for i := range rates {
+		rates[i], err = function.EvaluateToScalar(arguments[2+i], context)
+		if err != nil {
+			return api.SeriesList{}, nil, err
+		}
+	}
+	var optionalExtraTrainingTime *time.Duration
+	if len(arguments) > 2+m.Rates {
+		extraTrainingTime, err := function.EvaluateToDuration(arguments[2+m.Rates], context)
+		if err != nil {
+			return api.SeriesList{}, nil, err
+		}
+		optionalExtraTrainingTime = &extraTrainingTime
+	}
+
+	seriesList, extraSlots, err := evaluateWithTraining(context, arguments[0], optionalExtraTrainingTime)
+	if err != nil {
+		return api.SeriesList{}, nil, err
+	}
+
+	result := api.SeriesList{
+		Series: make([]api.Timeseries, len(seriesList.Series)),
+	}
+	periods := make([]int, len(seriesList.Series))
+
+	for seriesIndex, series := range seriesList.Series {
+		periods[seriesIndex] = period.of(context, series)
+		result.Series[seriesIndex] = api.Timeseries{
+			TagSet: series.TagSet,
+			Name:   series.Name,
+			Values: m.Forecast(series.Values, periods[seriesIndex], rates)[extraSlots:], // Slice to drop the first few extra slots from the result
+		}
+	}
+
+	return result, periods, nil
+}
+
+// RollingMultiplicativeHoltWintersModel computes a rolling multiplicative Holt-Winters model for the data.
 // It takes in several learning rates, as well as the period that describes the periodicity of the seasonal term.
 // The learning rates are interpreted as being "per period." For example, a value of 0.5 means that values in
 // this period are effectively weighted twice as much as those in the previous. A value of 0.9 means that values in
 // this period are weighted 1.0/(1.0 - 0.9) = 10 times as much as the previous.
-var FunctionRollingMultiplicativeHoltWinters = function.MakeFunction(
-	"forecast.rolling_multiplicative_holt_winters",
-	func(context function.EvaluationContext, seriesExpression function.Expression, period time.Duration, levelLearningRate float64, trendLearningRate float64, seasonalLearningRate float64, optionalExtraTrainingTime *time.Duration) (api.SeriesList, error) {
-		extraTrainingTime := time.Duration(0)
-		if optionalExtraTrainingTime != nil {
-			extraTrainingTime = *optionalExtraTrainingTime
-		}
-		if extraTrainingTime < 0 {
-			return api.SeriesList{}, fmt.Errorf("extra training time must be non-negative, but got %s", extraTrainingTime.String()) // TODO: use structured error
-		}
+var RollingMultiplicativeHoltWintersModel = PeriodicModel{
+	Name:  "forecast.rolling_multiplicative_holt_winters",
+	Rates: 3, // level, trend and seasonal
+	Forecast: func(values []float64, period int, rates []float64) []float64 {
+		return RollingMultiplicativeHoltWinters(values, period, rates[0], rates[1], rates[2])
+	},
+}
 
-		samples := int(period / context.Timerange().Resolution())
-		if samples <= 0 {
-			return api.SeriesList{}, fmt.Errorf("forecast.rolling_multiplicative_holt_winters expects the period parameter to mean at least one slot") // TODO: use a structured error
-		}
+// FunctionRollingMultiplicativeHoltWinters forecasts with RollingMultiplicativeHoltWintersModel.
+var FunctionRollingMultiplicativeHoltWinters = RollingMultiplicativeHoltWintersModel.Function()
 
-		newContext := context.WithTimerange(context.Timerange().ExtendBefore(extraTrainingTime))
-		extraSlots := newContext.Timerange().Slots() - context.Timerange().Slots()
-		seriesList, err := function.EvaluateToSeriesList(seriesExpression, newContext)
+// RollingSeasonalModel performs the rolling seasonal estimation.
+// It is designed for data which shows seasonality without trends, although which a high learning rate it can
+// perform tolerably well on data with trends as well.
+var RollingSeasonalModel = PeriodicModel{
+	Name:  "forecast.rolling_seasonal",
+	Rates: 1, // seasonal
+	Forecast: func(values []float64, period int, rates []float64) []float64 {
+		return RollingSeasonal(values, period, rates[0])
+	},
+}
+
+// FunctionRollingSeasonal forecasts with RollingSeasonalModel.
+var FunctionRollingSeasonal = RollingSeasonalModel.Function()
+
+// FunctionLinear forecasts with a simple linear regression.
+// For data which is mostly just a linear trend up or down, this will provide a good model of current behavior,
+// as well as a good estimate of near-future behavior.
+var FunctionLinear = function.MakeFunction(
+	"forecast.linear",
+	func(context function.EvaluationContext, seriesExpression function.Expression, optionalExtraTrainingTime *time.Duration) (api.SeriesList, error) {
+		seriesList, extraSlots, err := evaluateWithTraining(context, seriesExpression, optionalExtraTrainingTime)
 		if err != nil {
 			return api.SeriesList{}, err
 		}
@@ -57,7 +167,8 @@ var FunctionRollingMultiplicativeHoltWinters = function.MakeFunction(
 		for seriesIndex, series := range seriesList.Series {
 			result.Series[seriesIndex] = api.Timeseries{
 				TagSet: series.TagSet,
-				Values: RollingMultiplicativeHoltWinters(series.Values, samples, levelLearningRate, trendLearningRate, seasonalLearningRate)[extraSlots:], // Slice to drop the first few extra slots from the result
+				Name:   series.Name,
+				Values: Linear(series.Values)[extraSlots:], // Slice to drop the first few extra slots from the result
 			}
 		}
 
@@ -65,28 +176,26 @@ var FunctionRollingMultiplicativeHoltWinters = function.MakeFunction(
 	},
 )
 
-// FunctionRollingSeasonal is a forecasting MetricFunction that performs the rolling seasonal estimation.
-// It is designed for data which shows seasonality without trends, although which a high learning rate it can
-// perform tolerably well on data with trends as well.
-var FunctionRollingSeasonal = function.MakeFunction(
-	"forecast.rolling_seasonal",
-	func(context function.EvaluationContext, seriesExpression function.Expression, period time.Duration, seasonalLearningRate float64, optionalExtraTrainingTime *time.Duration) (api.SeriesList, error) {
-		extraTrainingTime := time.Duration(0)
-		if optionalExtraTrainingTime != nil {
-			extraTrainingTime = *optionalExtraTrainingTime
-		}
-		if extraTrainingTime < 0 {
-			return api.SeriesList{}, fmt.Errorf("extra training time must be non-negative, but got %s", extraTrainingTime.String()) // TODO: use structured error
-		}
+// RollingAdditiveHoltWintersModel computes a rolling additive Holt-Winters model for the data.
+// It takes the same parameters as forecast.rolling_multiplicative_holt_winters, but its seasonal term is added to the
+// level rather than multiplied by it, so it is suitable for series with zero or negative values.
+var RollingAdditiveHoltWintersModel = PeriodicModel{
+	Name:  "forecast.rolling_additive_holt_winters",
+	Rates: 3, // level, trend and seasonal
+	Forecast: func(values []float64, period int, rates []float64) []float64 {
+		return RollingAdditiveHoltWinters(values, period, rates[0], rates[1], rates[2])
+	},
+}
 
-		samples := int(period / context.Timerange().Resolution())
-		if samples <= 0 {
-			return api.SeriesList{}, fmt.Errorf("forecast.rolling_seasonal expects the period parameter to mean at least one slot") // TODO: use a structured error
-		}
+// FunctionRollingAdditiveHoltWinters forecasts with RollingAdditiveHoltWintersModel.
+var FunctionRollingAdditiveHoltWinters = RollingAdditiveHoltWintersModel.Function()
 
-		newContext := context.WithTimerange(context.Timerange().ExtendBefore(extraTrainingTime))
-		extraSlots := newContext.Timerange().Slots() - context.Timerange().Slots()
-		seriesList, err := function.EvaluateToSeriesList(seriesExpression, newContext)
+// FunctionRollingDoubleExponentialSmoothing forecasts with a level and trend, but without seasonality.
+// Since there is no period, the learning rates are interpreted as being "per slot".
+var FunctionRollingDoubleExponentialSmoothing = function.MakeFunction(
+	"forecast.rolling_double_exponential_smoothing",
+	func(context function.EvaluationContext, seriesExpression function.Expression, levelLearningRate float64, trendLearningRate float64, optionalExtraTrainingTime *time.Duration) (api.SeriesList, error) {
+		seriesList, extraSlots, err := evaluateWithTraining(context, seriesExpression, optionalExtraTrainingTime)
 		if err != nil {
 			return api.SeriesList{}, err
 		}
@@ -98,7 +207,8 @@ var FunctionRollingSeasonal = function.MakeFunction(
 		for seriesIndex, series := range seriesList.Series {
 			result.Series[seriesIndex] = api.Timeseries{
 				TagSet: series.TagSet,
-				Values: RollingSeasonal(series.Values, samples, seasonalLearningRate)[extraSlots:], // Slice to drop the first few extra slots from the result
+				Name:   series.Name,
+				Values: RollingDoubleExponentialSmoothing(series.Values, levelLearningRate, trendLearningRate)[extraSlots:], // Slice to drop the first few extra slots from the result
 			}
 		}
 
@@ -106,38 +216,64 @@ var FunctionRollingSeasonal = function.MakeFunction(
 	},
 )
 
-// FunctionLinear forecasts with a simple linear regression.
-// For data which is mostly just a linear trend up or down, this will provide a good model of current behavior,
-// as well as a good estimate of near-future behavior.
-var FunctionLinear = function.MakeFunction(
-	"forecast.linear",
-	func(context function.EvaluationContext, seriesExpression function.Expression, optionalTrainingTime *time.Duration) (api.SeriesList, error) {
-		extraTrainingTime := time.Duration(0)
-		if optionalTrainingTime != nil {
-			extraTrainingTime = *optionalTrainingTime
+// componentTag is the tag which names the component of each series produced by forecast.decompose.
+const componentTag = "component"
+
+// SeasonalTrendModel models the data as the sum of the trend and seasonal components found by Decompose.
+var SeasonalTrendModel = PeriodicModel{
+	Name: "forecast.seasonal_trend",
+	Forecast: func(values []float64, period int, rates []float64) []float64 {
+		trend, seasonal, _ := Decompose(values, period)
+		result := make([]float64, len(trend))
+		for i := range result {
+			result[i] = trend[i] + seasonal[i]
 		}
-		if extraTrainingTime < 0 {
-			return api.SeriesList{}, fmt.Errorf("extra training time must be non-negative, but got %s", extraTrainingTime.String()) // TODO: use structured error
+		return result
+	},
+}
+
+// FunctionSeasonalTrend forecasts with SeasonalTrendModel.
+var FunctionSeasonalTrend = SeasonalTrendModel.Function()
+
+// FunctionDecompose splits each series into its trend, seasonal and residual components (as found by Decompose),
+// which are tagged by their component.
+var FunctionDecompose = withPeriodParameter(function.MakeFunction(
+	"forecast.decompose",
+	func(context function.EvaluationContext, seriesExpression function.Expression, periodValue function.Value, optionalExtraTrainingTime *time.Duration) (api.SeriesList, error) {
+		period, err := evaluatePeriod("forecast.decompose", context, periodValue)
+		if err != nil {
+			return api.SeriesList{}, err
 		}
 
-		newContext := context.WithTimerange(context.Timerange().ExtendBefore(extraTrainingTime))
-		extraSlots := newContext.Timerange().Slots() - context.Timerange().Slots()
-		seriesList, err := function.EvaluateToSeriesList(seriesExpression, newContext)
+		seriesList, extraSlots, err := evaluateWithTraining(context, seriesExpression, optionalExtraTrainingTime)
 		if err != nil {
 			return api.SeriesList{}, err
 		}
 
 		result := api.SeriesList{
-			Series: make([]api.Timeseries, len(seriesList.Series)),
+			Series: make([]api.Timeseries, 0, 3*len(seriesList.Series)),
 		}
 
-		for seriesIndex, series := range seriesList.Series {
-			result.Series[seriesIndex] = api.Timeseries{
-				TagSet: series.TagSet,
-				Values: Linear(series.Values)[extraSlots:], // Slice to drop the first few extra slots from the result
+		for _, series := range seriesList.Series {
+			trend, seasonal, residual := Decompose(series.Values, period.of(context, series))
+			for _, component := range []struct {
+				name   string
+				values []float64
+			}{
+				{"trend", trend},
+				{"seasonal", seasonal},
+				{"residual", residual},
+			} {
+				tagSet := series.TagSet.Clone()
+				tagSet[componentTag] = component.name
+				result.Series = append(result.Series, api.Timeseries{
+					TagSet: tagSet,
+					Values: component.values[extraSlots:], // Slice to drop the first few extra slots from the result
+					Name:   series.Name,
+				})
 			}
 		}
 
 		return result, nil
 	},
-)
+))
@@ -375,3 +375,64 @@ func TestRollingAccuracy(t *testing.T) {
 		computeRMSEStatistics(t, test)
 	}
 }
+
+func TestRollingAdditiveHoltWinters(t *testing.T) {
+	// An additive seasonal pattern around zero, which the multiplicative model cannot handle.
+	pattern := []float64{-3, 0, 4, -1}
+	ys := make([]float64, 200)
+	for i := range ys {
+		ys[i] = 0.5*float64(i) + pattern[i%len(pattern)]
+	}
+	estimate := RollingAdditiveHoltWinters(ys, len(pattern), 0.5, 0.5, 0.5)
+	for i := 150; i < len(ys); i++ {
+		if math.IsNaN(estimate[i]) || math.Abs(estimate[i]-ys[i]) > 0.5 {
+			t.Errorf("expected estimate %g at index %d to be close to %g", estimate[i], i, ys[i])
+		}
+	}
+	missing := RollingAdditiveHoltWinters([]float64{math.NaN(), math.NaN(), 1, 2}, 2, 0.5, 0.5, 0.5)
+	if !math.IsNaN(missing[0]) || !math.IsNaN(missing[1]) || math.IsNaN(missing[2]) {
+		t.Errorf("expected estimates to be NaN only before the first value, but got %v", missing)
+	}
+}
+
+func TestRollingDoubleExponentialSmoothing(t *testing.T) {
+	ys := make([]float64, 100)
+	for i := range ys {
+		ys[i] = 10 - 2*float64(i)
+	}
+	ys[50] = math.NaN()
+	estimate := RollingDoubleExponentialSmoothing(ys, 0.5, 0.5)
+	for i := 80; i < len(ys); i++ {
+		if math.Abs(estimate[i]-ys[i]) > 1e-3 {
+			t.Errorf("expected estimate %g at index %d to be close to %g", estimate[i], i, ys[i])
+		}
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	pattern := []float64{5, -2, -1, -2}
+	ys := make([]float64, 40)
+	for i := range ys {
+		ys[i] = 100 + 3*float64(i) + pattern[i%len(pattern)]
+	}
+	trend, seasonal, residual := Decompose(ys, len(pattern))
+	for i := range ys {
+		if math.Abs(trend[i]+seasonal[i]+residual[i]-ys[i]) > 1e-9 {
+			t.Errorf("expected components at index %d to sum to %g", i, ys[i])
+		}
+	}
+	// Away from the edges, the trend and seasonal pattern are recovered exactly.
+	for i := len(pattern); i < len(ys)-len(pattern); i++ {
+		if math.Abs(trend[i]-(100+3*float64(i))) > 1e-9 {
+			t.Errorf("expected trend %g at index %d to be %g", trend[i], i, 100+3*float64(i))
+		}
+		if math.Abs(residual[i]) > 1e-9 {
+			t.Errorf("expected residual %g at index %d to be zero", residual[i], i)
+		}
+	}
+	for i := range ys {
+		if math.Abs(seasonal[i]-pattern[i%len(pattern)]) > 0.5 {
+			t.Errorf("expected seasonal %g at index %d to be close to %g", seasonal[i], i, pattern[i%len(pattern)])
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogram
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/builtin/aggregate"
+)
+
+// bucketTags are the tags which may hold the upper bound of each bucket, in
+// order of preference.
+var bucketTags = []string{"le", "bucket"}
+
+// Quantile estimates the qth quantile (between 0 and 1) of each histogram by
+// interpolating linearly within the bucket which contains it.
+var Quantile = function.MakeFunction(
+	"histogram.quantile",
+	func(list api.SeriesList, q float64, groups function.Groups) (api.SeriesList, error) {
+		if !(0 <= q && q <= 1) {
+			return api.SeriesList{}, fmt.Errorf("histogram.quantile expects a quantile between 0 and 1 but got %g", q)
+		}
+		return summarize("histogram.quantile", list, groups, func(buckets []bucket) float64 {
+			return quantile(buckets, q)
+		})
+	},
+)
+
+// Mean estimates the mean of each histogram, supposing that the observations in
+// each bucket lie at its midpoint.
+var Mean = function.MakeFunction(
+	"histogram.mean",
+	func(list api.SeriesList, groups function.Groups) (api.SeriesList, error) {
+		return summarize("histogram.mean", list, groups, mean)
+	},
+)
+
+// FractionBelow estimates the fraction of the observations in each histogram
+// which are at most the threshold, interpolating linearly within the bucket
+// which contains it.
+var FractionBelow = function.MakeFunction(
+	"histogram.fraction_below",
+	func(list api.SeriesList, threshold float64, groups function.Groups) (api.SeriesList, error) {
+		return summarize("histogram.fraction_below", list, groups, func(buckets []bucket) float64 {
+			return fractionBelow(buckets, threshold)
+		})
+	},
+)
+
+// bucket is the cumulative count of the observations which are at most its bound.
+type bucket struct {
+	bound float64
+	count float64
+}
+
+// summarize sums the series of each bucket in each group (as aggregate.sum
+// would, but keeping the buckets apart) and then summarizes the buckets of
+// each group at each point in time. Buckets whose count is NaN are ignored.
+func summarize(name string, list api.SeriesList, groups function.Groups, summarizer func([]bucket) float64) (api.SeriesList, error) {
+	result := api.SeriesList{
+		Series: []api.Timeseries{},
+	}
+	if len(list.Series) == 0 {
+		return result, nil
+	}
+	bucketTag := ""
+	for _, tag := range bucketTags {
+		if _, ok := list.Series[0].TagSet[tag]; ok {
+			bucketTag = tag
+			break
+		}
+	}
+	if bucketTag == "" {
+		return api.SeriesList{}, fmt.Errorf("%s expects each series to have an 'le' or 'bucket' tag but got %+v", name, list.Series[0].TagSet)
+	}
+	bounds := map[string]float64{}
+	for _, series := range list.Series {
+		value, ok := series.TagSet[bucketTag]
+		if !ok {
+			return api.SeriesList{}, fmt.Errorf("%s expects each series to have a '%s' tag but got %+v", name, bucketTag, series.TagSet)
+		}
+		bound, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(bound) {
+			return api.SeriesList{}, fmt.Errorf("%s expects the '%s' tag to be a number or '+Inf' but got '%s'", name, bucketTag, value)
+		}
+		bounds[value] = bound
+	}
+
+	tags := groups.List
+	for _, tag := range tags {
+		if tag == bucketTag && groups.Collapses {
+			return api.SeriesList{}, fmt.Errorf("%s cannot collapse the '%s' tag of its buckets", name, bucketTag)
+		}
+	}
+	if !groups.Collapses {
+		// The buckets are kept apart while the series within them are summed.
+		tags = append(append([]string{}, groups.List...), bucketTag)
+	}
+	for _, histogram := range aggregate.Partition(aggregate.By(list, aggregate.Sum, tags, groups.Collapses), []string{bucketTag}, true) {
+		sort.Sort(byBound{histogram.Series, bucketTag, bounds})
+		tagSet := histogram.Series[0].TagSet.Clone()
+		delete(tagSet, bucketTag)
+		values := make([]float64, len(histogram.Series[0].Values))
+		for t := range values {
+			buckets := []bucket{}
+			for _, series := range histogram.Series {
+				if count := series.Values[t]; !math.IsNaN(count) {
+					if len(buckets) > 0 && count < buckets[len(buckets)-1].count {
+						// Cumulative counts never decrease, so this is an artifact of collection.
+						count = buckets[len(buckets)-1].count
+					}
+					buckets = append(buckets, bucket{bounds[series.TagSet[bucketTag]], count})
+				}
+			}
+			values[t] = summarizer(buckets)
+		}
+		result.Series = append(result.Series, api.Timeseries{
+			Values: values,
+			TagSet: tagSet,
+		})
+	}
+	return result, nil
+}
+
+// byBound sorts the series of a histogram by the bounds of their buckets.
+type byBound struct {
+	series    []api.Timeseries
+	bucketTag string
+	bounds    map[string]float64
+}
+
+func (b byBound) Len() int {
+	return len(b.series)
+}
+func (b byBound) Less(i, j int) bool {
+	return b.bounds[b.series[i].TagSet[b.bucketTag]] < b.bounds[b.series[j].TagSet[b.bucketTag]]
+}
+func (b byBound) Swap(i, j int) {
+	b.series[i], b.series[j] = b.series[j], b.series[i]
+}
+
+// total is the number of observations in the histogram, which is the count of
+// its largest bucket. It is NaN if the histogram is empty.
+func total(buckets []bucket) float64 {
+	if len(buckets) == 0 || buckets[len(buckets)-1].count == 0 {
+		return math.NaN()
+	}
+	return buckets[len(buckets)-1].count
+}
+
+// lowerBound is the lower bound of the ith bucket, which is zero for the first
+// bucket unless its bound is negative.
+func lowerBound(buckets []bucket, i int) (float64, float64) {
+	if i == 0 {
+		return math.Min(0, buckets[0].bound), 0
+	}
+	return buckets[i-1].bound, buckets[i-1].count
+}
+
+func quantile(buckets []bucket, q float64) float64 {
+	count := total(buckets)
+	if math.IsNaN(count) {
+		return math.NaN()
+	}
+	rank := q * count
+	i := sort.Search(len(buckets), func(i int) bool { return buckets[i].count >= rank })
+	lower, lowerCount := lowerBound(buckets, i)
+	if math.IsInf(buckets[i].bound, 1) {
+		// Nothing is known about the observations in the +Inf bucket.
+		return lower
+	}
+	if buckets[i].count == lowerCount {
+		return buckets[i].bound
+	}
+	return lower + (buckets[i].bound-lower)*(rank-lowerCount)/(buckets[i].count-lowerCount)
+}
+
+func mean(buckets []bucket) float64 {
+	count := total(buckets)
+	if math.IsNaN(count) {
+		return math.NaN()
+	}
+	sum := 0.0
+	for i := range buckets {
+		lower, lowerCount := lowerBound(buckets, i)
+		midpoint := (lower + buckets[i].bound) / 2
+		if math.IsInf(buckets[i].bound, 1) {
+			midpoint = lower
+		}
+		if buckets[i].count > lowerCount {
+			sum += midpoint * (buckets[i].count - lowerCount)
+		}
+	}
+	return sum / count
+}
+
+func fractionBelow(buckets []bucket, threshold float64) float64 {
+	count := total(buckets)
+	if math.IsNaN(count) {
+		return math.NaN()
+	}
+	i := sort.Search(len(buckets), func(i int) bool { return buckets[i].bound >= threshold })
+	if i == len(buckets) {
+		return 1
+	}
+	lower, lowerCount := lowerBound(buckets, i)
+	if threshold <= lower {
+		return lowerCount / count
+	}
+	if math.IsInf(buckets[i].bound, 1) {
+		// Nothing is known about the observations in the +Inf bucket.
+		return lowerCount / count
+	}
+	return (lowerCount + (buckets[i].count-lowerCount)*(threshold-lower)/(buckets[i].bound-lower)) / count
+}
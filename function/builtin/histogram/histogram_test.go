@@ -0,0 +1,132 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/testing_support/assert"
+
+	"golang.org/x/net/context"
+)
+
+type literal struct {
+	value function.Value
+}
+
+func (lit literal) ExpressionString(mode function.DescriptionMode) string {
+	return "<literal>"
+}
+
+func (lit literal) Evaluate(context function.EvaluationContext) (function.Value, error) {
+	return lit.value, nil
+}
+
+func TestBucketSummaries(t *testing.T) {
+	inf := math.Inf(1)
+	buckets := []bucket{{1, 20}, {2, 60}, {4, 80}, {inf, 100}}
+	a := assert.New(t)
+	a.EqFloat(quantile(buckets, 0.1), 0.5, 1e-9)
+	a.EqFloat(quantile(buckets, 0.5), 1.75, 1e-9)
+	a.EqFloat(quantile(buckets, 0.7), 3, 1e-9)
+	a.EqFloat(quantile(buckets, 0.9), 4, 1e-9)
+	a.EqFloat(quantile(buckets, 0), 0, 1e-9)
+	a.EqFloat(mean(buckets), (20*0.5+40*1.5+20*3+20*4)/100.0, 1e-9)
+	a.EqFloat(fractionBelow(buckets, 1.5), 0.4, 1e-9)
+	a.EqFloat(fractionBelow(buckets, 4), 0.8, 1e-9)
+	a.EqFloat(fractionBelow(buckets, 10), 0.8, 1e-9)
+	a.EqFloat(fractionBelow(buckets, -1), 0, 1e-9)
+	a.EqFloat(fractionBelow(buckets[:3], 10), 1, 1e-9)
+	for _, empty := range [][]bucket{{}, {{1, 0}, {inf, 0}}} {
+		if !math.IsNaN(quantile(empty, 0.5)) || !math.IsNaN(mean(empty)) || !math.IsNaN(fractionBelow(empty, 1)) {
+			t.Errorf("expected an empty histogram %+v to have NaN summaries", empty)
+		}
+	}
+}
+
+func TestHistogramFunctions(t *testing.T) {
+	nan := math.NaN()
+	timerange, err := api.NewSnappedTimerange(0, 2*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	list := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{10, 0, 5}, TagSet: api.TagSet{"app": "web", "host": "a", "le": "+Inf"}},
+			{Values: []float64{5, 0, 5}, TagSet: api.TagSet{"app": "web", "host": "a", "le": "1"}},
+			{Values: []float64{10, 0, 5}, TagSet: api.TagSet{"app": "web", "host": "b", "le": "+Inf"}},
+			{Values: []float64{5, 0, nan}, TagSet: api.TagSet{"app": "web", "host": "b", "le": "1"}},
+			{Values: []float64{4, 4, 4}, TagSet: api.TagSet{"app": "db", "host": "c", "le": "1"}},
+			{Values: []float64{8, 4, 4}, TagSet: api.TagSet{"app": "db", "host": "c", "le": "2"}},
+			{Values: []float64{8, 4, 4}, TagSet: api.TagSet{"app": "db", "host": "c", "le": "+Inf"}},
+		},
+	}
+	for _, test := range []struct {
+		fun       function.Function
+		parameter float64
+		groups    function.Groups
+		expected  map[string][]float64
+	}{
+		{Quantile, 0.5, function.Groups{List: []string{"app"}}, map[string][]float64{
+			"web": {1, nan, 1},
+			"db":  {1, 0.5, 0.5},
+		}},
+		{Quantile, 0.75, function.Groups{List: []string{"host", "le"}, Collapses: true}, map[string][]float64{
+			"web": {1, nan, 1},
+			"db":  {1.5, 0.75, 0.75},
+		}},
+		{FractionBelow, 0.5, function.Groups{List: []string{"app"}}, map[string][]float64{
+			"web": {0.25, nan, 0.25},
+			"db":  {0.25, 0.5, 0.5},
+		}},
+	} {
+		a := assert.New(t).Contextf("%s(%g) %+v", test.fun.Name(), test.parameter, test.groups)
+		ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+		arguments := []function.Expression{literal{function.SeriesListValue(list)}, literal{function.ScalarValue(test.parameter)}}
+		resultValue, err := test.fun.Run(ctx, arguments, test.groups)
+		if test.groups.Collapses {
+			// The buckets cannot be collapsed.
+			if err == nil {
+				t.Errorf("expected an error collapsing the buckets")
+			}
+			test.groups.List = test.groups.List[:1]
+			resultValue, err = test.fun.Run(ctx, arguments, test.groups)
+		}
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, convErr := resultValue.ToSeriesList(timerange)
+		if convErr != nil {
+			t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+		}
+		a.EqInt(len(result.Series), len(test.expected))
+		for _, series := range result.Series {
+			a.Eq(series.TagSet, api.TagSet{"app": series.TagSet["app"]})
+			a.EqFloatArray(series.Values, test.expected[series.TagSet["app"]], 1e-9)
+		}
+	}
+
+	ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+	for _, invalid := range []api.TagSet{{"host": "a"}, {"le": "many"}} {
+		arguments := []function.Expression{literal{function.SeriesListValue(api.SeriesList{Series: []api.Timeseries{{Values: []float64{1, 2, 3}, TagSet: invalid}}})}}
+		if _, err := Mean.Run(ctx, arguments, function.Groups{}); err == nil {
+			t.Errorf("expected an error for a series with tags %+v", invalid)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/builtin/filter"
+	"github.com/square/metrics/function/builtin/join"
+)
+
+// Combine joins the scalar sets on their tags, in the same way as the series
+// lists given to an operator, and applies the operator to each joined pair.
+func Combine(left function.ScalarSet, right function.ScalarSet, operator func(float64, float64) float64) function.ScalarSet {
+	joined := join.Join([]api.SeriesList{pointList(left), pointList(right)})
+	result := make(function.ScalarSet, len(joined.Rows))
+	for i, row := range joined.Rows {
+		result[i] = function.TaggedScalar{
+			TagSet: row.TagSet,
+			Value:  operator(row.Row[0].Values[0], row.Row[1].Values[0]),
+		}
+	}
+	return result
+}
+
+// pointList is the series list with a single point for each scalar.
+func pointList(set function.ScalarSet) api.SeriesList {
+	list := api.SeriesList{
+		Series: make([]api.Timeseries, len(set)),
+	}
+	for i, scalar := range set {
+		list.Series[i] = api.Timeseries{Values: []float64{scalar.Value}, TagSet: scalar.TagSet}
+	}
+	return list
+}
+
+// sortSet sorts the scalars by value, in ascending or descending order, with
+// NaN values last.
+func sortSet(set function.ScalarSet, descending bool) function.ScalarSet {
+	result := make(function.ScalarSet, len(set))
+	copy(result, set)
+	sort.Stable(byValue{result, descending})
+	return result
+}
+
+type byValue struct {
+	set        function.ScalarSet
+	descending bool
+}
+
+func (s byValue) Len() int {
+	return len(s.set)
+}
+
+func (s byValue) Swap(i, j int) {
+	s.set[i], s.set[j] = s.set[j], s.set[i]
+}
+
+func (s byValue) Less(i, j int) bool {
+	x, y := s.set[i].Value, s.set[j].Value
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return !math.IsNaN(x) && math.IsNaN(y)
+	}
+	if s.descending {
+		return x > y
+	}
+	return x < y
+}
+
+// Sort orders the scalars by value, in ascending order unless the optional
+// order is 'desc'. NaN values are always last.
+var Sort = function.MakeFunction(
+	"scalar.sort",
+	func(set function.ScalarSet, optionalOrder *string) (function.ScalarSet, error) {
+		descending := false
+		if optionalOrder != nil {
+			switch *optionalOrder {
+			case "asc":
+			case "desc":
+				descending = true
+			default:
+				return nil, fmt.Errorf("scalar.sort expects order 'asc' or 'desc' but got '%s'", *optionalOrder)
+			}
+		}
+		return sortSet(set, descending), nil
+	},
+)
+
+// Where keeps the scalars which compare to the threshold by the operator, as
+// in filter.where.
+var Where = function.MakeFunction(
+	"scalar.where",
+	func(set function.ScalarSet, operator string, threshold float64) (function.ScalarSet, error) {
+		compare, ok := filter.Comparisons[operator]
+		if !ok {
+			return nil, fmt.Errorf("scalar.where expects one of '<', '<=', '>', '>=', '=' or '!=' but got '%s'", operator)
+		}
+		result := function.ScalarSet{}
+		for _, scalar := range set {
+			if compare(scalar.Value, threshold) {
+				result = append(result, scalar)
+			}
+		}
+		return result, nil
+	},
+)
+
+// Highest keeps the given number of scalars with the highest values, in
+// descending order.
+var Highest = newLimit("scalar.highest", true)
+
+// Lowest keeps the given number of scalars with the lowest values, in
+// ascending order.
+var Lowest = newLimit("scalar.lowest", false)
+
+func newLimit(name string, descending bool) function.MetricFunction {
+	return function.MakeFunction(
+		name,
+		func(set function.ScalarSet, countFloat float64) (function.ScalarSet, error) {
+			if countFloat < 0 {
+				return nil, fmt.Errorf("%s expects a non-negative count but got %g", name, countFloat)
+			}
+			count := int(countFloat + 0.5)
+			sorted := sortSet(set, descending)
+			if count < len(sorted) {
+				sorted = sorted[:count]
+			}
+			return sorted, nil
+		},
+	)
+}
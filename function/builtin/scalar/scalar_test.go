@@ -0,0 +1,143 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar
+
+import (
+	"math"
+	"testing"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/testing_support/assert"
+
+	"golang.org/x/net/context"
+)
+
+type literal struct {
+	value function.Value
+}
+
+func (l literal) ExpressionString(mode function.DescriptionMode) string {
+	return "<literal>"
+}
+
+func (l literal) Evaluate(context function.EvaluationContext) (function.Value, error) {
+	return l.value, nil
+}
+
+var testSet = function.ScalarSet{
+	{TagSet: api.TagSet{"host": "a", "dc": "west"}, Value: 3},
+	{TagSet: api.TagSet{"host": "b", "dc": "west"}, Value: math.NaN()},
+	{TagSet: api.TagSet{"host": "c", "dc": "east"}, Value: -1},
+	{TagSet: api.TagSet{"host": "d", "dc": "east"}, Value: 7},
+	{TagSet: api.TagSet{"host": "e", "dc": "north"}, Value: 3},
+}
+
+// hosts lists the host tag of each scalar, in order.
+func hosts(set function.ScalarSet) []string {
+	result := []string{}
+	for _, scalar := range set {
+		result = append(result, scalar.TagSet["host"])
+	}
+	return result
+}
+
+func TestCombine(t *testing.T) {
+	a := assert.New(t)
+	totals := function.ScalarSet{
+		{TagSet: api.TagSet{"dc": "west"}, Value: 10},
+		{TagSet: api.TagSet{"dc": "east"}, Value: 2},
+		{TagSet: api.TagSet{"dc": "south"}, Value: 1},
+	}
+	combined := Combine(testSet, totals, func(x float64, y float64) float64 { return x / y })
+	a.EqInt(len(combined), 4)
+	for _, scalar := range combined {
+		expected := map[string]float64{"a": 0.3, "b": math.NaN(), "c": -0.5, "d": 3.5}[scalar.TagSet["host"]]
+		a.EqFloat(scalar.Value, expected, 1e-10)
+		a.EqString(scalar.TagSet["dc"], map[string]string{"a": "west", "b": "west", "c": "east", "d": "east"}[scalar.TagSet["host"]])
+	}
+
+	// An untagged scalar joins with every scalar.
+	offset := Combine(function.ScalarSet{{TagSet: api.TagSet{}, Value: 1}}, testSet, func(x float64, y float64) float64 { return x + y })
+	a.EqInt(len(offset), len(testSet))
+	for i := range offset {
+		a.Eq(offset[i].TagSet, testSet[i].TagSet)
+		a.EqFloat(offset[i].Value, testSet[i].Value+1, 1e-10)
+	}
+}
+
+func run(t *testing.T, fun function.Function, arguments ...function.Value) (function.ScalarSet, error) {
+	expressions := make([]function.Expression, len(arguments))
+	for i := range arguments {
+		expressions[i] = literal{arguments[i]}
+	}
+	timerange, err := api.NewSnappedTimerange(0, 120, 30)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+	value, err := fun.Run(ctx, expressions, function.Groups{})
+	if err != nil {
+		return nil, err
+	}
+	set, convErr := value.ToScalarSet()
+	if convErr != nil {
+		t.Fatalf("error converting to scalar set: %s", convErr.WithContext("test case"))
+	}
+	return set, nil
+}
+
+func TestScalarFunctions(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		fun       function.Function
+		arguments []function.Value
+		expected  []string
+	}{
+		{"sort", Sort, []function.Value{testSet}, []string{"c", "a", "e", "d", "b"}},
+		{"sort asc", Sort, []function.Value{testSet, function.StringValue("asc")}, []string{"c", "a", "e", "d", "b"}},
+		{"sort desc", Sort, []function.Value{testSet, function.StringValue("desc")}, []string{"d", "a", "e", "c", "b"}},
+		{"sort empty", Sort, []function.Value{function.ScalarSet{}}, []string{}},
+		{"where", Where, []function.Value{testSet, function.StringValue(">="), function.ScalarValue(3)}, []string{"a", "d", "e"}},
+		{"where not equal", Where, []function.Value{testSet, function.StringValue("!="), function.ScalarValue(3)}, []string{"b", "c", "d"}},
+		{"highest", Highest, []function.Value{testSet, function.ScalarValue(2)}, []string{"d", "a"}},
+		{"lowest", Lowest, []function.Value{testSet, function.ScalarValue(3)}, []string{"c", "a", "e"}},
+		{"lowest of all", Lowest, []function.Value{testSet, function.ScalarValue(10)}, []string{"c", "a", "e", "d", "b"}},
+		{"highest of scalar", Highest, []function.Value{function.ScalarValue(4), function.ScalarValue(1)}, []string{""}},
+	} {
+		a := assert.New(t).Contextf("%s", test.name)
+		result, err := run(t, test.fun, test.arguments...)
+		a.CheckError(err)
+		a.Eq(hosts(result), test.expected)
+	}
+	// The scalar set is not modified.
+	a := assert.New(t)
+	a.Eq(hosts(testSet), []string{"a", "b", "c", "d", "e"})
+
+	for _, test := range []struct {
+		name      string
+		fun       function.Function
+		arguments []function.Value
+	}{
+		{"sort order", Sort, []function.Value{testSet, function.StringValue("up")}},
+		{"where operator", Where, []function.Value{testSet, function.StringValue("~"), function.ScalarValue(3)}},
+		{"highest count", Highest, []function.Value{testSet, function.ScalarValue(-2)}},
+		{"lowest count", Lowest, []function.Value{testSet, function.ScalarValue(-0.25)}},
+	} {
+		if _, err := run(t, test.fun, test.arguments...); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
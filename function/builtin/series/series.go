@@ -0,0 +1,175 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package series
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/builtin/aggregate"
+)
+
+// SourceTag is the tag which series.union sets to the name of the expression each series came from.
+const SourceTag = "source"
+
+// Union returns a single series list holding the series of each of the lists, in order, with the SourceTag of each
+// series set to the name of the list it came from.
+func Union(lists []api.SeriesList, sources []string) api.SeriesList {
+	result := api.SeriesList{
+		Series: []api.Timeseries{},
+	}
+	for i, list := range lists {
+		for _, series := range list.Series {
+			tagSet := series.TagSet.Clone()
+			tagSet[SourceTag] = sources[i]
+			series.TagSet = tagSet
+			result.Series = append(result.Series, series)
+		}
+	}
+	return result
+}
+
+// A Policy decides whether the candidate series should be kept instead of the current one, when both have the same
+// tags.
+type Policy func(current api.Timeseries, candidate api.Timeseries) bool
+
+// Policies are the policies which series.dedup can use to choose between series, by name.
+var Policies = map[string]Policy{
+	"first": func(current api.Timeseries, candidate api.Timeseries) bool {
+		return false
+	},
+	"last": func(current api.Timeseries, candidate api.Timeseries) bool {
+		return true
+	},
+	"most_complete": func(current api.Timeseries, candidate api.Timeseries) bool {
+		return aggregate.Count(candidate.Values) > aggregate.Count(current.Values)
+	},
+	"highest_mean": func(current api.Timeseries, candidate api.Timeseries) bool {
+		return better(aggregate.Mean(current.Values), aggregate.Mean(candidate.Values), false)
+	},
+	"lowest_mean": func(current api.Timeseries, candidate api.Timeseries) bool {
+		return better(aggregate.Mean(current.Values), aggregate.Mean(candidate.Values), true)
+	},
+}
+
+// better determines whether the candidate value is higher (or lower) than the current one. NaN values are worse than
+// any other.
+func better(current float64, candidate float64, lower bool) bool {
+	if math.IsNaN(candidate) {
+		return false
+	}
+	if math.IsNaN(current) {
+		return true
+	}
+	if lower {
+		return candidate < current
+	}
+	return candidate > current
+}
+
+// dedupKey is the combination of the series' values for the given tags (or for all of its other tags, if `collapses`
+// is true). Without any tags, it is the series' entire tagset.
+func dedupKey(series api.Timeseries, tags []string, collapses bool) string {
+	if len(tags) == 0 {
+		return series.TagSet.Serialize()
+	}
+	key := api.TagSet{}
+	if collapses {
+		key = series.TagSet.Clone()
+		for _, tag := range tags {
+			delete(key, tag)
+		}
+	} else {
+		for _, tag := range tags {
+			if value, ok := series.TagSet[tag]; ok {
+				key[tag] = value
+			}
+		}
+	}
+	return key.Serialize()
+}
+
+// Dedup keeps one series of the list for each combination of values of the given tags (or of all the other tags, if
+// `collapses` is true), chosen by the policy. Without any tags, it keeps one series for each distinct tagset.
+// The kept series keep all of their tags, and are in the order in which each combination first occurs.
+func Dedup(list api.SeriesList, policy Policy, tags []string, collapses bool) api.SeriesList {
+	index := map[string]int{}
+	result := api.SeriesList{
+		Series: []api.Timeseries{},
+	}
+	for _, series := range list.Series {
+		key := dedupKey(series, tags, collapses)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(result.Series)
+			result.Series = append(result.Series, series)
+			continue
+		}
+		if policy(result.Series[i], series) {
+			result.Series[i] = series
+		}
+	}
+	return result
+}
+
+// UnionFunction wraps up Union into a Function called "series.union", which accepts any number of series lists.
+var UnionFunction = function.MetricFunction{
+	FunctionName: "series.union",
+	MinArguments: 1,
+	MaxArguments: -1,
+	Signature: &function.Signature{
+		Parameters: []function.Parameter{{Kind: "series list"}},
+		Variadic:   true,
+		Returns:    "series list",
+	},
+	Compute: func(context function.EvaluationContext, arguments []function.Expression, groups function.Groups) (function.Value, error) {
+		values, err := function.EvaluateMany(context, arguments)
+		if err != nil {
+			return nil, err
+		}
+		lists := make([]api.SeriesList, len(values))
+		sources := make([]string, len(values))
+		for i, value := range values {
+			list, convErr := value.ToSeriesList(context.Timerange())
+			if convErr != nil {
+				return nil, convErr.WithContext(arguments[i].ExpressionString(function.StringQuery))
+			}
+			lists[i] = list
+			sources[i] = arguments[i].ExpressionString(function.StringName)
+		}
+		return function.SeriesListValue(Union(lists, sources)), nil
+	},
+}
+
+// DedupFunction wraps up Dedup into a Function called "series.dedup", with the policy given by name.
+var DedupFunction = function.MakeFunction(
+	"series.dedup",
+	func(list api.SeriesList, policyName string, groups function.Groups) (api.SeriesList, error) {
+		policy, ok := Policies[policyName]
+		if !ok {
+			names := []string{}
+			for name := range Policies {
+				names = append(names, fmt.Sprintf("'%s'", name))
+			}
+			sort.Strings(names)
+			return api.SeriesList{}, fmt.Errorf("series.dedup expects one of %s but got '%s'", strings.Join(names, ", "), policyName)
+		}
+		return Dedup(list, policy, groups.List, groups.Collapses), nil
+	},
+)
@@ -0,0 +1,83 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package series
+
+import (
+	"math"
+	"testing"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/testing_support/assert"
+)
+
+func TestUnion(t *testing.T) {
+	a := assert.New(t)
+	http := api.SeriesList{Series: []api.Timeseries{
+		{Values: []float64{1, 2}, TagSet: api.TagSet{"host": "a"}},
+		{Values: []float64{3, 4}, TagSet: api.TagSet{"host": "b", "source": "load balancer"}},
+	}}
+	grpc := api.SeriesList{Series: []api.Timeseries{
+		{Values: []float64{5, 6}, TagSet: api.TagSet{"host": "a"}},
+	}}
+	result := Union([]api.SeriesList{http, grpc, {Series: []api.Timeseries{}}}, []string{"http.requests", "grpc.requests", "empty"})
+	a.Eq(result, api.SeriesList{Series: []api.Timeseries{
+		{Values: []float64{1, 2}, TagSet: api.TagSet{"host": "a", "source": "http.requests"}},
+		{Values: []float64{3, 4}, TagSet: api.TagSet{"host": "b", "source": "http.requests"}},
+		{Values: []float64{5, 6}, TagSet: api.TagSet{"host": "a", "source": "grpc.requests"}},
+	}})
+	// The original lists are not modified.
+	a.EqString(http.Series[0].TagSet["source"], "")
+	a.EqString(http.Series[1].TagSet["source"], "load balancer")
+}
+
+func TestDedup(t *testing.T) {
+	nan := math.NaN()
+	list := api.SeriesList{Series: []api.Timeseries{
+		{Values: []float64{1, nan, 1}, TagSet: api.TagSet{"host": "a", "source": "http"}},
+		{Values: []float64{4, 4, 4}, TagSet: api.TagSet{"host": "b", "source": "http"}},
+		{Values: []float64{2, 2, 2}, TagSet: api.TagSet{"host": "a", "source": "grpc"}},
+		{Values: []float64{nan, nan, nan}, TagSet: api.TagSet{"host": "b", "source": "grpc"}},
+		{Values: []float64{0, 0, 0}, TagSet: api.TagSet{"source": "grpc"}},
+		{Values: []float64{3, 3, 3}, TagSet: api.TagSet{"host": "a", "source": "http"}},
+	}}
+	for _, test := range []struct {
+		policy    string
+		tags      []string
+		collapses bool
+		expected  []int
+	}{
+		{"first", nil, false, []int{0, 1, 2, 3, 4}},
+		{"last", nil, false, []int{5, 1, 2, 3, 4}},
+		{"first", []string{"host"}, false, []int{0, 1, 4}},
+		{"last", []string{"host"}, false, []int{5, 3, 4}},
+		{"most_complete", []string{"host"}, false, []int{2, 1, 4}},
+		{"highest_mean", []string{"host"}, false, []int{5, 1, 4}},
+		{"lowest_mean", []string{"host"}, false, []int{0, 1, 4}},
+		{"highest_mean", []string{"source"}, true, []int{5, 1, 4}},
+		{"first", []string{"source"}, false, []int{0, 2}},
+		{"lowest_mean", []string{"host", "source"}, true, []int{4}},
+	} {
+		a := assert.New(t).Contextf("%s by %v (collapses: %t)", test.policy, test.tags, test.collapses)
+		result := Dedup(list, Policies[test.policy], test.tags, test.collapses)
+		a.EqInt(len(result.Series), len(test.expected))
+		if len(result.Series) != len(test.expected) {
+			continue
+		}
+		for i, index := range test.expected {
+			a.Eq(result.Series[i].TagSet, list.Series[index].TagSet)
+			a.EqFloatArray(result.Series[i].Values, list.Series[index].Values, 1e-10)
+		}
+	}
+}
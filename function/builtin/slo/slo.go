@@ -0,0 +1,137 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slo
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/builtin/join"
+)
+
+// BurnRate is the rate at which the error budget of the objective (such as
+// 0.999) is being spent over the trailing window: 1 means that the budget
+// would be spent exactly by the end of the objective's period.
+var BurnRate = newObjectiveFunction("slo.burn_rate", func(errorRatio float64, objective float64) float64 {
+	return errorRatio / (1 - objective)
+})
+
+// ErrorBudgetRemaining is the fraction of the error budget of the objective
+// which remains over the trailing window, which becomes negative once the
+// budget has been overspent.
+var ErrorBudgetRemaining = newObjectiveFunction("slo.error_budget_remaining", func(errorRatio float64, objective float64) float64 {
+	return 1 - errorRatio/(1-objective)
+})
+
+// newObjectiveFunction creates a function of the good and total event counts
+// which computes the ratio of bad events to total events over the trailing
+// window ending at each slot, and then transforms it with the objective.
+// The good and total series are joined as by the arithmetic operators. Slots
+// where either count is NaN are ignored, and a window with no events has no
+// errors. A window where every slot is ignored is NaN.
+func newObjectiveFunction(name string, transform func(errorRatio float64, objective float64) float64) function.MetricFunction {
+	return function.MakeFunction(
+		name,
+		func(context function.EvaluationContext, goodExpression function.Expression, totalExpression function.Expression, objective float64, window time.Duration) (api.SeriesList, error) {
+			if !(0 < objective && objective < 1) {
+				return api.SeriesList{}, fmt.Errorf("%s expects an objective strictly between 0 and 1 but got %g", name, objective)
+			}
+			if window <= 0 {
+				return api.SeriesList{}, fmt.Errorf("%s expects a positive window but got %s", name, window.String())
+			}
+			timerange := context.Timerange()
+			limit := int(float64(window)/float64(timerange.Resolution()) + 0.5)
+			if limit < 1 {
+				limit = 1
+			}
+			// Data before the timerange is fetched so that the first slots have a full window.
+			newContext := context.WithTimerange(timerange.ExtendBefore(time.Duration(limit-1) * timerange.Resolution()))
+			good, err := function.EvaluateToSeriesList(goodExpression, newContext)
+			if err != nil {
+				return api.SeriesList{}, err
+			}
+			total, err := function.EvaluateToSeriesList(totalExpression, newContext)
+			if err != nil {
+				return api.SeriesList{}, err
+			}
+
+			joined := join.Join([]api.SeriesList{good, total})
+			result := api.SeriesList{
+				Series: make([]api.Timeseries, len(joined.Rows)),
+			}
+			for i, row := range joined.Rows {
+				ratios := errorRatios(row.Row[0].Values, row.Row[1].Values, limit)
+				for j := range ratios {
+					ratios[j] = transform(ratios[j], objective)
+				}
+				result.Series[i] = api.Timeseries{
+					Values: ratios,
+					TagSet: row.TagSet,
+				}
+			}
+			return result, nil
+		},
+	)
+}
+
+// errorRatios computes the ratio of bad events to total events over the
+// trailing window of `limit` slots ending at each slot after the first
+// `limit-1` slots.
+func errorRatios(good []float64, total []float64, limit int) []float64 {
+	if len(total) < limit {
+		return []float64{}
+	}
+	result := make([]float64, len(total)-limit+1)
+	var window struct {
+		bad, total      float64 // the sums of bad and total events
+		slots, badSlots int     // the numbers of slots where both counts are present, and where there are bad events
+		totalSlots      int     // the number of slots where there are events
+	}
+	update := func(i int, sign int) {
+		if i < 0 || math.IsNaN(good[i]) || math.IsNaN(total[i]) {
+			return
+		}
+		bad := math.Max(total[i]-good[i], 0)
+		window.bad += float64(sign) * bad
+		window.total += float64(sign) * total[i]
+		window.slots += sign
+		if bad > 0 {
+			window.badSlots += sign
+		}
+		if total[i] > 0 {
+			window.totalSlots += sign
+		}
+	}
+	for i := range total {
+		update(i, 1)
+		update(i-limit, -1)
+		if i-limit+1 < 0 {
+			continue
+		}
+		switch {
+		case window.slots == 0:
+			result[i-limit+1] = math.NaN()
+		case window.badSlots == 0 || window.totalSlots == 0:
+			// The counts avoid rounding errors in the sums when there are no bad events.
+			result[i-limit+1] = 0
+		default:
+			result[i-limit+1] = window.bad / window.total
+		}
+	}
+	return result
+}
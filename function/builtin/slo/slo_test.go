@@ -0,0 +1,136 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slo
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/testing_support/assert"
+
+	"golang.org/x/net/context"
+)
+
+type literal struct {
+	value function.Value
+}
+
+func (lit literal) ExpressionString(mode function.DescriptionMode) string {
+	return "<literal>"
+}
+
+func (lit literal) Evaluate(context function.EvaluationContext) (function.Value, error) {
+	return lit.value, nil
+}
+
+func TestObjectiveFunctions(t *testing.T) {
+	n := math.NaN()
+	timerange, err := api.NewSnappedTimerange(0, 4*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	// The series include the extra slot before the timerange for the window.
+	good := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{9, 10, 8, n, 10, 10}, TagSet: api.TagSet{"app": "web", "host": "a"}},
+			{Values: []float64{n, n, n, n, n, n}, TagSet: api.TagSet{"app": "db", "host": "b"}},
+		},
+	}
+	total := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{10, 10, 10, 10, 0, 10}, TagSet: api.TagSet{"app": "web"}},
+			{Values: []float64{10, 10, 10, 10, 10, 10}, TagSet: api.TagSet{"app": "db"}},
+			{Values: []float64{10, 10, 10, 10, 10, 10}, TagSet: api.TagSet{"app": "search"}},
+		},
+	}
+	for _, test := range []struct {
+		fun      function.Function
+		expected [][]float64
+	}{
+		{BurnRate, [][]float64{{0.5, 1, 2, 0, 0}, {n, n, n, n, n}}},
+		{ErrorBudgetRemaining, [][]float64{{0.5, 0, -1, 1, 1}, {n, n, n, n, n}}},
+	} {
+		a := assert.New(t).Contextf("%s", test.fun.Name())
+		ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+		arguments := []function.Expression{
+			literal{function.SeriesListValue(good)},
+			literal{function.SeriesListValue(total)},
+			literal{function.ScalarValue(0.9)},
+			literal{function.NewDurationValue("1m", time.Minute)},
+		}
+		resultValue, err := test.fun.Run(ctx, arguments, function.Groups{})
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, convErr := resultValue.ToSeriesList(timerange)
+		if convErr != nil {
+			t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+		}
+		a.EqInt(len(result.Series), 2)
+		for i, series := range result.Series {
+			a.Eq(series.TagSet, good.Series[i].TagSet)
+			a.EqFloatArray(series.Values, test.expected[i], 1e-9)
+		}
+	}
+}
+
+func TestObjectiveFunctions_Invalid(t *testing.T) {
+	timerange, err := api.NewSnappedTimerange(0, 4*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	list := literal{function.SeriesListValue(api.SeriesList{})}
+	for _, test := range []struct {
+		objective float64
+		window    time.Duration
+	}{
+		{1, time.Minute},
+		{0, time.Minute},
+		{1.5, time.Minute},
+		{0.99, 0},
+	} {
+		ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+		arguments := []function.Expression{list, list, literal{function.ScalarValue(test.objective)}, literal{function.NewDurationValue(test.window.String(), test.window)}}
+		if _, err := BurnRate.Run(ctx, arguments, function.Groups{}); err == nil {
+			t.Errorf("expected an error for objective %g and window %s", test.objective, test.window)
+		}
+	}
+}
+
+func Test_errorRatios(t *testing.T) {
+	// Fractional counts would accumulate rounding errors in a running sum.
+	good := make([]float64, 1000)
+	total := make([]float64, 1000)
+	for i := range total {
+		total[i] = 0.1
+		good[i] = 0.1
+		if i < 500 {
+			good[i] = 0.07
+		}
+	}
+	ratios := errorRatios(good, total, 10)
+	a := assert.New(t)
+	a.EqInt(len(ratios), 991)
+	a.EqFloat(ratios[0], 0.3, 1e-9)
+	for _, ratio := range ratios[500:] {
+		if ratio != 0 {
+			t.Fatalf("expected windows without errors to have a ratio of exactly 0 but got %g", ratio)
+		}
+	}
+}
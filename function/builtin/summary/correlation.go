@@ -0,0 +1,140 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package summary
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/builtin/join"
+)
+
+// correlationTag is the tag which names the measurement of each scalar produced
+// by summarize.cross_correlation.
+const correlationTag = "correlation"
+
+// Correlation computes the Pearson correlation coefficient of each pair of
+// series from the two lists which join (as the arithmetic operators do).
+var Correlation = function.MakeFunction(
+	"summarize.correlation",
+	func(left api.SeriesList, right api.SeriesList) function.ScalarSet {
+		result := function.ScalarSet{}
+		for _, row := range join.Join([]api.SeriesList{left, right}).Rows {
+			result = append(result, function.TaggedScalar{
+				TagSet: row.TagSet,
+				Value:  Pearson(row.Row[0].Values, row.Row[1].Values),
+			})
+		}
+		return result
+	},
+)
+
+// CrossCorrelation finds the lag (up to the given maximum) at which each pair
+// of joined series is most strongly correlated. Each pair gives two scalars,
+// tagged by 'correlation': the "coefficient" at the best lag, and the "lag"
+// itself in milliseconds. A positive lag means that the second series moves
+// before the first. Lags are limited to half of the timerange, so that enough
+// points overlap.
+var CrossCorrelation = function.MakeFunction(
+	"summarize.cross_correlation",
+	func(left api.SeriesList, right api.SeriesList, maxLag time.Duration, timerange api.Timerange) (function.ScalarSet, error) {
+		if maxLag < 0 {
+			return nil, fmt.Errorf("summarize.cross_correlation expects a non-negative maximum lag but got %s", maxLag.String())
+		}
+		maxSlots := int(maxLag / timerange.Resolution())
+		if maxSlots > timerange.Slots()/2 {
+			maxSlots = timerange.Slots() / 2
+		}
+		result := function.ScalarSet{}
+		for _, row := range join.Join([]api.SeriesList{left, right}).Rows {
+			bestLag := 0
+			best := Pearson(row.Row[0].Values, row.Row[1].Values)
+			// Smaller lags are preferred when the correlations are equal.
+			for lag := 1; lag <= maxSlots; lag++ {
+				for _, signed := range []int{-lag, lag} {
+					coefficient := laggedPearson(row.Row[0].Values, row.Row[1].Values, signed)
+					if math.IsNaN(best) || math.Abs(coefficient) > math.Abs(best) {
+						best = coefficient
+						bestLag = signed
+					}
+				}
+			}
+			lag := math.NaN()
+			if !math.IsNaN(best) {
+				lag = float64(int64(bestLag) * timerange.ResolutionMillis())
+			}
+			for _, measure := range []struct {
+				name  string
+				value float64
+			}{
+				{"coefficient", best},
+				{"lag", lag},
+			} {
+				tagSet := row.TagSet.Clone()
+				tagSet[correlationTag] = measure.name
+				result = append(result, function.TaggedScalar{
+					TagSet: tagSet,
+					Value:  measure.value,
+				})
+			}
+		}
+		return result, nil
+	},
+)
+
+// laggedPearson is the correlation of xs with ys delayed by the lag.
+func laggedPearson(xs []float64, ys []float64, lag int) float64 {
+	if lag >= 0 {
+		return Pearson(xs[lag:], ys[:len(ys)-lag])
+	}
+	return Pearson(xs[:len(xs)+lag], ys[-lag:])
+}
+
+// Pearson is the Pearson correlation coefficient of xs and ys, which must have
+// the same length. Pairs where either value is NaN are ignored. It is NaN if
+// there are fewer than two pairs or either has no variance.
+func Pearson(xs []float64, ys []float64) float64 {
+	count := 0.0
+	sumX, sumY := 0.0, 0.0
+	for i := range xs {
+		if math.IsNaN(xs[i]) || math.IsNaN(ys[i]) {
+			continue
+		}
+		sumX += xs[i]
+		sumY += ys[i]
+		count++
+	}
+	if count < 2 {
+		return math.NaN()
+	}
+	meanX, meanY := sumX/count, sumY/count
+	covariance, varianceX, varianceY := 0.0, 0.0, 0.0
+	for i := range xs {
+		if math.IsNaN(xs[i]) || math.IsNaN(ys[i]) {
+			continue
+		}
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+	if varianceX == 0 || varianceY == 0 {
+		return math.NaN()
+	}
+	return covariance / math.Sqrt(varianceX*varianceY)
+}
@@ -0,0 +1,103 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package summary
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/builtin/transform"
+)
+
+// DuringHours summarizes each series by the named summarize function (such as
+// 'mean' or 'coverage'), considering only the slots within the daily window
+// from one local time of day until another (as transform.mask_hours does),
+// excluding the optional list of days of the week (such as 'sat,sun'). Unlike
+// masking the series first, the slots outside of the window are not counted
+// as missing.
+var DuringHours = function.MakeFunction(
+	"summarize.during_hours",
+	func(context function.EvaluationContext, list api.SeriesList, summaryName string, from string, to string, optionalSkippedDays *string, optionalTimezone *string) (function.ScalarSet, error) {
+		skippedDays := ""
+		if optionalSkippedDays != nil {
+			skippedDays = *optionalSkippedDays
+		}
+		schedule, err := transform.NewSchedule(context, from, to, skippedDays, optionalTimezone)
+		if err != nil {
+			return nil, fmt.Errorf("summarize.during_hours %s", err.Error())
+		}
+		if !strings.Contains(summaryName, ".") {
+			summaryName = "summarize." + summaryName
+		}
+		if !strings.HasPrefix(summaryName, "summarize.") || summaryName == "summarize.during_hours" {
+			return nil, fmt.Errorf("summarize.during_hours expects a summarize function but got '%s'", summaryName)
+		}
+		summary, ok := context.RegistryGetFunction(summaryName)
+		if !ok {
+			return nil, fmt.Errorf("summarize.during_hours given unknown summarize function '%s'", summaryName)
+		}
+
+		contained := schedule.Slots(context.Timerange())
+		count := 0
+		for _, ok := range contained {
+			if ok {
+				count++
+			}
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("summarize.during_hours found no slots of the timerange within the window")
+		}
+		within := api.SeriesList{
+			Series: make([]api.Timeseries, len(list.Series)),
+		}
+		for i, series := range list.Series {
+			values := []float64{}
+			for j, value := range series.Values {
+				if j < len(contained) && contained[j] {
+					values = append(values, value)
+				}
+			}
+			within.Series[i] = api.Timeseries{
+				Values: values,
+				TagSet: series.TagSet,
+				Name:   series.Name,
+			}
+		}
+		summaries, err := summary.Run(context, []function.Expression{listExpression{within}}, function.Groups{})
+		if err != nil {
+			return nil, err
+		}
+		scalars, convErr := summaries.ToScalarSet()
+		if convErr != nil {
+			return nil, convErr.WithContext(fmt.Sprintf("the result of %s in summarize.during_hours", summaryName))
+		}
+		return scalars, nil
+	},
+)
+
+// listExpression is an expression for a series list which has already been evaluated.
+type listExpression struct {
+	list api.SeriesList
+}
+
+func (e listExpression) ExpressionString(mode function.DescriptionMode) string {
+	return "<series list>"
+}
+
+func (e listExpression) Evaluate(context function.EvaluationContext) (function.Value, error) {
+	return function.SeriesListValue(e.list), nil
+}
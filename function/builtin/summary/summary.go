@@ -15,11 +15,13 @@
 package summary
 
 import (
+	"fmt"
 	"math"
 	"time"
 
 	"github.com/square/metrics/api"
 	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/builtin/forecast"
 )
 
 var recentScaled = func(name string, summarizer func([]float64, api.Timerange) float64) function.MetricFunction {
@@ -136,6 +138,21 @@ var Count = recent(
 	},
 )
 
+// Coverage computes the fraction of points in the line which are not missing
+var Coverage = recent(
+	"summarize.coverage",
+	func(slice []float64) float64 {
+		count := 0
+		for i := range slice {
+			if math.IsNaN(slice[i]) {
+				continue
+			}
+			count++
+		}
+		return float64(count) / float64(len(slice))
+	},
+)
+
 // Total computes the total number of points in the line
 var Total = recent(
 	"summarize.total",
@@ -201,3 +218,27 @@ var Current = function.MakeFunction(
 		return result
 	},
 )
+
+// DominantPeriod computes the strongest period (in milliseconds, at most the
+// given maximum) in the periodogram of each series, or NaN if there is none.
+var DominantPeriod = function.MakeFunction(
+	"summarize.dominant_period",
+	func(list api.SeriesList, maxPeriod time.Duration, timerange api.Timerange) (function.ScalarSet, error) {
+		maxSlots := int(maxPeriod / timerange.Resolution())
+		if maxSlots < 2 {
+			return nil, fmt.Errorf("summarize.dominant_period expects a maximum period of at least two slots but got %s", maxPeriod.String())
+		}
+		result := function.ScalarSet{}
+		for i := range list.Series {
+			period := math.NaN()
+			if slots := forecast.DominantPeriod(list.Series[i].Values, maxSlots); slots != 0 {
+				period = float64(int64(slots) * timerange.ResolutionMillis())
+			}
+			result = append(result, function.TaggedScalar{
+				TagSet: list.Series[i].TagSet,
+				Value:  period,
+			})
+		}
+		return result, nil
+	},
+)
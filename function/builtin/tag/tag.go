@@ -16,6 +16,7 @@ package tag
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/square/metrics/api"
 	"github.com/square/metrics/function"
@@ -101,6 +102,78 @@ func CopyTag(list api.SeriesList, target string, source string) (api.SeriesList,
 	}, nil
 }
 
+// RenameTag returns a copy of the series list where the `from` tag has been renamed to `to` in each timeseries in the list.
+// Timeseries without the `from` tag are unchanged.
+func RenameTag(list api.SeriesList, from string, to string) (api.SeriesList, error) {
+	if from == "" {
+		return api.SeriesList{}, fmt.Errorf("tag.rename given empty string for source tag")
+	}
+	if to == "" {
+		return api.SeriesList{}, fmt.Errorf("tag.rename given empty string for target tag")
+	}
+	series := make([]api.Timeseries, len(list.Series))
+	for i := range series {
+		series[i] = list.Series[i]
+		if _, ok := list.Series[i].TagSet[from]; ok {
+			series[i] = dropTagSeries(copyTagSeries(list.Series[i], to, from), from)
+		}
+	}
+	return api.SeriesList{
+		Series: series,
+	}, nil
+}
+
+// ReplaceTag returns a copy of the series list where each match of the regular expression in the value of `tag` has been
+// replaced by `replacement`, which may refer to capture groups as $1 or ${name}.
+// If the resulting value is empty, the tag is removed.
+func ReplaceTag(list api.SeriesList, tag string, pattern string, replacement string) (api.SeriesList, error) {
+	if tag == "" {
+		return api.SeriesList{}, fmt.Errorf("tag.replace given empty string for tag")
+	}
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return api.SeriesList{}, fmt.Errorf("tag.replace given invalid regular expression %q: %s", pattern, err.Error())
+	}
+	series := make([]api.Timeseries, len(list.Series))
+	for i := range series {
+		series[i] = list.Series[i]
+		value, ok := list.Series[i].TagSet[tag]
+		if !ok {
+			continue
+		}
+		if replaced := regex.ReplaceAllString(value, replacement); replaced != "" {
+			series[i] = setTagSeries(list.Series[i], tag, replaced)
+		} else {
+			series[i] = dropTagSeries(list.Series[i], tag)
+		}
+	}
+	return api.SeriesList{
+		Series: series,
+	}, nil
+}
+
+// templateTag matches the {{tag}} placeholders in alias templates.
+var templateTag = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// Alias returns a copy of the series list where each timeseries is named by the template, with each {{tag}}
+// placeholder replaced by the value of that tag (or by nothing if the timeseries does not have it).
+func Alias(list api.SeriesList, template string) (api.SeriesList, error) {
+	if template == "" {
+		return api.SeriesList{}, fmt.Errorf("tag.alias given empty string for template")
+	}
+	series := make([]api.Timeseries, len(list.Series))
+	for i := range series {
+		tagSet := list.Series[i].TagSet
+		series[i] = list.Series[i]
+		series[i].Name = templateTag.ReplaceAllStringFunc(template, func(placeholder string) string {
+			return tagSet[templateTag.FindStringSubmatch(placeholder)[1]]
+		})
+	}
+	return api.SeriesList{
+		Series: series,
+	}, nil
+}
+
 // DropFunction wraps up DropTag into a Function called "tag.drop"
 var DropFunction = function.MakeFunction("tag.drop", DropTag)
 
@@ -109,3 +182,12 @@ var SetFunction = function.MakeFunction("tag.set", SetTag)
 
 // CopyFunction wraps up CopyTag into a Function called "tag.copy"
 var CopyFunction = function.MakeFunction("tag.copy", CopyTag)
+
+// RenameFunction wraps up RenameTag into a Function called "tag.rename"
+var RenameFunction = function.MakeFunction("tag.rename", RenameTag)
+
+// ReplaceFunction wraps up ReplaceTag into a Function called "tag.replace"
+var ReplaceFunction = function.MakeFunction("tag.replace", ReplaceTag)
+
+// AliasFunction wraps up Alias into a Function called "tag.alias"
+var AliasFunction = function.MakeFunction("tag.alias", Alias)
@@ -481,3 +481,69 @@ func TestCopy(t *testing.T) {
 		}
 	}
 }
+
+func TestRename(t *testing.T) {
+	a := assert.New(t)
+	list := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{1}, TagSet: api.TagSet{"host": "q12", "dc": "north"}},
+			{Values: []float64{2}, TagSet: api.TagSet{"dc": "south"}},
+		},
+	}
+	result, err := RenameTag(list, "host", "server")
+	a.CheckError(err)
+	a.EqInt(len(result.Series), 2)
+	a.Eq(result.Series[0].TagSet, api.TagSet{"server": "q12", "dc": "north"})
+	a.Eq(result.Series[1].TagSet, api.TagSet{"dc": "south"})
+	// The original list is not modified.
+	a.Eq(list.Series[0].TagSet, api.TagSet{"host": "q12", "dc": "north"})
+
+	if _, err := RenameTag(list, "", "server"); err == nil {
+		t.Errorf("expected an error renaming the empty tag")
+	}
+}
+
+func TestReplace(t *testing.T) {
+	a := assert.New(t)
+	list := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{1}, TagSet: api.TagSet{"host": "web-12.north.example.com"}},
+			{Values: []float64{2}, TagSet: api.TagSet{"host": "db-3.south.example.com"}},
+			{Values: []float64{3}, TagSet: api.TagSet{"dc": "east"}},
+		},
+	}
+	result, err := ReplaceTag(list, "host", `^([a-z]+)-(\d+)\..*$`, "$1$2")
+	a.CheckError(err)
+	a.EqInt(len(result.Series), 3)
+	a.Eq(result.Series[0].TagSet, api.TagSet{"host": "web12"})
+	a.Eq(result.Series[1].TagSet, api.TagSet{"host": "db3"})
+	a.Eq(result.Series[2].TagSet, api.TagSet{"dc": "east"})
+	a.Eq(list.Series[0].TagSet, api.TagSet{"host": "web-12.north.example.com"})
+
+	// Tags whose values are replaced by the empty string are removed.
+	result, err = ReplaceTag(list, "host", `^web-.*`, "")
+	a.CheckError(err)
+	a.Eq(result.Series[0].TagSet, api.TagSet{})
+	a.Eq(result.Series[1].TagSet, api.TagSet{"host": "db-3.south.example.com"})
+
+	if _, err := ReplaceTag(list, "host", `(`, ""); err == nil {
+		t.Errorf("expected an error for an invalid regular expression")
+	}
+}
+
+func TestAlias(t *testing.T) {
+	a := assert.New(t)
+	list := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{1}, TagSet: api.TagSet{"app": "metrics", "host": "q12"}},
+			{Values: []float64{2}, TagSet: api.TagSet{"app": "indexer"}},
+		},
+	}
+	result, err := Alias(list, "{{app}} on {{ host }}")
+	a.CheckError(err)
+	a.EqInt(len(result.Series), 2)
+	a.EqString(result.Series[0].Name, "metrics on q12")
+	a.EqString(result.Series[1].Name, "indexer on ")
+	a.Eq(result.Series[0].TagSet, list.Series[0].TagSet)
+	a.EqString(list.Series[0].Name, "")
+}
@@ -0,0 +1,113 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+)
+
+// CounterRate is the increase per second of each counter series.
+// Like Increase, it detects counter resets and spans gaps of NaN values.
+var CounterRate = newCounterFunction("transform.counter_rate", true)
+
+// Increase is the increase of each counter series since its previous slot.
+// A decrease in the counter is treated as a reset to zero, unless the optional
+// maximum value is given and the counter was above half of it, in which case
+// the counter is assumed to have wrapped around past the maximum. The increase between two values separated by NaN
+// values is spread evenly across the slots between them.
+var Increase = newCounterFunction("transform.increase", false)
+
+// newCounterFunction creates a counter function which, like Rate, fetches one
+// extra slot before the timerange so that the first slot has an increase.
+func newCounterFunction(name string, perSecond bool) function.MetricFunction {
+	return function.MakeFunction(
+		name,
+		func(listExpression function.Expression, optionalMax *float64, context function.EvaluationContext) (api.SeriesList, error) {
+			if optionalMax != nil && !(*optionalMax > 0) {
+				return api.SeriesList{}, fmt.Errorf("%s expects a positive maximum counter value but got %g", name, *optionalMax)
+			}
+			newContext := context.WithTimerange(context.Timerange().ExtendBefore(context.Timerange().Resolution()))
+			list, err := function.EvaluateToSeriesList(listExpression, newContext)
+			if err != nil {
+				return api.SeriesList{}, err
+			}
+			scale := 1.0
+			if perSecond {
+				scale = context.Timerange().Resolution().Seconds()
+			}
+			resultList := api.SeriesList{
+				Series: make([]api.Timeseries, len(list.Series)),
+			}
+			for seriesIndex, series := range list.Series {
+				increases, resets := counterIncreases(series.Values, optionalMax)
+				if resets > 0 {
+					context.AddNote(fmt.Sprintf("%s(%v): the counter reset %d time(s)", name, series.TagSet, resets))
+				}
+				for i := range increases {
+					increases[i] /= scale
+				}
+				resultList.Series[seriesIndex] = api.Timeseries{
+					Values: increases,
+					TagSet: series.TagSet,
+					Name:   series.Name,
+				}
+			}
+			return resultList, nil
+		},
+	)
+}
+
+// counterIncreases computes the increase of the counter into each slot after
+// the first, along with the number of resets (or wraps) which were detected.
+// Slots before the first value or after the last value are NaN.
+func counterIncreases(values []float64, maxValue *float64) ([]float64, int) {
+	if len(values) == 0 {
+		return []float64{}, 0
+	}
+	increases := make([]float64, len(values)-1)
+	for i := range increases {
+		increases[i] = math.NaN()
+	}
+	resets := 0
+	last := -1 // the index of the most recent non-NaN value
+	for i, value := range values {
+		if math.IsNaN(value) {
+			continue
+		}
+		if last >= 0 {
+			delta := value - values[last]
+			if delta < 0 {
+				resets++
+				if maxValue != nil && values[last] > *maxValue/2 {
+					// The counter was near its maximum, so it wrapped around to zero.
+					delta = *maxValue - values[last] + value + 1
+				} else {
+					// The counter restarted from zero, so it has increased by at least its new value.
+					delta = math.Max(value, 0)
+				}
+			}
+			share := delta / float64(i-last)
+			for j := last + 1; j <= i; j++ {
+				increases[j-1] = share
+			}
+		}
+		last = i
+	}
+	return increases, resets
+}
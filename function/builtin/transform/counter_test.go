@@ -0,0 +1,106 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"math"
+	"testing"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/testing_support/assert"
+
+	"golang.org/x/net/context"
+)
+
+func TestCounterFunctions(t *testing.T) {
+	nan := math.NaN()
+	timerange, err := api.NewSnappedTimerange(0, 4*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	for _, test := range []struct {
+		name      string
+		values    []float64 // includes the extra slot before the timerange
+		max       *float64
+		increases []float64
+		resets    int
+	}{
+		{"steady", []float64{0, 3, 6, 9, 12, 15}, nil, []float64{3, 3, 3, 3, 3}, 0},
+		{"reset", []float64{10, 20, 30, 5, 15, 25}, nil, []float64{10, 10, 5, 10, 10}, 1},
+		{"reset in the first slot", []float64{100, 4, 8, 12, 16, 20}, nil, []float64{4, 4, 4, 4, 4}, 1},
+		{"reset in the last slot", []float64{1, 2, 3, 4, 5, 2}, nil, []float64{1, 1, 1, 1, 2}, 1},
+		{"gap", []float64{0, 2, nan, nan, 8, 10}, nil, []float64{2, 2, 2, 2, 2}, 0},
+		{"reset across a gap", []float64{0, 50, nan, 6, 9, 12}, nil, []float64{50, 3, 3, 3, 3}, 1},
+		{"missing extra slot", []float64{nan, 1, 2, 3, 4, 5}, nil, []float64{nan, 1, 1, 1, 1}, 0},
+		{"missing edges", []float64{nan, nan, 2, 4, nan, nan}, nil, []float64{nan, nan, 2, nan, nan}, 0},
+		{"wrap", []float64{250, 254, 2, 6, 10, 14}, floatPtr(255), []float64{4, 4, 4, 4, 4}, 1},
+		{"reset of a wrapping counter", []float64{990, 1000, 5, 15, 25, 35}, floatPtr(math.MaxUint32), []float64{10, 5, 10, 10, 10}, 1},
+		{"empty", []float64{nan, nan, nan, nan, nan, nan}, nil, []float64{nan, nan, nan, nan, nan}, 0},
+	} {
+		a := assert.New(t).Contextf("%s", test.name)
+		list := api.SeriesList{
+			Series: []api.Timeseries{{Values: test.values, TagSet: api.TagSet{"host": "a"}}},
+		}
+		arguments := []function.Expression{literal{function.SeriesListValue(list)}}
+		if test.max != nil {
+			arguments = append(arguments, literal{function.ScalarValue(*test.max)})
+		}
+		for _, counter := range []struct {
+			fun   function.Function
+			scale float64
+		}{
+			{Increase, 1},
+			{CounterRate, 30},
+		} {
+			ctx := function.EvaluationContextBuilder{EvaluationNotes: &function.EvaluationNotes{}, Timerange: timerange, Ctx: context.Background()}.Build()
+			resultValue, err := counter.fun.Run(ctx, arguments, function.Groups{})
+			a.CheckError(err)
+			if err != nil {
+				continue
+			}
+			result, convErr := resultValue.ToSeriesList(ctx.Timerange())
+			if convErr != nil {
+				t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+			}
+			a.EqInt(len(result.Series), 1)
+			expected := make([]float64, len(test.increases))
+			for i := range expected {
+				expected[i] = test.increases[i] / counter.scale
+			}
+			a.EqFloatArray(result.Series[0].Values, expected, 1e-7)
+			a.Eq(result.Series[0].TagSet, api.TagSet{"host": "a"})
+			a.EqInt(len(ctx.Notes()), test.resets)
+		}
+	}
+}
+
+func TestCounterFunctions_InvalidMax(t *testing.T) {
+	timerange, err := api.NewSnappedTimerange(0, 4*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	list := literal{function.SeriesListValue(api.SeriesList{})}
+	for _, max := range []float64{0, -10, math.NaN()} {
+		ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+		if _, err := Increase.Run(ctx, []function.Expression{list, literal{function.ScalarValue(max)}}, function.Groups{}); err == nil {
+			t.Errorf("expected an error for maximum counter value %g", max)
+		}
+	}
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,180 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+)
+
+// MaskHours replaces the values outside of the daily window from the first
+// local time of day until the second (such as '09:00' and '17:00') with NaN.
+// The window wraps past midnight when it ends before it starts, so that
+// ('17:00', '09:00') masks the values inside of business hours instead. Local
+// times are in the optional timezone, or else the timezone of the query.
+var MaskHours = function.MakeFunction(
+	"transform.mask_hours",
+	func(context function.EvaluationContext, list api.SeriesList, from string, to string, optionalTimezone *string) (api.SeriesList, error) {
+		schedule, err := NewSchedule(context, from, to, "", optionalTimezone)
+		if err != nil {
+			return api.SeriesList{}, fmt.Errorf("transform.mask_hours %s", err.Error())
+		}
+		return schedule.mask(list, context.Timerange()), nil
+	},
+)
+
+// MaskWeekdays replaces the values on the listed days of the week (such as
+// 'sat,sun') with NaN. Days are in the optional timezone, or else the timezone
+// of the query.
+var MaskWeekdays = function.MakeFunction(
+	"transform.mask_weekdays",
+	func(context function.EvaluationContext, list api.SeriesList, days string, optionalTimezone *string) (api.SeriesList, error) {
+		schedule, err := NewSchedule(context, "", "", days, optionalTimezone)
+		if err != nil {
+			return api.SeriesList{}, fmt.Errorf("transform.mask_weekdays %s", err.Error())
+		}
+		return schedule.mask(list, context.Timerange()), nil
+	},
+)
+
+// A Schedule selects times by their local time of day and day of the week.
+type Schedule struct {
+	From        int // minutes after local midnight
+	To          int // minutes after local midnight; the window wraps past midnight if To < From
+	SkippedDays map[time.Weekday]bool
+	Location    *time.Location
+}
+
+// NewSchedule creates a schedule for the daily window from one local time of
+// day until another (all day if both are empty), excluding the listed days of
+// the week. Local times are in the optional timezone, or else the timezone of
+// the context.
+func NewSchedule(context function.EvaluationContext, from string, to string, skippedDays string, optionalTimezone *string) (Schedule, error) {
+	schedule := Schedule{
+		From:        0,
+		To:          24 * 60,
+		SkippedDays: map[time.Weekday]bool{},
+		Location:    context.Timezone(),
+	}
+	if optionalTimezone != nil {
+		location, err := time.LoadLocation(*optionalTimezone)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("expects a timezone name (such as 'America/New_York') but got '%s'", *optionalTimezone)
+		}
+		schedule.Location = location
+	}
+	if from != "" || to != "" {
+		var err error
+		if schedule.From, err = parseClock(from); err != nil {
+			return Schedule{}, err
+		}
+		if schedule.To, err = parseClock(to); err != nil {
+			return Schedule{}, err
+		}
+		if schedule.From == schedule.To {
+			return Schedule{}, fmt.Errorf("expects a window which does not start and end at '%s'", from)
+		}
+	}
+	if skippedDays != "" {
+		for _, name := range strings.Split(skippedDays, ",") {
+			day, err := parseWeekday(name)
+			if err != nil {
+				return Schedule{}, err
+			}
+			schedule.SkippedDays[day] = true
+		}
+	}
+	return schedule, nil
+}
+
+// Contains determines whether the time is in the schedule.
+func (s Schedule) Contains(t time.Time) bool {
+	local := t.In(s.Location)
+	if s.SkippedDays[local.Weekday()] {
+		return false
+	}
+	minutes := local.Hour()*60 + local.Minute()
+	if s.From <= s.To {
+		return s.From <= minutes && minutes < s.To
+	}
+	return s.From <= minutes || minutes < s.To
+}
+
+// Slots determines whether each slot of the timerange starts in the schedule.
+func (s Schedule) Slots(timerange api.Timerange) []bool {
+	result := make([]bool, timerange.Slots())
+	for i := range result {
+		millis := timerange.StartMillis() + int64(i)*timerange.ResolutionMillis()
+		result[i] = s.Contains(time.Unix(0, millis*int64(time.Millisecond)))
+	}
+	return result
+}
+
+// mask replaces the values of the list which are outside of the schedule with NaN.
+func (s Schedule) mask(list api.SeriesList, timerange api.Timerange) api.SeriesList {
+	contained := s.Slots(timerange)
+	result := api.SeriesList{
+		Series: make([]api.Timeseries, len(list.Series)),
+	}
+	for i, series := range list.Series {
+		values := make([]float64, len(series.Values))
+		for j, value := range series.Values {
+			if j < len(contained) && contained[j] {
+				values[j] = value
+			} else {
+				values[j] = math.NaN()
+			}
+		}
+		result.Series[i] = api.Timeseries{
+			Values: values,
+			TagSet: series.TagSet,
+			Name:   series.Name,
+		}
+	}
+	return result
+}
+
+// parseClock parses a local time of day such as '09:00' or '17:30' (or '24:00'
+// for the end of the day) into the number of minutes after midnight.
+func parseClock(text string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(text), ":")
+	if len(parts) == 2 {
+		hours, hoursErr := strconv.Atoi(parts[0])
+		minutes, minutesErr := strconv.Atoi(parts[1])
+		if hoursErr == nil && minutesErr == nil && 0 <= hours && 0 <= minutes && minutes < 60 && hours*60+minutes <= 24*60 {
+			return hours*60 + minutes, nil
+		}
+	}
+	return 0, fmt.Errorf("expects a time of day such as '09:00' but got '%s'", text)
+}
+
+// parseWeekday parses the name of a day of the week, such as 'sat' or 'Saturday'.
+func parseWeekday(text string) (time.Weekday, error) {
+	name := strings.ToLower(strings.TrimSpace(text))
+	if len(name) >= 3 {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.HasPrefix(strings.ToLower(day.String()), name) {
+				return day, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("expects days of the week such as 'sat,sun' but got '%s'", text)
+}
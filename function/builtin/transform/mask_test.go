@@ -0,0 +1,117 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/testing_support/assert"
+
+	"golang.org/x/net/context"
+)
+
+func TestMasks(t *testing.T) {
+	n := math.NaN()
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Error loading timezone: %s", err.Error())
+	}
+	monday := int64(1451865600000)     // 2016-01-04 00:00 UTC
+	friday := monday - 3*24*60*60*1000 // 2016-01-01 00:00 UTC
+	hours, err := api.NewTimerange(monday, monday+21*60*60*1000, 3*60*60*1000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	days, err := api.NewTimerange(friday, friday+6*24*60*60*1000, 24*60*60*1000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	for _, test := range []struct {
+		name      string
+		fun       function.Function
+		timerange api.Timerange
+		timezone  *time.Location
+		arguments []string
+		expected  []float64
+	}{
+		{"business hours", MaskHours, hours, nil, []string{"09:00", "17:00"}, []float64{n, n, n, 3, 4, 5, n, n}},
+		{"outside business hours", MaskHours, hours, nil, []string{"17:00", "09:00"}, []float64{0, 1, 2, n, n, n, 6, 7}},
+		{"to the end of the day", MaskHours, hours, nil, []string{"20:00", "24:00"}, []float64{n, n, n, n, n, n, n, 7}},
+		{"in a timezone", MaskHours, hours, nil, []string{"09:00", "17:00", "America/New_York"}, []float64{n, n, n, n, n, 5, 6, 7}},
+		{"in the query's timezone", MaskHours, hours, newYork, []string{"09:00", "17:00"}, []float64{n, n, n, n, n, 5, 6, 7}},
+		{"weekends", MaskWeekdays, days, nil, []string{"sat,sun"}, []float64{0, n, n, 3, 4, 5, 6}},
+		{"full names", MaskWeekdays, days, nil, []string{" Monday , friday"}, []float64{n, 1, 2, n, 4, 5, 6}},
+		{"weekends in a timezone", MaskWeekdays, days, nil, []string{"sat,sun", "America/New_York"}, []float64{0, 1, n, n, 4, 5, 6}},
+	} {
+		a := assert.New(t).Contextf("%s", test.name)
+		values := make([]float64, test.timerange.Slots())
+		for i := range values {
+			values[i] = float64(i)
+		}
+		list := api.SeriesList{
+			Series: []api.Timeseries{{Values: values, TagSet: api.TagSet{"host": "a"}}},
+		}
+		arguments := []function.Expression{literal{function.SeriesListValue(list)}}
+		for _, argument := range test.arguments {
+			arguments = append(arguments, literal{function.StringValue(argument)})
+		}
+		ctx := function.EvaluationContextBuilder{Timerange: test.timerange, Timezone: test.timezone, Ctx: context.Background()}.Build()
+		resultValue, err := test.fun.Run(ctx, arguments, function.Groups{})
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, convErr := resultValue.ToSeriesList(test.timerange)
+		if convErr != nil {
+			t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+		}
+		a.EqInt(len(result.Series), 1)
+		a.EqFloatArray(result.Series[0].Values, test.expected, 1e-7)
+		a.Eq(result.Series[0].TagSet, api.TagSet{"host": "a"})
+	}
+}
+
+func TestMasks_Invalid(t *testing.T) {
+	timerange, err := api.NewSnappedTimerange(0, 4*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	list := literal{function.SeriesListValue(api.SeriesList{})}
+	for _, test := range []struct {
+		fun       function.Function
+		arguments []string
+	}{
+		{MaskHours, []string{"9am", "17:00"}},
+		{MaskHours, []string{"09:00", "25:00"}},
+		{MaskHours, []string{"09:60", "17:00"}},
+		{MaskHours, []string{"09:00", "09:00"}},
+		{MaskHours, []string{"09:00", "17:00", "Mars/Olympus_Mons"}},
+		{MaskWeekdays, []string{"sat,funday"}},
+		{MaskWeekdays, []string{"s"}},
+	} {
+		arguments := []function.Expression{list}
+		for _, argument := range test.arguments {
+			arguments = append(arguments, literal{function.StringValue(argument)})
+		}
+		ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+		if _, err := test.fun.Run(ctx, arguments, function.Groups{}); err == nil {
+			t.Errorf("expected an error for %s%v", test.fun.Name(), test.arguments)
+		}
+	}
+}
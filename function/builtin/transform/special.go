@@ -17,10 +17,13 @@ package transform
 import (
 	"fmt"
 	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/square/metrics/api"
 	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/builtin/aggregate"
 )
 
 var Timeshift = function.MakeFunction(
@@ -38,11 +41,7 @@ var MovingAverage = function.MakeFunction(
 			return api.SeriesList{}, fmt.Errorf("transform.moving_average must be given a non-negative duration")
 		}
 		// Applying a similar trick as did TimeshiftFunction. It fetches data prior to the start of the timerange.
-		limit := int(float64(size)/float64(context.Timerange().Resolution()) + 0.5) // Limit is the number of items to include in the average
-		if limit < 1 {
-			// At least one value must be included at all times
-			limit = 1
-		}
+		limit := windowSlots(size, context.Timerange().Resolution()) // Limit is the number of items to include in the average
 
 		timerange := context.Timerange()
 		newTimerange := timerange.ExtendBefore(time.Duration(limit-1) * timerange.Resolution())
@@ -86,6 +85,97 @@ var MovingAverage = function.MakeFunction(
 	},
 )
 
+// Summarize aggregates each series over consecutive windows of the given
+// duration, aligned to multiples of the duration, and gives every slot the
+// summary of its window. Windows at the edges of the timerange are filled using
+// data from outside of it.
+var Summarize = function.MakeFunction(
+	"transform.summarize",
+	func(context function.EvaluationContext, listExpression function.Expression, size time.Duration, method string) (api.SeriesList, error) {
+		summarizer, ok := windowSummarizers[method]
+		if !ok {
+			methods := []string{}
+			for name := range windowSummarizers {
+				methods = append(methods, fmt.Sprintf("'%s'", name))
+			}
+			sort.Strings(methods)
+			return api.SeriesList{}, fmt.Errorf("transform.summarize expects one of %s but got '%s'", strings.Join(methods, ", "), method)
+		}
+		if size <= 0 {
+			return api.SeriesList{}, fmt.Errorf("transform.summarize must be given a positive duration")
+		}
+		timerange := context.Timerange()
+		slots := windowSlots(size, timerange.Resolution())
+		window := int64(slots) * timerange.ResolutionMillis()
+
+		// Extend the timerange so that it begins and ends on window boundaries.
+		before := positiveModulo(timerange.StartMillis()-timerange.OffsetMillis(), window)
+		after := window - timerange.ResolutionMillis() - positiveModulo(timerange.EndMillis()-timerange.OffsetMillis(), window)
+		newTimerange := timerange.ExtendBefore(time.Duration(before) * time.Millisecond).ExtendAfter(time.Duration(after) * time.Millisecond)
+		list, err := function.EvaluateToSeriesList(listExpression, context.WithTimerange(newTimerange))
+		if err != nil {
+			return api.SeriesList{}, err
+		}
+
+		first := int(before / timerange.ResolutionMillis())
+		resultList := api.SeriesList{
+			Series: make([]api.Timeseries, len(list.Series)),
+		}
+		for i, series := range list.Series {
+			values := make([]float64, len(series.Values))
+			for start := 0; start < len(values); start += slots {
+				end := start + slots
+				if end > len(values) {
+					end = len(values)
+				}
+				summary := summarizer(series.Values[start:end])
+				for t := start; t < end; t++ {
+					values[t] = summary
+				}
+			}
+			resultList.Series[i] = api.Timeseries{
+				Values: values[first : first+timerange.Slots()],
+				TagSet: series.TagSet,
+				Name:   series.Name,
+			}
+		}
+		return resultList, nil
+	},
+)
+
+// windowSummarizers are the methods by which transform.summarize can summarize a window.
+var windowSummarizers = map[string]func([]float64) float64{
+	"max":   aggregate.Max,
+	"min":   aggregate.Min,
+	"mean":  aggregate.Mean,
+	"sum":   aggregate.Sum,
+	"count": aggregate.Count,
+	"last": func(values []float64) float64 {
+		for i := len(values) - 1; i >= 0; i-- {
+			if !math.IsNaN(values[i]) {
+				return values[i]
+			}
+		}
+		return math.NaN()
+	},
+}
+
+// windowSlots is the number of slots in a window of the given duration,
+// rounded to the nearest slot but always at least one.
+func windowSlots(size time.Duration, resolution time.Duration) int {
+	slots := int(float64(size)/float64(resolution) + 0.5)
+	if slots < 1 {
+		// At least one value must be included at all times
+		slots = 1
+	}
+	return slots
+}
+
+// positiveModulo is the remainder of n divided by m, in the range [0, m).
+func positiveModulo(n int64, m int64) int64 {
+	return ((n % m) + m) % m
+}
+
 var ExponentialMovingAverage = function.MakeFunction(
 	"transform.exponential_moving_average",
 	func(context function.EvaluationContext, listExpression function.Expression, size time.Duration) (api.SeriesList, error) {
@@ -126,6 +216,7 @@ var ExponentialMovingAverage = function.MakeFunction(
 			resultList.Series[i] = api.Timeseries{
 				Values: values[newTimerange.Slots()-timerange.Slots():],
 				TagSet: list.Series[i].TagSet,
+				Name:   list.Series[i].Name,
 			}
 		}
 		return resultList, nil
@@ -157,6 +248,7 @@ var Derivative = function.MakeFunction(
 			resultList.Series[seriesIndex] = api.Timeseries{
 				Values: newValues,
 				TagSet: series.TagSet, // TODO: verify that these are immutable
+				Name:   series.Name,
 			}
 		}
 		return resultList, nil
@@ -203,6 +295,7 @@ var Rate = function.MakeFunction(
 			resultList.Series[seriesIndex] = api.Timeseries{
 				Values: newValues,
 				TagSet: series.TagSet, // TODO: verify that these are immutable
+				Name:   series.Name,
 			}
 		}
 		return resultList, nil
@@ -19,6 +19,7 @@ package transform
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/square/metrics/api"
 	"github.com/square/metrics/function"
@@ -32,6 +33,7 @@ func transformEach(list api.SeriesList, transformation func([]float64) []float64
 		resultList.Series[seriesIndex] = api.Timeseries{
 			Values: transformation(series.Values),
 			TagSet: series.TagSet, // TODO: verify that these are immutable
+			Name:   series.Name,
 		}
 	}
 	return resultList
@@ -97,7 +99,7 @@ var Cumulative = function.MakeFunction(
 // MapMaker can be used to use a function as a transform, such as 'math.Abs' (or similar):
 //  `MapMaker(math.Abs)` is a transform function which can be used, e.g. with ApplyTransform
 // The name is used for error-checking purposes.
-func MapMaker(name string, fun func(float64) float64) function.Function {
+func MapMaker(name string, fun func(float64) float64) function.MetricFunction {
 	return function.MakeFunction(
 		name,
 		func(list api.SeriesList, timerange api.Timerange) api.SeriesList {
@@ -142,6 +144,82 @@ var NaNKeepLast = function.MakeFunction(
 	},
 )
 
+// Interpolate replaces runs of missing (NaN) data with values estimated from the
+// data around them: 'linear' interpolates between the values on either side,
+// 'previous' repeats the value before and 'next' repeats the value after.
+// Runs longer than the optional maximum gap are left missing.
+var Interpolate = function.MakeFunction(
+	"transform.interpolate",
+	func(list api.SeriesList, method string, optionalMaxGap *time.Duration, timerange api.Timerange) (api.SeriesList, error) {
+		if method != "linear" && method != "previous" && method != "next" {
+			return api.SeriesList{}, fmt.Errorf("transform.interpolate expects one of 'linear', 'previous' or 'next' but got '%s'", method)
+		}
+		maxGap := math.MaxInt32
+		if optionalMaxGap != nil {
+			if *optionalMaxGap < 0 {
+				return api.SeriesList{}, fmt.Errorf("transform.interpolate must be given a non-negative maximum gap")
+			}
+			maxGap = int(*optionalMaxGap / timerange.Resolution())
+		}
+		return transformEach(list, func(values []float64) []float64 {
+			result := make([]float64, len(values))
+			copy(result, values)
+			for start := 0; start < len(values); start++ {
+				if !math.IsNaN(values[start]) {
+					continue
+				}
+				// The gap spans [start, end).
+				end := start
+				for end < len(values) && math.IsNaN(values[end]) {
+					end++
+				}
+				hasPrevious, hasNext := start > 0, end < len(values)
+				if end-start <= maxGap {
+					for i := start; i < end; i++ {
+						switch {
+						case method == "linear" && hasPrevious && hasNext:
+							fraction := float64(i-start+1) / float64(end-start+1)
+							result[i] = values[start-1] + fraction*(values[end]-values[start-1])
+						case method == "previous" && hasPrevious:
+							result[i] = values[start-1]
+						case method == "next" && hasNext:
+							result[i] = values[end]
+						}
+					}
+				}
+				start = end
+			}
+			return result
+		}), nil
+	},
+)
+
+// NaNDropSeries removes the series whose fraction of values which are not NaN
+// is less than the given minimum fraction.
+var NaNDropSeries = function.MakeFunction(
+	"transform.nan_drop_series",
+	func(list api.SeriesList, minFraction float64) (api.SeriesList, error) {
+		if !(0 <= minFraction && minFraction <= 1) {
+			return api.SeriesList{}, fmt.Errorf("transform.nan_drop_series expects a fraction between 0 and 1 but got %g", minFraction)
+		}
+		result := api.SeriesList{
+			Series: []api.Timeseries{},
+		}
+		for _, series := range list.Series {
+			present := 0
+			for _, value := range series.Values {
+				if !math.IsNaN(value) {
+					present++
+				}
+			}
+			if len(series.Values) > 0 && float64(present) >= minFraction*float64(len(series.Values)) {
+				result.Series = append(result.Series, series)
+			}
+		}
+		return result, nil
+	},
+)
+
 // boundError represents an error in bounds, when (lower > upper) so the interval is empty.
 type boundError struct {
 	lower float64
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/square/metrics/api"
 	"github.com/square/metrics/function"
@@ -548,6 +549,147 @@ func TestApplyTransformNaN(t *testing.T) {
 	}
 }
 
+func TestSummarize(t *testing.T) {
+	nan := math.NaN()
+	// The windows of 90s which cover the timerange span the slots from 0 to 240s.
+	timerange, err := api.NewSnappedTimerange(60000, 240000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	list := api.SeriesList{
+		Series: []api.Timeseries{{
+			Values: []float64{1, 5, 2, nan, 4, 3, 7, nan, nan},
+			TagSet: api.TagSet{"series": "A"},
+		}},
+	}
+	for _, test := range []struct {
+		method   string
+		expected []float64
+	}{
+		{"max", []float64{5, 4, 4, 4, 7, 7, 7}},
+		{"min", []float64{1, 3, 3, 3, 7, 7, 7}},
+		{"mean", []float64{8.0 / 3, 3.5, 3.5, 3.5, 7, 7, 7}},
+		{"sum", []float64{8, 7, 7, 7, 7, 7, 7}},
+		{"count", []float64{3, 2, 2, 2, 1, 1, 1}},
+		{"last", []float64{2, 3, 3, 3, 7, 7, 7}},
+	} {
+		a := assert.New(t).Contextf("%s", test.method)
+		ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+		resultValue, err := Summarize.Run(ctx, []function.Expression{
+			literal{function.SeriesListValue(list)},
+			literal{function.NewDurationValue("90s", 90*time.Second)},
+			literal{function.StringValue(test.method)},
+		}, function.Groups{})
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, convErr := resultValue.ToSeriesList(ctx.Timerange())
+		if convErr != nil {
+			t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+		}
+		a.EqInt(len(result.Series), 1)
+		a.EqFloatArray(result.Series[0].Values, test.expected, 1e-7)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	nan := math.NaN()
+	timerange, err := api.NewSnappedTimerange(0, 7*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	list := api.SeriesList{
+		Series: []api.Timeseries{{
+			Values: []float64{nan, 1, nan, 3, nan, nan, nan, 7},
+			TagSet: api.TagSet{"series": "A"},
+		}},
+	}
+	for _, test := range []struct {
+		method   string
+		maxGap   time.Duration // zero for no maximum
+		expected []float64
+	}{
+		{"linear", 0, []float64{nan, 1, 2, 3, 4, 5, 6, 7}},
+		{"linear", 60 * time.Second, []float64{nan, 1, 2, 3, nan, nan, nan, 7}},
+		{"previous", 0, []float64{nan, 1, 1, 3, 3, 3, 3, 7}},
+		{"next", 90 * time.Second, []float64{1, 1, 3, 3, 7, 7, 7, 7}},
+		{"next", 30 * time.Second, []float64{1, 1, 3, 3, nan, nan, nan, 7}},
+	} {
+		a := assert.New(t).Contextf("%s with maximum gap %s", test.method, test.maxGap)
+		arguments := []function.Expression{
+			literal{function.SeriesListValue(list)},
+			literal{function.StringValue(test.method)},
+		}
+		if test.maxGap != 0 {
+			arguments = append(arguments, literal{function.NewDurationValue(test.maxGap.String(), test.maxGap)})
+		}
+		ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+		resultValue, err := Interpolate.Run(ctx, arguments, function.Groups{})
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, convErr := resultValue.ToSeriesList(ctx.Timerange())
+		if convErr != nil {
+			t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+		}
+		a.EqFloatArray(result.Series[0].Values, test.expected, 1e-7)
+	}
+
+	ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+	if _, err := Interpolate.Run(ctx, []function.Expression{literal{function.SeriesListValue(list)}, literal{function.StringValue("cubic")}}, function.Groups{}); err == nil {
+		t.Errorf("expected an error for an unknown interpolation method")
+	}
+}
+
+func TestNaNDropSeries(t *testing.T) {
+	a := assert.New(t)
+	nan := math.NaN()
+	timerange, err := api.NewSnappedTimerange(0, 3*30000, 30000)
+	if err != nil {
+		t.Fatalf("Error creating test timerange: %s", err.Error())
+	}
+	list := api.SeriesList{
+		Series: []api.Timeseries{
+			{Values: []float64{1, 2, 3, 4}, TagSet: api.TagSet{"series": "full"}},
+			{Values: []float64{1, nan, 3, nan}, TagSet: api.TagSet{"series": "half"}},
+			{Values: []float64{nan, nan, nan, 4}, TagSet: api.TagSet{"series": "sparse"}},
+			{Values: []float64{nan, nan, nan, nan}, TagSet: api.TagSet{"series": "empty"}},
+		},
+	}
+	for _, test := range []struct {
+		minFraction float64
+		expected    []string
+	}{
+		{0, []string{"full", "half", "sparse", "empty"}},
+		{0.25, []string{"full", "half", "sparse"}},
+		{0.5, []string{"full", "half"}},
+		{1, []string{"full"}},
+	} {
+		ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+		resultValue, err := NaNDropSeries.Run(ctx, []function.Expression{literal{function.SeriesListValue(list)}, literal{function.ScalarValue(test.minFraction)}}, function.Groups{})
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, convErr := resultValue.ToSeriesList(ctx.Timerange())
+		if convErr != nil {
+			t.Fatalf("error converting to series list: %s", convErr.WithContext("test case"))
+		}
+		names := []string{}
+		for _, series := range result.Series {
+			names = append(names, series.TagSet["series"])
+		}
+		a.Contextf("minimum fraction %g", test.minFraction).Eq(names, test.expected)
+	}
+
+	ctx := function.EvaluationContextBuilder{Timerange: timerange, Ctx: context.Background()}.Build()
+	if _, err := NaNDropSeries.Run(ctx, []function.Expression{literal{function.SeriesListValue(list)}, literal{function.ScalarValue(1.5)}}, function.Groups{}); err == nil {
+		t.Errorf("expected an error for a fraction above 1")
+	}
+}
+
 // Test that the transforms of the following work as expected:
 // - transform.derivative | transform.integral
 func TestTransformIdentity(t *testing.T) {
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/square/metrics/api"
 	"github.com/square/metrics/inspect"
@@ -38,6 +39,8 @@ type EvaluationContextBuilder struct {
 	FetchLimit           FetchCounter            // A limit on the number of fetches which may be performed
 	Profiler             *inspect.Profiler       // A profiler pointer
 	EvaluationNotes      *EvaluationNotes        // Debug + numerical notes that can be added during evaluation
+	Timezone             *time.Location          // Timezone used to interpret local times (UTC if nil)
+	PredicateParser      predicate.Parser        // Parses predicates given to functions as strings (optional)
 	Ctx                  context.Context
 
 	// These may be changed in sub-contexts while evaluating the query.
@@ -83,11 +86,29 @@ func (context EvaluationContext) SampleMethod() timeseries.SampleMethod {
 	return context.private.SampleMethod
 }
 
+// Timezone returns the timezone used to interpret local times, which is UTC
+// unless the query specified one.
+func (context EvaluationContext) Timezone() *time.Location {
+	if context.private.Timezone == nil {
+		return time.UTC
+	}
+	return context.private.Timezone
+}
+
 // Predicate returns the underlying predicate.Predicate.
 func (context EvaluationContext) Predicate() predicate.Predicate {
 	return context.private.Predicate
 }
 
+// ParsePredicate parses a predicate written as in the where clause of a query,
+// such as "dc = 'west' and not host match '^canary'".
+func (context EvaluationContext) ParsePredicate(text string) (predicate.Predicate, error) {
+	if context.private.PredicateParser == nil {
+		return nil, fmt.Errorf("no predicate parser was provided to the evaluation context")
+	}
+	return context.private.PredicateParser(text)
+}
+
 // FetchLimitConsume tries to consume the amount of resources from the limit,
 // returning a non-nil error if this would overdraw the alloted limit.
 func (context EvaluationContext) FetchLimitConsume(n int) error {
@@ -14,7 +14,10 @@
 
 package function
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // The Function interface defines a metric function.
 // It is given several (unevaluated) expressions as input, and evaluates to a Value.
@@ -30,6 +33,12 @@ type Registry interface {
 	All() []string                       // all the registered functions
 }
 
+// A DescribedFunction is a Function which can describe its own usage.
+type DescribedFunction interface {
+	Function
+	Describe() Description
+}
+
 // Groups holds grouping information - which tags to group by (if any), and whether to `collapse` (Collapses = true) or `group` (Collapses = false)
 type Groups struct {
 	List      []string // the tags to group by
@@ -43,6 +52,53 @@ type MetricFunction struct {
 	MaxArguments  int    // MaxArguments is the maximum number of arguments the function allows. -1 indicates an unlimited number.
 	AllowsGroupBy bool   // Whether the function allows a 'group by' clause.
 	Compute       func(EvaluationContext, []Expression, Groups) (Value, error)
+	Signature     *Signature // optional; the types of the function's arguments and result.
+	Description   string     // optional; a short human-readable explanation of the function.
+}
+
+// Parameter describes a single argument accepted by a function.
+type Parameter struct {
+	Kind     string `json:"kind"` // one of "string", "scalar", "scalar set", "duration", "series list", "value", or "expression"
+	Optional bool   `json:"optional"`
+}
+
+// Signature describes the arguments accepted by a function and the type of its result.
+type Signature struct {
+	Parameters    []Parameter `json:"parameters"`
+	Variadic      bool        `json:"variadic,omitempty"` // whether the last parameter may be repeated
+	AllowsGroupBy bool        `json:"allows_group_by"`
+	Returns       string      `json:"returns"`
+}
+
+// Format renders the signature as it would be called by name, for example
+// "transform.moving_average(series list, duration) -> series list".
+func (s Signature) Format(name string) string {
+	parameters := make([]string, len(s.Parameters))
+	for i, parameter := range s.Parameters {
+		parameters[i] = parameter.Kind
+		if parameter.Optional {
+			parameters[i] = "[" + parameter.Kind + "]"
+		}
+	}
+	if s.Variadic && len(parameters) > 0 {
+		parameters[len(parameters)-1] += "..."
+	}
+	groupBy := ""
+	if s.AllowsGroupBy {
+		groupBy = " [group by ...]"
+	}
+	return fmt.Sprintf("%s(%s%s) -> %s", name, strings.Join(parameters, ", "), groupBy, s.Returns)
+}
+
+// Description describes the usage of a function.
+type Description struct {
+	Name          string     `json:"name"`
+	Usage         string     `json:"usage,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	MinArguments  int        `json:"min_arguments"`
+	MaxArguments  int        `json:"max_arguments"` // -1 indicates an unlimited number
+	AllowsGroupBy bool       `json:"allows_group_by"`
+	Signature     *Signature `json:"signature,omitempty"`
 }
 
 // Name returns the MetricFunction's name.
@@ -50,6 +106,22 @@ func (f MetricFunction) Name() string {
 	return f.FunctionName
 }
 
+// Describe returns a description of the MetricFunction's usage.
+func (f MetricFunction) Describe() Description {
+	description := Description{
+		Name:          f.FunctionName,
+		Description:   f.Description,
+		MinArguments:  f.MinArguments,
+		MaxArguments:  f.MaxArguments,
+		AllowsGroupBy: f.AllowsGroupBy,
+		Signature:     f.Signature,
+	}
+	if f.Signature != nil {
+		description.Usage = f.Signature.Format(f.FunctionName)
+	}
+	return description
+}
+
 // Run evaluates the given MetricFunction on its arguments.
 // It performs error-checking against the supplies number of arguments and/or group-by clause.
 func (f MetricFunction) Run(context EvaluationContext, arguments []Expression, groups Groups) (Value, error) {
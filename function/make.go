@@ -50,6 +50,10 @@ func MakeFunction(name string, function interface{}) MetricFunction {
 	requiredArgumentCount := 0
 	optionalArgumentCount := 0
 	allowsGroupBy := false
+	signature := &Signature{Parameters: []Parameter{}, Returns: kindNames[funcType.Out(0)]}
+	if signature.Returns == "" {
+		signature.Returns = kindNames[valueType]
+	}
 	for i := 0; i < funcType.NumIn(); i++ {
 		argType := funcType.In(i)
 		switch argType {
@@ -58,15 +62,18 @@ func MakeFunction(name string, function interface{}) MetricFunction {
 		case groupsType:
 			// asks for groups
 			allowsGroupBy = true
+			signature.AllowsGroupBy = true
 		case stringType, scalarType, scalarSetType, durationType, timeseriesType, valueType, expressionType:
 			// An ordinary argument.
 			if optionalArgumentCount > 0 {
 				panic("Non-optional arguments cannot occur after optional ones.")
 			}
 			requiredArgumentCount++
+			signature.Parameters = append(signature.Parameters, Parameter{Kind: kindNames[argType]})
 		case reflect.PtrTo(stringType), reflect.PtrTo(scalarType), reflect.PtrTo(scalarSetType), reflect.PtrTo(durationType), reflect.PtrTo(timeseriesType), reflect.PtrTo(valueType), reflect.PtrTo(expressionType):
 			// An optional argument
 			optionalArgumentCount++
+			signature.Parameters = append(signature.Parameters, Parameter{Kind: kindNames[argType.Elem()], Optional: true})
 		default:
 			panic(fmt.Sprintf("MetricFunction function argument asks for unsupported type: cannot supply argument %d of type %+v.", i, argType))
 		}
@@ -79,6 +86,7 @@ func MakeFunction(name string, function interface{}) MetricFunction {
 		MinArguments:  requiredArgumentCount,
 		MaxArguments:  requiredArgumentCount + optionalArgumentCount,
 		AllowsGroupBy: allowsGroupBy,
+		Signature:     signature,
 		// Compute does a lot of reflection to get this to work.
 		Compute: func(context EvaluationContext, arguments []Expression, groups Groups) (Value, error) {
 
@@ -229,3 +237,14 @@ var contextType = reflect.TypeOf(EvaluationContext{})
 var timerangeType = reflect.TypeOf(api.Timerange{})
 
 var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// kindNames are the names used to describe argument and result types in a Signature.
+var kindNames = map[reflect.Type]string{
+	stringType:     "string",
+	scalarType:     "scalar",
+	scalarSetType:  "scalar set",
+	durationType:   "duration",
+	timeseriesType: "series list",
+	valueType:      "value",
+	expressionType: "expression",
+}
@@ -85,6 +85,15 @@ func (m memoizedExpression) Evaluate(context EvaluationContext) (Value, error) {
 	return context.EvaluateMemoized(m.Expression)
 }
 
+// Unmemoize returns the expression wrapped by Memoize, and false if the
+// expression was not memoized.
+func Unmemoize(expression Expression) (ActualExpression, bool) {
+	if m, ok := expression.(memoizedExpression); ok {
+		return m.Expression, true
+	}
+	return nil, false
+}
+
 // ExpressionString behaves identically to the underlying expression
 func (m memoizedExpression) ExpressionString(mode DescriptionMode) string {
 	return m.Expression.ExpressionString(mode)
@@ -0,0 +1,66 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"sort"
+
+	"github.com/square/metrics/function"
+)
+
+// An Overlay layers its own functions over a base registry, so that functions
+// can be added, or the base registry's functions shadowed, without modifying
+// the base registry. Overlays can themselves be the base of other overlays.
+type Overlay struct {
+	base  function.Registry
+	local StandardRegistry
+}
+
+// NewOverlay creates an overlay with no functions of its own.
+func NewOverlay(base function.Registry) Overlay {
+	return Overlay{base: base, local: New()}
+}
+
+// GetFunction returns the overlay's own function with the given name, or else
+// the base registry's.
+func (o Overlay) GetFunction(name string) (function.Function, bool) {
+	if fun, ok := o.local.GetFunction(name); ok {
+		return fun, true
+	}
+	return o.base.GetFunction(name)
+}
+
+func (o Overlay) All() []string {
+	names := o.local.All()
+	for _, name := range o.base.All() {
+		if _, ok := o.local.GetFunction(name); !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Register adds a function to the overlay, shadowing any function of the base
+// registry with the same name.
+func (o Overlay) Register(fun function.Function) error {
+	return o.local.Register(fun)
+}
+
+// RegisterAll adds each of the functions to the overlay like Register, unless
+// any of them cannot be registered, in which case none are.
+func (o Overlay) RegisterAll(functions []function.Function) error {
+	return o.local.RegisterAll(functions)
+}
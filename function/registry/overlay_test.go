@@ -0,0 +1,95 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/testing_support/assert"
+)
+
+func TestOverlay(t *testing.T) {
+	a := assert.New(t)
+	base := New()
+	a.CheckError(base.RegisterAll([]function.Function{
+		function.MetricFunction{FunctionName: "shared", Compute: dummyCompute, Description: "base"},
+		function.MetricFunction{FunctionName: "builtin", Compute: dummyCompute},
+	}))
+	tenant := NewOverlay(base)
+	a.CheckError(tenant.Register(function.MetricFunction{FunctionName: "shared", Compute: dummyCompute, Description: "tenant"}))
+	a.CheckError(tenant.Register(function.MetricFunction{FunctionName: "experimental", Compute: dummyCompute}))
+	a.Eq(tenant.All(), []string{"builtin", "experimental", "shared"})
+	a.Eq(base.All(), []string{"builtin", "shared"})
+
+	shared, _ := tenant.GetFunction("shared")
+	a.EqString(shared.(function.MetricFunction).Description, "tenant")
+	shared, _ = base.GetFunction("shared")
+	a.EqString(shared.(function.MetricFunction).Description, "base")
+	_, ok := tenant.GetFunction("builtin")
+	a.Eq(ok, true)
+	_, ok = base.GetFunction("experimental")
+	a.Eq(ok, false)
+
+	// Overlays can be layered, but cannot shadow their own functions.
+	team := NewOverlay(tenant)
+	a.CheckError(team.Register(function.MetricFunction{FunctionName: "experimental", Compute: dummyCompute, Description: "team"}))
+	a.Eq(team.All(), []string{"builtin", "experimental", "shared"})
+	if err := tenant.Register(function.MetricFunction{FunctionName: "shared", Compute: dummyCompute}); err == nil {
+		t.Errorf("expected an error registering a function twice in an overlay")
+	}
+}
+
+func TestRegisterAll(t *testing.T) {
+	a := assert.New(t)
+	sr := New()
+	a.CheckError(sr.Register(function.MetricFunction{FunctionName: "existing", Compute: dummyCompute}))
+	for _, names := range [][]string{
+		{"new", "existing"},
+		{"new", "new"},
+		{"new", ""},
+	} {
+		functions := []function.Function{}
+		for _, name := range names {
+			functions = append(functions, function.MetricFunction{FunctionName: name, Compute: dummyCompute})
+		}
+		if err := sr.RegisterAll(functions); err == nil {
+			t.Errorf("expected an error registering %v", names)
+		}
+		a.Eq(sr.All(), []string{"existing"})
+	}
+}
+
+func TestRegister_Concurrent(t *testing.T) {
+	sr := New()
+	tenant := NewOverlay(sr)
+	wait := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			name := fmt.Sprintf("function_%d", i)
+			if err := sr.Register(function.MetricFunction{FunctionName: name, Compute: dummyCompute}); err != nil {
+				t.Errorf("unexpected error registering %s: %s", name, err.Error())
+			}
+			tenant.GetFunction(name)
+			tenant.All()
+		}(i)
+	}
+	wait.Wait()
+	assert.New(t).EqInt(len(tenant.All()), 20)
+}
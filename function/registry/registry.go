@@ -18,14 +18,21 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/square/metrics/api"
 	"github.com/square/metrics/function"
 	"github.com/square/metrics/function/builtin/aggregate"
+	"github.com/square/metrics/function/builtin/anomaly"
 	"github.com/square/metrics/function/builtin/filter"
 	"github.com/square/metrics/function/builtin/forecast"
+	"github.com/square/metrics/function/builtin/histogram"
 	"github.com/square/metrics/function/builtin/join"
+	"github.com/square/metrics/function/builtin/scalar"
+	"github.com/square/metrics/function/builtin/series"
+	"github.com/square/metrics/function/builtin/slo"
 	"github.com/square/metrics/function/builtin/summary"
 	"github.com/square/metrics/function/builtin/tag"
 	"github.com/square/metrics/function/builtin/transform"
@@ -33,85 +40,150 @@ import (
 
 func init() {
 	// Arithmetic operators
-	MustRegister(NewOperator("+", func(x float64, y float64) float64 { return x + y }))
-	MustRegister(NewOperator("-", func(x float64, y float64) float64 { return x - y }))
-	MustRegister(NewOperator("*", func(x float64, y float64) float64 { return x * y }))
-	MustRegister(NewOperator("/", func(x float64, y float64) float64 { return x / y }))
+	MustRegisterDescribed(NewOperator("+", func(x float64, y float64) float64 { return x + y }), "Adds the matching series of two series lists.")
+	MustRegisterDescribed(NewOperator("-", func(x float64, y float64) float64 { return x - y }), "Subtracts the matching series of two series lists.")
+	MustRegisterDescribed(NewOperator("*", func(x float64, y float64) float64 { return x * y }), "Multiplies the matching series of two series lists.")
+	MustRegisterDescribed(NewOperator("/", func(x float64, y float64) float64 { return x / y }), "Divides the matching series of two series lists.")
 	// Aggregates
-	MustRegister(NewAggregate("aggregate.max", aggregate.Max))
-	MustRegister(NewAggregate("aggregate.min", aggregate.Min))
-	MustRegister(NewAggregate("aggregate.mean", aggregate.Mean))
-	MustRegister(NewAggregate("aggregate.sum", aggregate.Sum))
-	MustRegister(NewAggregate("aggregate.total", aggregate.Total))
-	MustRegister(NewAggregate("aggregate.count", aggregate.Count))
+	MustRegisterDescribed(NewAggregate("aggregate.max", aggregate.Max), "The maximum of each group of series at each point in time.")
+	MustRegisterDescribed(NewAggregate("aggregate.min", aggregate.Min), "The minimum of each group of series at each point in time.")
+	MustRegisterDescribed(NewAggregate("aggregate.mean", aggregate.Mean), "The mean of each group of series at each point in time.")
+	MustRegisterDescribed(NewAggregate("aggregate.sum", aggregate.Sum), "The sum of each group of series at each point in time, ignoring NaN values.")
+	MustRegisterDescribed(NewAggregate("aggregate.total", aggregate.Total), "The number of series in each group.")
+	MustRegisterDescribed(NewAggregate("aggregate.count", aggregate.Count), "The number of series in each group which are not NaN at each point in time.")
+	MustRegisterDescribed(NewAggregate("aggregate.count_distinct", aggregate.CountDistinct), "The number of distinct values, other than NaN, among each group of series at each point in time.")
+	MustRegisterDescribed(NewAggregate("aggregate.median", aggregate.Median), "The median of each group of series at each point in time, ignoring NaN values.")
+	MustRegisterDescribed(NewAggregate("aggregate.variance", aggregate.Variance), "The population variance of each group of series at each point in time, ignoring NaN values.")
+	MustRegisterDescribed(NewAggregate("aggregate.stddev", aggregate.Stddev), "The population standard deviation of each group of series at each point in time, ignoring NaN values.")
+	MustRegisterDescribed(NewParameterizedAggregate("aggregate.quantile", quantileAggregator), "The given quantile (between 0 and 1) of each group of series at each point in time, interpolating between values and ignoring NaN values.")
 	// Transformations
-	MustRegister(transform.Integral)
-	MustRegister(transform.Cumulative)
-	MustRegister(transform.NaNFill)
-	MustRegister(transform.MapMaker("transform.abs", math.Abs))
-	MustRegister(transform.MapMaker("transform.log", math.Log10))
-	MustRegister(transform.NaNKeepLast)
-	MustRegister(transform.Bound)
-	MustRegister(transform.LowerBound)
-	MustRegister(transform.UpperBound)
+	MustRegisterDescribed(transform.Integral, "The running integral of each series, treating its values as rates per second.")
+	MustRegisterDescribed(transform.Cumulative, "The running sum of each series over the timerange.")
+	MustRegisterDescribed(transform.NaNFill, "Replaces NaN values with the given default value.")
+	MustRegisterDescribed(transform.MapMaker("transform.abs", math.Abs), "The absolute value of each point.")
+	MustRegisterDescribed(transform.MapMaker("transform.log", math.Log10), "The base-10 logarithm of each point.")
+	MustRegisterDescribed(transform.NaNKeepLast, "Replaces NaN values with the last value which was not NaN.")
+	MustRegisterDescribed(transform.Interpolate, "Fills runs of NaN values using the given method ('linear', 'previous' or 'next'), leaving runs longer than the optional maximum gap unfilled.")
+	MustRegisterDescribed(transform.NaNDropSeries, "Removes the series whose fraction of values which are not NaN is below the given minimum fraction.")
+	MustRegisterDescribed(transform.MaskHours, "Replaces the values outside of the daily window between two local times of day (such as '09:00' and '17:00', which wraps past midnight if it ends first) with NaN, in the optional timezone.")
+	MustRegisterDescribed(transform.MaskWeekdays, "Replaces the values on the listed days of the week (such as 'sat,sun') with NaN, in the optional timezone.")
+	MustRegisterDescribed(transform.Bound, "Clamps each point between the given lower and upper bounds.")
+	MustRegisterDescribed(transform.LowerBound, "Clamps each point to be at least the given lower bound.")
+	MustRegisterDescribed(transform.UpperBound, "Clamps each point to be at most the given upper bound.")
 
 	// Filter
-	MustRegister(NewFilterCount("filter.highest_mean", aggregate.Mean, false))
-	MustRegister(NewFilterCount("filter.highest_max", aggregate.Max, false))
-	MustRegister(NewFilterCount("filter.highest_min", aggregate.Min, false))
+	MustRegisterDescribed(NewFilterCount("filter.highest_mean", aggregate.Mean, false), "Keeps the given number of series with the highest mean over the optional recent duration.")
+	MustRegisterDescribed(NewFilterCount("filter.highest_max", aggregate.Max, false), "Keeps the given number of series with the highest maximum over the optional recent duration.")
+	MustRegisterDescribed(NewFilterCount("filter.highest_min", aggregate.Min, false), "Keeps the given number of series with the highest minimum over the optional recent duration.")
 
-	MustRegister(NewFilterCount("filter.lowest_mean", aggregate.Mean, true))
-	MustRegister(NewFilterCount("filter.lowest_max", aggregate.Max, true))
-	MustRegister(NewFilterCount("filter.lowest_min", aggregate.Min, true))
+	MustRegisterDescribed(NewFilterCount("filter.lowest_mean", aggregate.Mean, true), "Keeps the given number of series with the lowest mean over the optional recent duration.")
+	MustRegisterDescribed(NewFilterCount("filter.lowest_max", aggregate.Max, true), "Keeps the given number of series with the lowest maximum over the optional recent duration.")
+	MustRegisterDescribed(NewFilterCount("filter.lowest_min", aggregate.Min, true), "Keeps the given number of series with the lowest minimum over the optional recent duration.")
 
-	MustRegister(NewFilterThreshold("filter.mean_above", aggregate.Mean, false))
-	MustRegister(NewFilterThreshold("filter.max_above", aggregate.Max, false))
-	MustRegister(NewFilterThreshold("filter.min_above", aggregate.Min, false))
+	MustRegisterDescribed(NewFilterThreshold("filter.mean_above", aggregate.Mean, false), "Keeps the series whose mean over the optional recent duration is above the threshold.")
+	MustRegisterDescribed(NewFilterThreshold("filter.max_above", aggregate.Max, false), "Keeps the series whose maximum over the optional recent duration is above the threshold.")
+	MustRegisterDescribed(NewFilterThreshold("filter.min_above", aggregate.Min, false), "Keeps the series whose minimum over the optional recent duration is above the threshold.")
 
-	MustRegister(NewFilterThreshold("filter.mean_below", aggregate.Mean, true))
-	MustRegister(NewFilterThreshold("filter.max_below", aggregate.Max, true))
-	MustRegister(NewFilterThreshold("filter.min_below", aggregate.Min, true))
+	MustRegisterDescribed(NewFilterThreshold("filter.mean_below", aggregate.Mean, true), "Keeps the series whose mean over the optional recent duration is below the threshold.")
+	MustRegisterDescribed(NewFilterThreshold("filter.max_below", aggregate.Max, true), "Keeps the series whose maximum over the optional recent duration is below the threshold.")
+	MustRegisterDescribed(NewFilterThreshold("filter.min_below", aggregate.Min, true), "Keeps the series whose minimum over the optional recent duration is below the threshold.")
+
+	MustRegisterDescribed(FilterTopWithOther, "Keeps the given number of series in each group with the highest summary ('max', 'min', 'mean', 'median', 'sum' or 'count') over the optional recent duration, combining the rest of each group with the given aggregator into one series whose tags which differ within the group are set to the optional sentinel value.")
+	MustRegisterDescribed(filter.Tag, "Keeps the series whose tags satisfy the predicate, written as in a where clause.")
+	MustRegisterDescribed(filter.Where, "Keeps the series whose summary by the named summarize function (given the optional duration) compares to the threshold by the operator ('<', '<=', '>', '>=', '=' or '!=').")
+	MustRegisterDescribed(filter.CorrelatedWith, "Keeps the series whose correlation (or anti-correlation) with a series of the reference which has matching tags has magnitude at least the threshold.")
 
 	// Weird ones
-	MustRegister(transform.Derivative)
-	MustRegister(transform.MovingAverage)
-	MustRegister(transform.ExponentialMovingAverage)
-	MustRegister(transform.Rate)
-	MustRegister(transform.Timeshift)
+	MustRegisterDescribed(transform.Derivative, "The change per second between consecutive points of each series.")
+	MustRegisterDescribed(transform.MovingAverage, "The average of each series over a trailing window of the given duration.")
+	MustRegisterDescribed(transform.Summarize, "Summarizes each series over consecutive windows of the given duration with the given method ('max', 'min', 'mean', 'sum', 'count' or 'last'), filling each window with its summary.")
+	MustRegisterDescribed(transform.ExponentialMovingAverage, "The exponentially weighted average of each series with the given duration as its time constant.")
+	MustRegisterDescribed(transform.Rate, "The increase per second of each counter series, treating decreases as counter resets.")
+	MustRegisterDescribed(transform.CounterRate, "The increase per second of each counter series, treating decreases as resets (or as wrapping past the optional maximum value when the counter was above half of it) and spreading increases across gaps.")
+	MustRegisterDescribed(transform.Increase, "The increase of each counter series in each slot, treating decreases as resets (or as wrapping past the optional maximum value when the counter was above half of it) and spreading increases across gaps.")
+	MustRegisterDescribed(transform.Timeshift, "Evaluates the expression over the timerange shifted by the given duration.")
 
 	// Tags
-	MustRegister(tag.DropFunction)
-	MustRegister(tag.SetFunction)
-	MustRegister(tag.CopyFunction)
+	MustRegisterDescribed(tag.DropFunction, "Removes the given tag from every series.")
+	MustRegisterDescribed(tag.SetFunction, "Sets the given tag to the given value on every series.")
+	MustRegisterDescribed(tag.CopyFunction, "Sets the first (target) tag to the value of the second (source) tag on every series.")
+	MustRegisterDescribed(tag.RenameFunction, "Renames the first (source) tag to the second (target) tag on every series which has it.")
+	MustRegisterDescribed(tag.ReplaceFunction, "Replaces each match of the regular expression in the value of the given tag with the replacement, which may refer to capture groups as $1.")
+	MustRegisterDescribed(tag.AliasFunction, "Names every series with the template, replacing each {{tag}} with the value of that tag.")
+
+	// Series lists
+	MustRegisterDescribed(series.UnionFunction, "Combines the series of any number of series lists into one list, setting the 'source' tag of each series to the name of the expression it came from.")
+	MustRegisterDescribed(series.DedupFunction, "Keeps one series for each combination of the grouped tags (or each distinct tagset, without a group by), chosen by the policy ('first', 'last', 'most_complete', 'highest_mean' or 'lowest_mean').")
 
 	// Forecasting
-	MustRegister(forecast.FunctionRollingMultiplicativeHoltWinters)
-	MustRegister(forecast.FunctionAnomalyRollingMultiplicativeHoltWinters)
-	MustRegister(forecast.FunctionRollingSeasonal)
-	MustRegister(forecast.FunctionAnomalyRollingSeasonal)
-	MustRegister(forecast.FunctionLinear)
+	MustRegisterDescribed(forecast.FunctionRollingMultiplicativeHoltWinters, "Forecasts each series with the given period (or 'auto' to detect it) and level, trend and seasonal learning rates, optionally training over extra time before the timerange.")
+	MustRegisterDescribed(forecast.FunctionAnomalyRollingMultiplicativeHoltWinters, "The number of standard deviations that each series deviates from forecast.rolling_multiplicative_holt_winters with the same arguments.")
+	MustRegisterDescribed(forecast.FunctionRollingSeasonal, "Forecasts each series with the given period (or 'auto' to detect it) and seasonal learning rate, optionally training over extra time before the timerange.")
+	MustRegisterDescribed(forecast.FunctionAnomalyRollingSeasonal, "The number of standard deviations that each series deviates from forecast.rolling_seasonal with the same arguments.")
+	MustRegisterDescribed(forecast.FunctionRollingAdditiveHoltWinters, "Forecasts each series with an additive seasonal term (suitable for zero or negative values) with the given period (or 'auto') and level, trend and seasonal learning rates, optionally training over extra time before the timerange.")
+	MustRegisterDescribed(forecast.FunctionAnomalyRollingAdditiveHoltWinters, "The number of standard deviations that each series deviates from forecast.rolling_additive_holt_winters with the same arguments.")
+	MustRegisterDescribed(forecast.FunctionRollingDoubleExponentialSmoothing, "Forecasts each series with a level and trend but no seasonality, with the given per-slot level and trend learning rates, optionally training over extra time before the timerange.")
+	MustRegisterDescribed(forecast.FunctionAnomalyRollingDoubleExponentialSmoothing, "The number of standard deviations that each series deviates from forecast.rolling_double_exponential_smoothing with the same arguments.")
+	MustRegisterDescribed(forecast.FunctionSeasonalTrend, "Models each series as the sum of the trend and seasonal components found by forecast.decompose with the given period (or 'auto').")
+	MustRegisterDescribed(forecast.FunctionAnomalySeasonalTrend, "The number of standard deviations that each series deviates from forecast.seasonal_trend with the same arguments.")
+	MustRegisterDescribed(forecast.FunctionDecompose, "Splits each series into trend, seasonal and residual series (tagged by 'component') with the given period (or 'auto'), optionally using extra time before the timerange.")
+	MustRegisterDescribed(forecast.FunctionLinear, "Forecasts each series with a linear trend, optionally training over extra time before the timerange.")
+
+	MustRegisterDescribed(forecast.FunctionDrop, "Replaces the values of each series with NaN within the given duration of the end of the timerange.")
 
-	MustRegister(forecast.FunctionDrop)
+	// Anomaly detection
+	MustRegisterDescribed(anomaly.ZScore, "The number of standard deviations that each value deviates from the mean of the values within the given window before it.")
+	MustRegisterDescribed(anomaly.MAD, "The number of scaled median absolute deviations that each value deviates from the median of the values within the given window before it.")
+	MustRegisterDescribed(anomaly.Outliers, "Keeps the series which at some time deviate from the median of their group by more than the given number of scaled median absolute deviations.")
+	MustRegisterDescribed(anomaly.Changepoints, "Marks the level shifts in each series (with segments of at least the given duration and the given sensitivity) with the change in level, and is zero elsewhere.")
+	MustRegisterDescribed(anomaly.LargestChangepoint, "The time and magnitude of the largest level shift in each series, as found by anomaly.changepoints, tagged by 'changepoint'.")
+
+	// Histograms
+	MustRegisterDescribed(histogram.Quantile, "Estimates the given quantile (between 0 and 1) of each group of histogram buckets (with cumulative counts and an 'le' or 'bucket' tag), interpolating within buckets.")
+	MustRegisterDescribed(histogram.Mean, "Estimates the mean of each group of histogram buckets (with cumulative counts and an 'le' or 'bucket' tag) from the midpoints of the buckets.")
+	MustRegisterDescribed(histogram.FractionBelow, "Estimates the fraction of observations at most the threshold in each group of histogram buckets (with cumulative counts and an 'le' or 'bucket' tag).")
+
+	// Service level objectives
+	MustRegisterDescribed(slo.BurnRate, "The rate at which the error budget of the objective (such as 0.999) is spent over the trailing window, from the good and total event counts with matching tags.")
+	MustRegisterDescribed(slo.ErrorBudgetRemaining, "The fraction of the error budget of the objective (such as 0.999) which remains over the trailing window, from the good and total event counts with matching tags.")
 
 	// Summary
-	MustRegister(summary.Current)
-	MustRegister(summary.Oldest)
-	MustRegister(summary.Mean)
-	MustRegister(summary.Min)
-	MustRegister(summary.Max)
-	MustRegister(summary.Integral)
-	MustRegister(summary.LastNotNaN)
-	MustRegister(summary.FirstNotNaN)
-	MustRegister(summary.Count)
-	MustRegister(summary.Total)
-}
-
-// StandardRegistry of a functions available in MQE.
+	MustRegisterDescribed(summary.Current, "The most recent value of each series.")
+	MustRegisterDescribed(summary.Oldest, "The earliest value of each series.")
+	MustRegisterDescribed(summary.Mean, "The mean of each series over the optional recent duration.")
+	MustRegisterDescribed(summary.Min, "The minimum of each series over the optional recent duration.")
+	MustRegisterDescribed(summary.Max, "The maximum of each series over the optional recent duration.")
+	MustRegisterDescribed(summary.Integral, "The integral of each series over the optional recent duration, in units per second.")
+	MustRegisterDescribed(summary.LastNotNaN, "The last value of each series which is not NaN, within the optional recent duration.")
+	MustRegisterDescribed(summary.FirstNotNaN, "The first value of each series which is not NaN, within the optional recent duration.")
+	MustRegisterDescribed(summary.Count, "The number of values of each series which are not NaN, within the optional recent duration.")
+	MustRegisterDescribed(summary.Coverage, "The fraction of values of each series which are not NaN, within the optional recent duration.")
+	MustRegisterDescribed(summary.Total, "The number of points of each series, including NaN values, within the optional recent duration.")
+	MustRegisterDescribed(summary.DuringHours, "Summarizes each series by the named summarize function, considering only the slots within the daily window between two local times of day (such as '09:00' and '17:00'), excluding the optional days of the week (such as 'sat,sun'), in the optional timezone.")
+	MustRegisterDescribed(summary.DominantPeriod, "The strongest period (in milliseconds, up to the given maximum) in the periodogram of each series, or NaN if there is none.")
+	MustRegisterDescribed(summary.Correlation, "The Pearson correlation coefficient of each pair of series from the two lists which have matching tags.")
+	MustRegisterDescribed(summary.CrossCorrelation, "The best lag (in milliseconds, up to the given maximum) and the correlation coefficient at that lag for each pair of series from the two lists which have matching tags, tagged by 'correlation'.")
+
+	// Scalar sets
+	MustRegisterDescribed(scalar.Sort, "Sorts the scalars by value, in ascending order unless the optional order is 'desc', with NaN values last.")
+	MustRegisterDescribed(scalar.Where, "Keeps the scalars which compare to the threshold by the operator ('<', '<=', '>', '>=', '=' or '!=').")
+	MustRegisterDescribed(scalar.Highest, "Keeps the given number of scalars with the highest values, in descending order.")
+	MustRegisterDescribed(scalar.Lowest, "Keeps the given number of scalars with the lowest values, in ascending order.")
+}
+
+// StandardRegistry of a functions available in MQE. It is safe to register
+// functions while others are being looked up.
 type StandardRegistry struct {
 	mapping map[string]function.Function
+	lock    *sync.RWMutex
 }
 
-var defaultRegistry = StandardRegistry{mapping: make(map[string]function.Function)}
+var defaultRegistry = New()
+
+// New creates an empty registry.
+func New() StandardRegistry {
+	return StandardRegistry{mapping: make(map[string]function.Function), lock: &sync.RWMutex{}}
+}
 
 func Default() StandardRegistry {
 	return defaultRegistry
@@ -119,11 +191,15 @@ func Default() StandardRegistry {
 
 // GetFunction returns a function associated with the given name, if it exists.
 func (r StandardRegistry) GetFunction(name string) (function.Function, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
 	fun, ok := r.mapping[name]
 	return fun, ok
 }
 
 func (r StandardRegistry) All() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
 	result := make([]string, len(r.mapping))
 	counter := 0
 	for key := range r.mapping {
@@ -136,14 +212,27 @@ func (r StandardRegistry) All() []string {
 
 // Register a new function into the registry.
 func (r StandardRegistry) Register(fun function.Function) error {
-	_, ok := r.mapping[fun.Name()]
-	if ok {
-		return fmt.Errorf("function %s has already been registered", fun.Name())
+	return r.RegisterAll([]function.Function{fun})
+}
+
+// RegisterAll registers each of the functions, unless any of them cannot be
+// registered, in which case none are.
+func (r StandardRegistry) RegisterAll(functions []function.Function) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	names := map[string]bool{}
+	for _, fun := range functions {
+		if fun.Name() == "" {
+			return fmt.Errorf("empty function name")
+		}
+		if _, ok := r.mapping[fun.Name()]; ok || names[fun.Name()] {
+			return fmt.Errorf("function %s has already been registered", fun.Name())
+		}
+		names[fun.Name()] = true
 	}
-	if fun.Name() == "" {
-		return fmt.Errorf("empty function name")
+	for _, fun := range functions {
+		r.mapping[fun.Name()] = fun
 	}
-	r.mapping[fun.Name()] = fun
 	return nil
 }
 
@@ -155,6 +244,13 @@ func MustRegister(fun function.Function) {
 	}
 }
 
+// MustRegisterDescribed adds a new metric function with the given description
+// to the global function registry.
+func MustRegisterDescribed(fun function.MetricFunction, description string) {
+	fun.Description = description
+	MustRegister(fun)
+}
+
 // Constructor Functions
 
 // NewFilterCount creates a new instance of a filtering function with count limit.
@@ -178,6 +274,68 @@ func NewFilterCount(name string, summary func([]float64) float64, ascending bool
 	)
 }
 
+// seriesSummaries are the summaries which filter.top_with_other can use to rank and combine series, by name.
+var seriesSummaries = map[string]func([]float64) float64{
+	"max":    aggregate.Max,
+	"min":    aggregate.Min,
+	"mean":   aggregate.Mean,
+	"median": aggregate.Median,
+	"sum":    aggregate.Sum,
+	"count":  aggregate.Count,
+}
+
+// seriesSummary looks up the summary with the given name.
+func seriesSummary(name string) (func([]float64) float64, error) {
+	if summary, ok := seriesSummaries[name]; ok {
+		return summary, nil
+	}
+	names := []string{}
+	for name := range seriesSummaries {
+		names = append(names, fmt.Sprintf("'%s'", name))
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("expected one of %s but got '%s'", strings.Join(names, ", "), name)
+}
+
+// FilterTopWithOther keeps the given number of series in each group with the highest summary over the optional recent
+// duration, and combines the rest of each group into a single series whose tags which differ within the group are set
+// to the optional sentinel value (by default, "other").
+var FilterTopWithOther = function.MakeFunction(
+	"filter.top_with_other",
+	func(list api.SeriesList, countFloat float64, summaryName string, aggregatorName string, optionalOther *string, optionalDuration *time.Duration, timerange api.Timerange, groups function.Groups) (api.SeriesList, error) {
+		count := int(countFloat + 0.5)
+		if count < 0 {
+			return api.SeriesList{}, fmt.Errorf("expected non-negative count but got %g", countFloat)
+		}
+		duration := timerange.Duration()
+		if optionalDuration != nil {
+			duration = *optionalDuration
+		}
+		if duration < 0 {
+			return api.SeriesList{}, fmt.Errorf("expected positive recent duration but got %+v", duration)
+		}
+		summary, err := seriesSummary(summaryName)
+		if err != nil {
+			return api.SeriesList{}, fmt.Errorf("invalid summary for filter.top_with_other: %s", err.Error())
+		}
+		aggregator, err := seriesSummary(aggregatorName)
+		if err != nil {
+			return api.SeriesList{}, fmt.Errorf("invalid aggregator for filter.top_with_other: %s", err.Error())
+		}
+		other := "other"
+		if optionalOther != nil {
+			other = *optionalOther
+		}
+		result := api.SeriesList{
+			Series: []api.Timeseries{},
+		}
+		for _, group := range aggregate.Partition(list, groups.List, groups.Collapses) {
+			result.Series = append(result.Series, filter.TopWithOther(group, count, summary, aggregator, other, 1+int(duration/timerange.Resolution())).Series...)
+		}
+		return result, nil
+	},
+)
+
 // NewFilterThreshold creates a new instance of a filtering function.
 func NewFilterThreshold(name string, summary func([]float64) float64, below bool) function.MetricFunction {
 	return function.MakeFunction(
@@ -205,12 +363,54 @@ func NewAggregate(name string, aggregator func([]float64) float64) function.Metr
 	)
 }
 
-// NewOperator creates a new binary operator function.
-// the binary operators display a natural join semantic.
-func NewOperator(op string, operator func(float64, float64) float64) function.Function {
+// NewParameterizedAggregate makes a MetricFunction from a named aggregating
+// function which is configured by a scalar parameter, such as a quantile.
+// The parameter follows the series list in the function's arguments.
+func NewParameterizedAggregate(name string, makeAggregator func(float64) (func([]float64) float64, error)) function.MetricFunction {
 	return function.MakeFunction(
+		name,
+		func(seriesList api.SeriesList, parameter float64, groups function.Groups) (api.SeriesList, error) {
+			aggregator, err := makeAggregator(parameter)
+			if err != nil {
+				return api.SeriesList{}, err
+			}
+			return aggregate.By(seriesList, aggregator, groups.List, groups.Collapses), nil
+		},
+	)
+}
+
+// quantileAggregator makes the aggregator for a quantile between 0 and 1.
+func quantileAggregator(q float64) (func([]float64) float64, error) {
+	if !(0 <= q && q <= 1) {
+		return nil, fmt.Errorf("expected quantile between 0 and 1 but got %g", q)
+	}
+	return aggregate.Quantile(q), nil
+}
+
+// NewOperator creates a new binary operator function.
+// the binary operators display a natural join semantic. If neither operand is
+// a series list, the operands are joined as scalar sets and the result is a
+// scalar set.
+func NewOperator(op string, operator func(float64, float64) float64) function.MetricFunction {
+	fun := function.MakeFunction(
 		op,
-		func(leftList api.SeriesList, rightList api.SeriesList, timerange api.Timerange) (api.SeriesList, error) {
+		func(leftExpression function.Expression, rightExpression function.Expression, context function.EvaluationContext) (function.Value, error) {
+			values, err := function.EvaluateMany(context, []function.Expression{leftExpression, rightExpression})
+			if err != nil {
+				return nil, err
+			}
+			if leftSet, rightSet, ok := scalarOperands(values[0], values[1]); ok {
+				return scalar.Combine(leftSet, rightSet, operator), nil
+			}
+			leftList, convErr := values[0].ToSeriesList(context.Timerange())
+			if convErr != nil {
+				return nil, convErr.WithContext(leftExpression.ExpressionString(function.StringQuery))
+			}
+			rightList, convErr := values[1].ToSeriesList(context.Timerange())
+			if convErr != nil {
+				return nil, convErr.WithContext(rightExpression.ExpressionString(function.StringQuery))
+			}
+
 			joined := join.Join([]api.SeriesList{leftList, rightList})
 
 			result := make([]api.Timeseries, len(joined.Rows))
@@ -222,12 +422,43 @@ func NewOperator(op string, operator func(float64, float64) float64) function.Fu
 				for j := 0; j < len(left.Values); j++ {
 					array[j] = operator(left.Values[j], right.Values[j])
 				}
-				result[i] = api.Timeseries{Values: array, TagSet: row.TagSet}
+				// The result keeps the display name of the left operand, or of the right operand if the left has none.
+				name := left.Name
+				if name == "" {
+					name = right.Name
+				}
+				result[i] = api.Timeseries{Values: array, TagSet: row.TagSet, Name: name}
 			}
 
-			return api.SeriesList{
+			return function.SeriesListValue(api.SeriesList{
 				Series: result,
-			}, nil
+			}), nil
 		},
 	)
+	// The operands are evaluated by the operator itself, since they may be series lists or scalar sets.
+	fun.Signature.Parameters = []function.Parameter{{Kind: "value"}, {Kind: "value"}}
+	return fun
+}
+
+// scalarOperands converts the operands to scalar sets when at least one of them
+// is a scalar set and neither is a series list. Arithmetic on bare scalars
+// still produces a series list.
+func scalarOperands(left function.Value, right function.Value) (function.ScalarSet, function.ScalarSet, bool) {
+	if _, ok := left.(function.SeriesListValue); ok {
+		return nil, nil, false
+	}
+	if _, ok := right.(function.SeriesListValue); ok {
+		return nil, nil, false
+	}
+	_, leftIsSet := left.(function.ScalarSet)
+	_, rightIsSet := right.(function.ScalarSet)
+	if !leftIsSet && !rightIsSet {
+		return nil, nil, false
+	}
+	leftSet, leftErr := left.ToScalarSet()
+	rightSet, rightErr := right.ToScalarSet()
+	if leftErr != nil || rightErr != nil {
+		return nil, nil, false
+	}
+	return leftSet, rightSet, true
 }
@@ -28,7 +28,7 @@ var dummyCompute = func(function.EvaluationContext, []function.Expression, funct
 
 func Test_Registry_Default(t *testing.T) {
 	a := assert.New(t)
-	sr := StandardRegistry{mapping: make(map[string]function.Function)}
+	sr := New()
 	a.Eq(sr.All(), []string{})
 	if err := sr.Register(function.MetricFunction{FunctionName: "foo", Compute: dummyCompute}); err != nil {
 		a.CheckError(err)
@@ -49,7 +49,7 @@ func Test_Registry_Error(t *testing.T) {
 	} {
 		a := assert.New(t).Contextf("%s", suite.Name)
 		// set up the standard registry
-		sr := StandardRegistry{mapping: make(map[string]function.Function)}
+		sr := New()
 		if err := sr.Register(function.MetricFunction{FunctionName: "existing", Compute: dummyCompute}); err != nil {
 			a.CheckError(err)
 			return
@@ -59,3 +59,27 @@ func Test_Registry_Error(t *testing.T) {
 		}
 	}
 }
+
+func Test_Registry_Descriptions(t *testing.T) {
+	for _, name := range Default().All() {
+		a := assert.New(t).Contextf("%s", name)
+		fun, ok := Default().GetFunction(name)
+		if !ok {
+			a.Errorf("registered function could not be found")
+			continue
+		}
+		description := fun.(function.DescribedFunction).Describe()
+		if description.Description == "" {
+			a.Errorf("expected a description")
+		}
+		if description.Signature == nil {
+			a.Errorf("expected a signature")
+			continue
+		}
+		if description.Signature.Variadic {
+			a.EqInt(description.MaxArguments, -1)
+			continue
+		}
+		a.EqInt(len(description.Signature.Parameters), description.MaxArguments)
+	}
+}
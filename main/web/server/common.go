@@ -24,13 +24,18 @@ import (
 	"strconv"
 
 	"github.com/square/metrics/log"
+	"github.com/square/metrics/query/parser"
 )
 
 func encodeError(err error) []byte {
-	encoded, err2 := json.MarshalIndent(Response{
+	response := Response{
 		Success: false,
 		Message: err.Error(),
-	}, "", "  ")
+	}
+	if syntaxErrors, ok := err.(parser.SyntaxErrors); ok {
+		response.Errors = syntaxErrors
+	}
+	encoded, err2 := json.MarshalIndent(response, "", "  ")
 	if err2 == nil {
 		return encoded
 	}
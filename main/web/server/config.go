@@ -22,6 +22,19 @@ type Config struct {
 	StaticDir     string `yaml:"static_dir"`
 	JSONIngestion bool   `yaml:"json_ingestion"`
 	HTTPIngestion bool   `yaml:"enable_http_ingestion"`
+	// TenantHeader is the request header which names the tenant whose
+	// functions are used, if any.
+	TenantHeader string `yaml:"tenant_header"`
+	// DefaultTenant is the tenant for requests which do not name one. If it is
+	// empty, they use only the functions of the server's registry.
+	DefaultTenant string                  `yaml:"default_tenant"`
+	Tenants       map[string]TenantConfig `yaml:"tenants"`
+}
+
+// TenantConfig describes the functions which a tenant adds to (or shadows in)
+// the server's registry.
+type TenantConfig struct {
+	MacrosPath string `yaml:"macros_path"`
 }
 
 type Hook struct {
@@ -0,0 +1,86 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/square/metrics/query/command"
+)
+
+// functionsHandler exposes the usage of the functions available in the system.
+type functionsHandler struct {
+	context    command.ExecutionContext
+	registries tenantRegistries
+}
+
+func (h functionsHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+
+	context, err := h.registries.withRegistry(h.context, request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		writer.Write(encodeError(err))
+		return
+	}
+
+	// Make sure the query params have been parsed
+	if err := request.ParseForm(); err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		writer.Write(encodeError(err))
+		return
+	}
+
+	matcher, err := regexp.Compile(request.Form.Get("match"))
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		writer.Write(encodeError(err))
+		return
+	}
+
+	describe := command.DescribeFunctionsCommand{Matcher: matcher}
+	result, err := describe.Execute(context)
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		writer.Write(encodeError(err))
+		return
+	}
+
+	response := Response{
+		Success: true,
+		QueryResponse: QueryResponse{
+			Name:     describe.Name(),
+			Body:     result.Body,
+			Metadata: result.Metadata,
+		},
+	}
+
+	pretty, _ := strconv.ParseBool(request.Form.Get("pretty"))
+	var encoded []byte
+	if pretty {
+		encoded, err = json.MarshalIndent(response, "", "  ")
+	} else {
+		encoded, err = json.Marshal(response)
+	}
+	if err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+		writer.Write([]byte(`{"success": false, "message": "Failed to encode the result message."}`))
+		return
+	}
+	writer.Write(encoded)
+}
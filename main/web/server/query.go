@@ -29,8 +29,9 @@ import (
 )
 
 type Response struct {
-	Success bool   `json:"success"`
-	Message string `json:"message,omitempty"`
+	Success bool                 `json:"success"`
+	Message string               `json:"message,omitempty"`
+	Errors  []parser.SyntaxError `json:"errors,omitempty"` // the location of each error in the query
 	QueryResponse
 	Profile []inspect.Profile `json:"profile,omitempty"`
 }
@@ -42,8 +43,9 @@ type QueryResponse struct {
 }
 
 type queryHandler struct {
-	hook    Hook
-	context command.ExecutionContext
+	hook       Hook
+	context    command.ExecutionContext
+	registries tenantRegistries
 }
 
 type KeyIs struct {
@@ -171,9 +173,10 @@ func predicateFromConstraint(c Constraint) (predicate.Predicate, error) {
 }
 
 type QueryForm struct {
-	Input       string      `query:"query" json:"query"`     // query to execute.
-	Profile     bool        `query:"profile" json:"profile"` // if true, then profile information will be exposed to the user.
-	Constraints *Constraint `query:"-" json:"where"`
+	Input       string                     `query:"query" json:"query"`     // query to execute.
+	Profile     bool                       `query:"profile" json:"profile"` // if true, then profile information will be exposed to the user.
+	Constraints *Constraint                `query:"-" json:"where"`
+	Variables   map[string]parser.Variable `query:"variables" query_kind:"json" json:"variables"` // values of the $variables in the query.
 }
 
 func (q queryHandler) process(profiler *inspect.Profiler, parsedForm QueryForm) (QueryResponse, error) {
@@ -181,7 +184,10 @@ func (q queryHandler) process(profiler *inspect.Profiler, parsedForm QueryForm)
 	var rawCommand command.Command
 	var err error
 	profiler.Do("Parsing Query", func() {
-		rawCommand, err = parser.Parse(parsedForm.Input)
+		rawCommand, err = parser.ParseWithOptions(parsedForm.Input, parser.Options{
+			Registry:  q.context.Registry,
+			Variables: parsedForm.Variables,
+		})
 	})
 	if err != nil {
 		return QueryResponse{}, err
@@ -225,6 +231,14 @@ func (q queryHandler) ServeHTTP(writer http.ResponseWriter, request *http.Reques
 	writer.Header().Set("Content-Type", "application/json")
 	profiler := inspect.New()
 
+	context, err := q.registries.withRegistry(q.context, request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		writer.Write(encodeError(err))
+		return
+	}
+	q.context = context
+
 	queryForm := QueryForm{}
 
 	switch request.Header.Get("Content-Type") {
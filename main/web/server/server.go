@@ -24,6 +24,10 @@ import (
 
 func NewMux(config Config, context command.ExecutionContext, hook Hook) (*http.ServeMux, error) {
 	// Wrap the given API and Backend in their Profiling counterparts.
+	registries, err := newTenantRegistries(config, context.Registry)
+	if err != nil {
+		return nil, err
+	}
 	httpMux := http.NewServeMux()
 	httpMux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
 		http.Redirect(writer, request, "/ui", http.StatusTemporaryRedirect)
@@ -31,12 +35,19 @@ func NewMux(config Config, context command.ExecutionContext, hook Hook) (*http.S
 	httpMux.Handle("/ui", singleStaticHandler{config.StaticDir, "index.html"})
 	httpMux.Handle("/embed", singleStaticHandler{config.StaticDir, "embed.html"})
 	httpMux.Handle("/query", queryHandler{
-		context: context,
-		hook:    hook,
+		context:    context,
+		registries: registries,
+		hook:       hook,
 	})
 	httpMux.Handle("/token", tokenHandler{
-		context: context,
+		context:    context,
+		registries: registries,
+	})
+	httpMux.Handle("/functions", functionsHandler{
+		context:    context,
+		registries: registries,
 	})
+	httpMux.Handle("/format", formatHandler{})
 	if config.HTTPIngestion {
 		if updateAPI, ok := context.MetricMetadataAPI.(metadata.MetricUpdateAPI); ok {
 			httpMux.Handle("/ingest", ingestHandler{
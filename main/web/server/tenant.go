@@ -0,0 +1,92 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/registry"
+	"github.com/square/metrics/query/command"
+	"github.com/square/metrics/query/macro"
+)
+
+// tenantRegistries selects the function registry for each request, so that
+// functions can be added for some tenants without affecting the others.
+type tenantRegistries struct {
+	header        string
+	defaultTenant string
+	base          function.Registry
+	tenants       map[string]registry.Overlay
+}
+
+// newTenantRegistries creates an overlay of the base registry for each tenant,
+// with the tenant's macros registered in it.
+func newTenantRegistries(config Config, base function.Registry) (tenantRegistries, error) {
+	if base == nil {
+		base = registry.Default()
+	}
+	result := tenantRegistries{
+		header:        config.TenantHeader,
+		defaultTenant: config.DefaultTenant,
+		base:          base,
+		tenants:       map[string]registry.Overlay{},
+	}
+	for name, tenant := range config.Tenants {
+		overlay := registry.NewOverlay(base)
+		if tenant.MacrosPath != "" {
+			macros, err := macro.Load(tenant.MacrosPath)
+			if err != nil {
+				return tenantRegistries{}, fmt.Errorf("error loading macros for tenant %s: %s", name, err.Error())
+			}
+			if err := macro.Register(overlay, macros); err != nil {
+				return tenantRegistries{}, fmt.Errorf("error registering macros for tenant %s: %s", name, err.Error())
+			}
+		}
+		result.tenants[name] = overlay
+	}
+	if _, ok := result.tenants[result.defaultTenant]; result.defaultTenant != "" && !ok {
+		return tenantRegistries{}, fmt.Errorf("the default tenant %s is not configured", result.defaultTenant)
+	}
+	return result, nil
+}
+
+// forRequest is the registry of the tenant named by the request's header, or
+// else of the default tenant.
+func (t tenantRegistries) forRequest(request *http.Request) (function.Registry, error) {
+	name := t.defaultTenant
+	if t.header != "" && request.Header.Get(t.header) != "" {
+		name = request.Header.Get(t.header)
+	}
+	if name == "" {
+		return t.base, nil
+	}
+	overlay, ok := t.tenants[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown tenant %q", name)
+	}
+	return overlay, nil
+}
+
+// withRegistry is the context with the registry selected for the request.
+func (t tenantRegistries) withRegistry(context command.ExecutionContext, request *http.Request) (command.ExecutionContext, error) {
+	selected, err := t.forRequest(request)
+	if err != nil {
+		return command.ExecutionContext{}, err
+	}
+	context.Registry = selected
+	return context, nil
+}
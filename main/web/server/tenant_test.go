@@ -0,0 +1,103 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/square/metrics/function/registry"
+	"github.com/square/metrics/query/command"
+	"github.com/square/metrics/testing_support/assert"
+)
+
+func TestTenantRegistries(t *testing.T) {
+	directory, err := ioutil.TempDir("", "macros")
+	if err != nil {
+		t.Fatalf("error creating directory: %s", err.Error())
+	}
+	defer os.RemoveAll(directory)
+	definitions := "macros:\n- definition: define experimental.twice(x) = $x * 2\n"
+	if err := ioutil.WriteFile(filepath.Join(directory, "experimental.yaml"), []byte(definitions), 0644); err != nil {
+		t.Fatalf("error writing file: %s", err.Error())
+	}
+	config := Config{
+		TenantHeader: "X-Tenant",
+		Tenants: map[string]TenantConfig{
+			"experimental": {MacrosPath: directory},
+			"plain":        {},
+		},
+	}
+	mux, err := NewMux(config, command.ExecutionContext{Registry: registry.Default()}, Hook{})
+	if err != nil {
+		t.Fatalf("unexpected error creating server: %s", err.Error())
+	}
+	for _, test := range []struct {
+		tenant  string
+		status  int
+		matches int
+	}{
+		{"", http.StatusOK, 0},
+		{"plain", http.StatusOK, 0},
+		{"experimental", http.StatusOK, 1},
+		{"unknown", http.StatusBadRequest, 0},
+	} {
+		a := assert.New(t).Contextf("tenant %q", test.tenant)
+		request, err := http.NewRequest("GET", "/functions?match=^experimental", nil)
+		if err != nil {
+			t.Fatalf("error creating request: %s", err.Error())
+		}
+		if test.tenant != "" {
+			request.Header.Set("X-Tenant", test.tenant)
+		}
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, request)
+		a.EqInt(recorder.Code, test.status)
+		if recorder.Code != http.StatusOK {
+			continue
+		}
+		response := struct {
+			Body []interface{} `json:"body"`
+		}{}
+		a.CheckError(json.Unmarshal(recorder.Body.Bytes(), &response))
+		a.EqInt(len(response.Body), test.matches)
+	}
+	if _, ok := registry.Default().GetFunction("experimental.twice"); ok {
+		t.Errorf("expected the tenant's macros not to be registered in the default registry")
+	}
+
+	// The default tenant is used for requests which do not name one.
+	config.DefaultTenant = "experimental"
+	registries, err := newTenantRegistries(config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating registries: %s", err.Error())
+	}
+	request, _ := http.NewRequest("GET", "/query", nil)
+	selected, err := registries.forRequest(request)
+	assert.New(t).CheckError(err)
+	if _, ok := selected.GetFunction("experimental.twice"); !ok {
+		t.Errorf("expected the default tenant's registry to be selected")
+	}
+
+	config.DefaultTenant = "missing"
+	if _, err := newTenantRegistries(config, nil); err == nil {
+		t.Errorf("expected an error for an unconfigured default tenant")
+	}
+}
@@ -25,13 +25,21 @@ import (
 
 // tokenHandler function and metric name tokens available in the system for the autocomplete.
 type tokenHandler struct {
-	context command.ExecutionContext
+	context    command.ExecutionContext
+	registries tenantRegistries
 }
 
 func (h tokenHandler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
 	writer.Header().Set("Content-Type", "application/json")
 
-	metrics, err := h.context.MetricMetadataAPI.GetAllMetrics(metadata.Context{}) // no profiling used
+	context, err := h.registries.withRegistry(h.context, request)
+	if err != nil {
+		writer.WriteHeader(http.StatusBadRequest)
+		writer.Write(encodeError(err))
+		return
+	}
+
+	metrics, err := context.MetricMetadataAPI.GetAllMetrics(metadata.Context{}) // no profiling used
 	if err != nil {
 		writer.WriteHeader(http.StatusBadRequest)
 		writer.Write(encodeError(err))
@@ -42,7 +50,7 @@ func (h tokenHandler) ServeHTTP(writer http.ResponseWriter, request *http.Reques
 		Success: true,
 		QueryResponse: QueryResponse{
 			Body: map[string]interface{}{ // map to array-like types.
-				"functions": h.context.Registry.All(),
+				"functions": context.Registry.All(),
 				"metrics":   metrics,
 			},
 		},
@@ -802,6 +802,10 @@ function dateFromIndex(index, timerange) {
 function makeLabel(onlySingleSeries, serieslist, series) {
   var tagsets = [];
   var label;
+  if (series.name) {
+    // the series was given a display name with tag.alias.
+    return series.name;
+  }
   for (var key in series.tagset) {
     if (key[0] !== "$") {
       tagsets.push(key + ":" + series.tagset[key]);
@@ -31,6 +31,8 @@ import (
 	"github.com/square/metrics/metric_metadata/cached"
 	"github.com/square/metrics/metric_metadata/cassandra"
 	"github.com/square/metrics/query/command"
+	"github.com/square/metrics/query/macro"
+	"github.com/square/metrics/query/parser"
 	"github.com/square/metrics/timeseries/blueflood"
 	"github.com/square/metrics/util"
 
@@ -67,6 +69,7 @@ func main() {
 
 	config := struct {
 		ConversionRulesPath string           `yaml:"conversion_rules_path"`
+		MacrosPath          string           `yaml:"macros_path"`
 		Cassandra           cassandra.Config `yaml:"cassandra"`
 		Blueflood           blueflood.Config `yaml:"blueflood"`
 		Web                 server.Config    `yaml:"web"`
@@ -86,6 +89,18 @@ func main() {
 		return
 	}
 
+	if config.MacrosPath != "" {
+		macros, err := macro.Load(config.MacrosPath)
+		if err != nil {
+			common.ExitWithErrorMessage("Error loading macros: %s", err.Error())
+			return
+		}
+		if err := macro.Register(registry.Default(), macros); err != nil {
+			common.ExitWithErrorMessage("Error registering macros: %s", err.Error())
+			return
+		}
+	}
+
 	config.Blueflood.GraphiteMetricConverter = &util.RuleBasedGraphiteConverter{Ruleset: ruleset}
 
 	blueflood := blueflood.NewBlueflood(config.Blueflood)
@@ -112,6 +127,7 @@ func main() {
 		FetchLimit:           1500,
 		SlotLimit:            5000,
 		Registry:             registry.Default(),
+		PredicateParser:      parser.ParsePredicate,
 		Ctx:                  context.Background(),
 	})
 	if err != nil {
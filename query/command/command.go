@@ -42,6 +42,7 @@ type ExecutionContext struct {
 	SlotLimit             int                   // optional (0 => default 1000)
 	Profiler              *inspect.Profiler     // optional
 	AdditionalConstraints predicate.Predicate   // optional. Additional contrains for describe and select commands
+	PredicateParser       predicate.Parser      // optional. Parses predicates written as strings, such as by filter.tag
 
 	Ctx netcontext.Context
 }
@@ -77,11 +78,43 @@ type DescribeMetricsCommand struct {
 	TagValue string
 }
 
+// DescribeFunctionsCommand returns the usage of all functions available in the registry.
+type DescribeFunctionsCommand struct {
+	Matcher *regexp.Regexp
+}
+
 type SelectContext struct {
 	Start        int64                   // Start of data timerange
 	End          int64                   // End of data timerange
 	Resolution   int64                   // Resolution of data timerange
 	SampleMethod timeseries.SampleMethod // to use when up/downsampling to match requested resolution
+	Timezone     *time.Location          // optional; when set, slots are aligned to local time instead of UTC
+}
+
+// alignmentOffset returns the offset (in milliseconds) which aligns slots of the
+// select context to local midnight in its timezone, measured at the start time.
+func (context SelectContext) alignmentOffset() int64 {
+	if context.Timezone == nil {
+		return 0
+	}
+	_, utcOffset := time.Unix(context.Start/1000, 0).In(context.Timezone).Zone()
+	return -int64(utcOffset) * 1000
+}
+
+// checkAlignment returns an error if the timezone's offset changes (e.g. for
+// daylight saving time) within the timerange by an amount which is not a
+// multiple of its resolution, since the slots after the change would no longer
+// be aligned to local time. In particular, daily slots cannot span a change.
+func (context SelectContext) checkAlignment(timerange api.Timerange) error {
+	if context.Timezone == nil {
+		return nil
+	}
+	startZone, startOffset := timerange.Start().In(context.Timezone).Zone()
+	endZone, endOffset := timerange.End().In(context.Timezone).Zone()
+	if (int64(endOffset-startOffset)*1000)%timerange.ResolutionMillis() == 0 {
+		return nil
+	}
+	return fmt.Errorf("slots of %s cannot stay aligned to local time in %s across the change from %s to %s; split the query at the change or use a finer resolution", timerange.Resolution(), context.Timezone, startZone, endZone)
 }
 
 // SelectCommand is the bread and butter of the metrics query engine.
@@ -183,6 +216,36 @@ func (cmd *DescribeMetricsCommand) Name() string {
 	return "describe metrics"
 }
 
+// Execute describes each function whose name matches.
+func (cmd *DescribeFunctionsCommand) Execute(context ExecutionContext) (Result, error) {
+	r := context.functionRegistry()
+	descriptions := []function.Description{}
+	for _, name := range r.All() {
+		if cmd.Matcher != nil && !cmd.Matcher.MatchString(name) {
+			continue
+		}
+		fun, ok := r.GetFunction(name)
+		if !ok {
+			continue
+		}
+		if described, ok := fun.(function.DescribedFunction); ok {
+			descriptions = append(descriptions, described.Describe())
+		} else {
+			descriptions = append(descriptions, function.Description{Name: name})
+		}
+	}
+	return Result{
+		Body: descriptions,
+		Metadata: map[string]interface{}{
+			"count": len(descriptions),
+		},
+	}, nil
+}
+
+func (cmd *DescribeFunctionsCommand) Name() string {
+	return "describe functions"
+}
+
 type QueryResult struct {
 	Query string `json:"query"`
 	Name  string `json:"name"`
@@ -194,19 +257,33 @@ type QueryResult struct {
 	Scalars []function.TaggedScalar `json:"scalars,omitempty"`
 }
 
-// Execute performs the query represented by the given query string, and returs the result.
-func (cmd *SelectCommand) Execute(context ExecutionContext) (Result, error) {
-	userTimerange, err := api.NewSnappedTimerange(cmd.Context.Start, cmd.Context.End, cmd.Context.Resolution)
-	if err != nil {
-		return Result{}, err
+// slotLimit returns the maximum number of slots allowed for each series.
+func (context ExecutionContext) slotLimit() int {
+	if context.SlotLimit == 0 {
+		return 1000 // the default limit
+	}
+	return context.SlotLimit
+}
+
+// functionRegistry returns the registry of functions, which is the default
+// registry unless one was provided.
+func (context ExecutionContext) functionRegistry() function.Registry {
+	if context.Registry == nil {
+		return registry.Default()
 	}
-	slotLimit := context.SlotLimit
-	defaultLimit := 1000
-	if slotLimit == 0 {
-		slotLimit = defaultLimit // the default limit
+	return context.Registry
+}
+
+// chooseTimerange applies the storage API's choice of resolution to the
+// timerange requested by the select command.
+func (cmd *SelectCommand) chooseTimerange(context ExecutionContext) (api.Timerange, error) {
+	offset := cmd.Context.alignmentOffset()
+	userTimerange, err := api.NewSnappedTimerangeWithOffset(cmd.Context.Start, cmd.Context.End, cmd.Context.Resolution, offset)
+	if err != nil {
+		return api.Timerange{}, err
 	}
 
-	smallestResolution := userTimerange.Duration() / time.Duration(slotLimit-2)
+	smallestResolution := userTimerange.Duration() / time.Duration(context.slotLimit()-2)
 	// ((end + res/2) - (start - res/2)) / res + 1 <= slots // make adjustments for a snap that moves the endpoints
 	// (do some algebra)
 	// (end - start + res) + res <= slots * res
@@ -217,15 +294,48 @@ func (cmd *SelectCommand) Execute(context ExecutionContext) (Result, error) {
 	// Update the timerange by applying the insights of the storage API:
 	chosenResolution, err := context.TimeseriesStorageAPI.ChooseResolution(userTimerange, smallestResolution)
 	if err != nil {
-		return Result{}, err
+		return api.Timerange{}, err
 	}
 
-	chosenTimerange, err := api.NewSnappedTimerange(userTimerange.StartMillis(), userTimerange.EndMillis(), int64(chosenResolution/time.Millisecond))
+	chosenTimerange, err := api.NewSnappedTimerangeWithOffset(userTimerange.StartMillis(), userTimerange.EndMillis(), int64(chosenResolution/time.Millisecond), offset)
+	if err != nil {
+		return api.Timerange{}, err
+	}
+	if err := cmd.Context.checkAlignment(chosenTimerange); err != nil {
+		return api.Timerange{}, err
+	}
+	return chosenTimerange, nil
+}
+
+// evaluationContextBuilder creates the builder for the evaluation context used
+// to evaluate the select command's expressions.
+func (cmd *SelectCommand) evaluationContextBuilder(context ExecutionContext, timerange api.Timerange, ctx netcontext.Context) function.EvaluationContextBuilder {
+	return function.EvaluationContextBuilder{
+		MetricMetadataAPI:    context.MetricMetadataAPI,
+		FetchLimit:           function.NewFetchCounter(context.FetchLimit),
+		TimeseriesStorageAPI: context.TimeseriesStorageAPI,
+		Predicate:            predicate.All(cmd.Predicate, context.AdditionalConstraints),
+		SampleMethod:         cmd.Context.SampleMethod,
+		Timezone:             cmd.Context.Timezone,
+		PredicateParser:      context.PredicateParser,
+		Timerange:            timerange,
+
+		Registry:        context.functionRegistry(),
+		Profiler:        context.Profiler,
+		EvaluationNotes: new(function.EvaluationNotes),
+
+		Ctx: ctx,
+	}
+}
+
+// Execute performs the query represented by the given query string, and returs the result.
+func (cmd *SelectCommand) Execute(context ExecutionContext) (Result, error) {
+	chosenTimerange, err := cmd.chooseTimerange(context)
 	if err != nil {
 		return Result{}, err
 	}
 
-	if chosenTimerange.Slots() > slotLimit {
+	if slotLimit := context.slotLimit(); chosenTimerange.Slots() > slotLimit {
 		return Result{}, function.NewLimitError(
 			"Requested number of data points exceeds the configured limit",
 			chosenTimerange.Slots(), slotLimit)
@@ -243,25 +353,7 @@ func (cmd *SelectCommand) Execute(context ExecutionContext) (Result, error) {
 		defer cancelFunc()
 	}
 
-	r := context.Registry
-	if r == nil {
-		r = registry.Default()
-	}
-
-	evaluationContext := function.EvaluationContextBuilder{
-		MetricMetadataAPI:    context.MetricMetadataAPI,
-		FetchLimit:           function.NewFetchCounter(context.FetchLimit),
-		TimeseriesStorageAPI: context.TimeseriesStorageAPI,
-		Predicate:            predicate.All(cmd.Predicate, context.AdditionalConstraints),
-		SampleMethod:         cmd.Context.SampleMethod,
-		Timerange:            chosenTimerange,
-
-		Registry:        r,
-		Profiler:        context.Profiler,
-		EvaluationNotes: new(function.EvaluationNotes),
-
-		Ctx: ctx,
-	}.Build()
+	evaluationContext := cmd.evaluationContextBuilder(context, chosenTimerange, ctx).Build()
 
 	results := make(chan []function.Value, 1)
 	errors := make(chan error, 1)
@@ -0,0 +1,256 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/query/expression"
+	"github.com/square/metrics/timeseries"
+
+	netcontext "golang.org/x/net/context"
+)
+
+// ExplainCommand describes the work that a select command would perform,
+// without fetching any timeseries data.
+type ExplainCommand struct {
+	Select *SelectCommand
+}
+
+// ExplainResult is the body of the result of an ExplainCommand.
+type ExplainResult struct {
+	Expressions         []ExplainNode         `json:"expressions"`
+	Timerange           api.Timerange         `json:"timerange"`            // the timerange chosen by the storage API
+	RequestedResolution int64                 `json:"requested_resolution"` // the resolution requested by the user (in milliseconds)
+	Slots               int                   `json:"slots"`
+	SlotLimit           int                   `json:"slot_limit"`
+	Fetches             int                   `json:"fetches"` // the total number of series which will be fetched
+	FetchLimit          int                   `json:"fetch_limit"`
+	FetchRequests       []ExplainFetchRequest `json:"fetch_requests"`
+	FetchPlans          []ExplainFetchPlan    `json:"fetch_plans,omitempty"` // only for storage APIs which are a timeseries.FetchPlanner
+}
+
+// ExplainNode describes a single node of the expression tree.
+type ExplainNode struct {
+	Expression string        `json:"expression"`
+	Kind       string        `json:"kind"`             // one of "function", "metric", "annotation", or "literal"
+	Shared     bool          `json:"shared,omitempty"` // whether the result is shared with an identical expression evaluated elsewhere
+	Fetch      *ExplainFetch `json:"fetch,omitempty"`
+	Arguments  []ExplainNode `json:"arguments,omitempty"`
+}
+
+// ExplainFetch describes the series matched by a metric expression.
+type ExplainFetch struct {
+	Metric         string `json:"metric"`
+	Predicate      string `json:"predicate"`
+	MatchedTagSets int    `json:"matched_tagsets"`
+	Error          string `json:"error,omitempty"`
+}
+
+// ExplainFetchRequest describes a single call that would be made to the storage API.
+type ExplainFetchRequest struct {
+	Metric    string        `json:"metric"`
+	Series    int           `json:"series"`
+	Timerange api.Timerange `json:"timerange"`
+}
+
+// ExplainFetchPlan describes how the storage API would fetch each series for a timerange.
+type ExplainFetchPlan struct {
+	Timerange api.Timerange             `json:"timerange"`
+	Intervals []timeseries.PlannedFetch `json:"intervals,omitempty"`
+	Error     string                    `json:"error,omitempty"`
+}
+
+// Execute evaluates the select command against a storage API which records
+// requests instead of performing them, and describes the result.
+func (cmd *ExplainCommand) Execute(context ExecutionContext) (Result, error) {
+	chosenTimerange, err := cmd.Select.chooseTimerange(context)
+	if err != nil {
+		return Result{}, err
+	}
+
+	ctx, cancelFunc := context.Ctx, netcontext.CancelFunc(nil)
+	if context.Timeout != 0 {
+		ctx, cancelFunc = netcontext.WithTimeout(ctx, context.Timeout)
+	}
+	if cancelFunc != nil {
+		defer cancelFunc()
+	}
+
+	storage := &explainStorage{StorageAPI: context.TimeseriesStorageAPI}
+	builder := cmd.Select.evaluationContextBuilder(context, chosenTimerange, ctx)
+	builder.TimeseriesStorageAPI = storage
+	builder.FetchLimit = function.NewFetchCounter(math.MaxInt32) // count the fetches instead of limiting them
+	evaluationContext := builder.Build()
+
+	seen := map[string]bool{}
+	nodes := make([]ExplainNode, len(cmd.Select.Expressions))
+	for i, expression := range cmd.Select.Expressions {
+		nodes[i] = explainExpression(expression, evaluationContext, seen)
+	}
+
+	if _, err := function.EvaluateMany(evaluationContext, cmd.Select.Expressions); err != nil {
+		return Result{}, err
+	}
+
+	requests := storage.Requests()
+	plans := []ExplainFetchPlan{}
+	if planner, ok := context.TimeseriesStorageAPI.(timeseries.FetchPlanner); ok {
+		planned := map[api.Timerange]bool{}
+		for _, request := range requests {
+			if planned[request.Timerange] {
+				continue
+			}
+			planned[request.Timerange] = true
+			plan := ExplainFetchPlan{Timerange: request.Timerange}
+			plan.Intervals, err = planner.PlanFetch(timeseries.RequestDetails{
+				SampleMethod: cmd.Select.Context.SampleMethod,
+				Timerange:    request.Timerange,
+				Ctx:          ctx,
+				Profiler:     context.Profiler,
+			})
+			if err != nil {
+				plan.Error = err.Error()
+			}
+			plans = append(plans, plan)
+		}
+	}
+
+	return Result{
+		Body: ExplainResult{
+			Expressions:         nodes,
+			Timerange:           chosenTimerange,
+			RequestedResolution: cmd.Select.Context.Resolution,
+			Slots:               chosenTimerange.Slots(),
+			SlotLimit:           context.slotLimit(),
+			Fetches:             builder.FetchLimit.Current(),
+			FetchLimit:          context.FetchLimit,
+			FetchRequests:       requests,
+			FetchPlans:          plans,
+		},
+	}, nil
+}
+
+// Name returns the name of the command.
+func (cmd *ExplainCommand) Name() string {
+	return "explain"
+}
+
+// explainExpression describes the given expression and its arguments. Memoized
+// expressions which have already been seen are marked as shared.
+func explainExpression(e function.Expression, context function.EvaluationContext, seen map[string]bool) ExplainNode {
+	node := ExplainNode{
+		Expression: e.ExpressionString(function.StringQuery),
+		Kind:       "literal",
+	}
+	var subject interface{} = e
+	actual, memoized := function.Unmemoize(e)
+	if memoized {
+		subject = actual
+	}
+	switch actual := subject.(type) {
+	case *expression.FunctionExpression:
+		node.Kind = "function"
+		for _, argument := range actual.Arguments {
+			node.Arguments = append(node.Arguments, explainExpression(argument, context, seen))
+		}
+	case *expression.AnnotationExpression:
+		node.Kind = "annotation"
+		node.Arguments = []ExplainNode{explainExpression(actual.Expression, context, seen)}
+	case *expression.MetricFetchExpression:
+		node.Kind = "metric"
+		node.Fetch = &ExplainFetch{
+			Metric:    actual.MetricName,
+			Predicate: actual.Predicate.Query(),
+		}
+		tagsets, err := actual.MatchingTagSets(context)
+		if err != nil {
+			node.Fetch.Error = err.Error()
+		}
+		node.Fetch.MatchedTagSets = len(tagsets)
+	}
+	if memoized && node.Kind != "literal" {
+		key := e.ExpressionString(function.StringMemoization)
+		node.Shared = seen[key]
+		seen[key] = true
+	}
+	return node
+}
+
+// explainStorage is a timeseries.StorageAPI which records fetch requests and
+// responds to them with empty series instead of fetching data.
+type explainStorage struct {
+	timeseries.StorageAPI
+	mutex    sync.Mutex
+	requests []ExplainFetchRequest
+}
+
+// FetchSingleTimeseries records the request and returns a series of NaN values.
+func (s *explainStorage) FetchSingleTimeseries(request timeseries.FetchRequest) (api.Timeseries, error) {
+	list, err := s.FetchMultipleTimeseries(timeseries.FetchMultipleRequest{
+		Metrics:        []api.TaggedMetric{request.Metric},
+		RequestDetails: request.RequestDetails,
+	})
+	if err != nil {
+		return api.Timeseries{}, err
+	}
+	return list.Series[0], nil
+}
+
+// FetchMultipleTimeseries records the request and returns series of NaN values.
+func (s *explainStorage) FetchMultipleTimeseries(request timeseries.FetchMultipleRequest) (api.SeriesList, error) {
+	if len(request.Metrics) != 0 {
+		s.mutex.Lock()
+		s.requests = append(s.requests, ExplainFetchRequest{
+			Metric:    string(request.Metrics[0].MetricKey),
+			Series:    len(request.Metrics),
+			Timerange: request.Timerange,
+		})
+		s.mutex.Unlock()
+	}
+	list := api.SeriesList{Series: make([]api.Timeseries, len(request.Metrics))}
+	for i, metric := range request.Metrics {
+		values := make([]float64, request.Timerange.Slots())
+		for j := range values {
+			values[j] = math.NaN()
+		}
+		list.Series[i] = api.Timeseries{Values: values, TagSet: metric.TagSet}
+	}
+	return list, nil
+}
+
+// Requests returns the recorded requests, ordered by metric and then by timerange.
+func (s *explainStorage) Requests() []ExplainFetchRequest {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	requests := append([]ExplainFetchRequest{}, s.requests...)
+	sort.Sort(fetchRequestsByMetric(requests))
+	return requests
+}
+
+type fetchRequestsByMetric []ExplainFetchRequest
+
+func (r fetchRequestsByMetric) Len() int      { return len(r) }
+func (r fetchRequestsByMetric) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r fetchRequestsByMetric) Less(i, j int) bool {
+	if r[i].Metric != r[j].Metric {
+		return r[i].Metric < r[j].Metric
+	}
+	return r[i].Timerange.StartMillis() < r[j].Timerange.StartMillis()
+}
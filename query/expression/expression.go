@@ -81,7 +81,8 @@ type MetricFetchExpression struct {
 	Predicate  predicate.Predicate
 }
 
-func (expr *MetricFetchExpression) ActualEvaluate(context function.EvaluationContext) (function.Value, error) {
+// MatchingTagSets returns the tagsets of the series that the expression would fetch in the given context.
+func (expr *MetricFetchExpression) MatchingTagSets(context function.EvaluationContext) ([]api.TagSet, error) {
 	// Merge predicates appropriately
 	p := predicate.All(expr.Predicate, context.Predicate())
 
@@ -92,7 +93,14 @@ func (expr *MetricFetchExpression) ActualEvaluate(context function.EvaluationCon
 	if err != nil {
 		return nil, err
 	}
-	filtered := applyPredicates(metricTagSets, p)
+	return applyPredicates(metricTagSets, p), nil
+}
+
+func (expr *MetricFetchExpression) ActualEvaluate(context function.EvaluationContext) (function.Value, error) {
+	filtered, err := expr.MatchingTagSets(context)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := context.FetchLimitConsume(len(filtered)); err != nil {
 		return nil, err
@@ -200,6 +208,20 @@ func (expr *AnnotationExpression) ExpressionString(mode function.DescriptionMode
 	return fmt.Sprintf("%s {%s}", expr.Expression.ExpressionString(mode), expr.Annotation)
 }
 
+// Parameter is a parameter of a macro, which is replaced by its argument when
+// the macro is expanded.
+type Parameter struct {
+	Name string
+}
+
+func (expr Parameter) Evaluate(context function.EvaluationContext) (function.Value, error) {
+	return nil, SyntaxError{fmt.Sprintf("parameter $%s has not been given a value", expr.Name)}
+}
+
+func (expr Parameter) ExpressionString(mode function.DescriptionMode) string {
+	return "$" + expr.Name
+}
+
 // Auxiliary functions
 // ===================
 
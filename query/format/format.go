@@ -0,0 +1,255 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package format renders queries with canonical formatting.
+//
+// Each clause of a select statement (the expressions, "where", "from", "to",
+// "resolution", "sample by" and "timezone") is placed on its own line.
+// Expressions which do not fit on a single line are placed one per line, and
+// long pipelines are broken before each "|". Comments are preserved.
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/square/metrics/query/parser"
+)
+
+// MaxWidth is the width that formatted lines are kept within, where possible.
+const MaxWidth = 80
+
+const indentation = "  "
+
+// parseOptions allow query templates to be formatted without their variables.
+var parseOptions = parser.Options{AllowUnboundVariables: true}
+
+// Format parses the query and renders it with canonical formatting.
+// An error is returned if the query cannot be parsed.
+func Format(query string) (string, error) {
+	if _, err := parser.ParseWithOptions(query, parseOptions); err != nil {
+		return "", err
+	}
+	tokens, comments, err := lex(query)
+	if err != nil {
+		return "", err
+	}
+	p := printer{}
+	switch {
+	case len(tokens) > 0 && tokens[0].is("describe"):
+		p.describeStatement(tokens)
+	case len(tokens) > 0 && tokens[0].is("explain"):
+		p.selectStatement(tokens[:1], tokens[1:])
+	default:
+		p.selectStatement(nil, tokens)
+	}
+	for _, c := range comments {
+		p.output = append(p.output, c.text)
+	}
+	formatted := strings.Join(p.output, "\n")
+	if _, err := parser.ParseWithOptions(formatted, parseOptions); err != nil {
+		return "", fmt.Errorf("formatting produced a query which does not parse: %s", err.Error())
+	}
+	return formatted, nil
+}
+
+// line is a sequence of tokens to be placed on a single line of output.
+type line struct {
+	indent int
+	tokens []token
+}
+
+type printer struct {
+	output []string
+}
+
+// describeStatement places the describe statement on a single line.
+func (p *printer) describeStatement(tokens []token) {
+	if len(tokens) > 1 && tokens[1].is("functions") {
+		tokens[1].text = "functions"
+	}
+	p.print(line{tokens: tokens})
+}
+
+// selectStatement places the expressions of a select statement on the first
+// line (or one per line if they do not fit) followed by one line per clause.
+func (p *printer) selectStatement(prefix []token, tokens []token) {
+	keyword := token{kind: tokenKeyword, text: "select"}
+	if len(tokens) > 0 && tokens[0].is("select") {
+		keyword, tokens = tokens[0], tokens[1:]
+	} else if len(tokens) > 0 {
+		// The first expression's leading comments belong above the statement.
+		keyword.leading, tokens[0].leading = tokens[0].leading, nil
+	}
+	head := append(append([]token{}, prefix...), keyword)
+
+	items := [][]token{}
+	rest := tokens
+	for {
+		var item []token
+		item, rest = splitClause(rest, func(t token, previous token) bool {
+			return t.is(",") || t.is("where") || (isPropertyKey(t) && previous.endsOperand())
+		})
+		if len(rest) == 0 || !rest[0].is(",") {
+			items = append(items, item)
+			break
+		}
+		items = append(items, append(item, rest[0]))
+		rest = rest[1:]
+	}
+
+	// Comments on any expression but the last (except those moved to the end of
+	// the line) require each expression to be placed on its own line.
+	singleLine := append([]token{}, head...)
+	commented := len(items[len(items)-1]) > 0 && len(items[len(items)-1][0].leading) > 0
+	for i, item := range items {
+		singleLine = append(singleLine, item...)
+		for _, t := range item {
+			if i < len(items)-1 && len(t.leading)+len(t.trailing) > 0 {
+				commented = true
+			}
+		}
+	}
+	if !commented && len(flat(singleLine)) <= MaxWidth {
+		p.print(line{tokens: singleLine})
+	} else {
+		p.print(line{tokens: head})
+		for _, item := range items {
+			p.expression(item, 1)
+		}
+	}
+
+	for len(rest) > 0 {
+		key := rest[0]
+		if isPropertyKey(key) {
+			key.text = strings.ToLower(key.text)
+		}
+		var clause []token
+		clause, rest = splitClause(rest[1:], func(t token, previous token) bool {
+			return isPropertyKey(t) && previous.endsOperand()
+		})
+		p.print(line{tokens: append([]token{key}, clause...)})
+	}
+}
+
+// expression places an expression on a line, breaking it before each pipe
+// which is not nested in parentheses if it is too long.
+func (p *printer) expression(tokens []token, indent int) {
+	if len(indentation)*indent+len(flat(tokens)) <= MaxWidth {
+		p.print(line{indent: indent, tokens: tokens})
+		return
+	}
+	depth := 0
+	start := 0
+	for i, t := range tokens {
+		switch {
+		case t.is("(") || t.is("["):
+			depth++
+		case t.is(")") || t.is("]"):
+			depth--
+		case t.is("|") && depth == 0 && i > start:
+			p.print(line{indent: indent, tokens: tokens[start:i]})
+			if start == 0 {
+				indent++
+			}
+			start = i
+		}
+	}
+	p.print(line{indent: indent, tokens: tokens[start:]})
+}
+
+// print renders a single line. Leading comments of its first token are placed
+// on lines above it, block comments within the line are kept in place and
+// line comments within the line are moved to its end.
+func (p *printer) print(l line) {
+	prefix := strings.Repeat(indentation, l.indent)
+	content := bytes.Buffer{}
+	ends := []string{}
+	for i, t := range l.tokens {
+		spaced := i > 0 && spaceBetween(l.tokens[i-1], t)
+		for _, c := range t.leading {
+			switch {
+			case i == 0:
+				p.output = append(p.output, prefix+c.text)
+			case c.isLine():
+				ends = append(ends, c.text)
+			default:
+				content.WriteString(" " + c.text)
+				spaced = true
+			}
+		}
+		if spaced {
+			content.WriteString(" ")
+		}
+		content.WriteString(t.text)
+		for _, c := range t.trailing {
+			if c.isLine() || i == len(l.tokens)-1 {
+				ends = append(ends, c.text)
+			} else {
+				content.WriteString(" " + c.text)
+			}
+		}
+	}
+	if len(ends) > 0 {
+		content.WriteString(" " + strings.Join(ends, " "))
+	}
+	p.output = append(p.output, prefix+content.String())
+}
+
+// flat renders the tokens on a single line, ignoring comments.
+func flat(tokens []token) string {
+	result := bytes.Buffer{}
+	for i, t := range tokens {
+		if i > 0 && spaceBetween(tokens[i-1], t) {
+			result.WriteString(" ")
+		}
+		result.WriteString(t.text)
+	}
+	return result.String()
+}
+
+// spaceBetween reports whether a space separates the two adjacent tokens.
+func spaceBetween(previous token, next token) bool {
+	switch {
+	case previous.is("(") || previous.is("["):
+		return false
+	case next.is(")") || next.is("]") || next.is(","):
+		return false
+	case (next.is("(") || next.is("[")) && previous.kind == tokenWord:
+		return false // function calls and metric predicates
+	}
+	return true
+}
+
+// splitClause splits the tokens before the first token, not nested in
+// parentheses or brackets, for which the end function returns true.
+func splitClause(tokens []token, end func(t token, previous token) bool) ([]token, []token) {
+	depth := 0
+	for i, t := range tokens {
+		switch {
+		case t.is("(") || t.is("["):
+			depth++
+		case t.is(")") || t.is("]"):
+			depth--
+		case depth == 0 && i > 0 && end(t, tokens[i-1]):
+			return tokens[:i], tokens[i:]
+		}
+	}
+	return tokens, nil
+}
+
+func isPropertyKey(t token) bool {
+	return t.is("from") || t.is("to") || t.is("resolution") || t.is("sample") || (t.kind == tokenWord && t.is("timezone"))
+}
@@ -0,0 +1,251 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/query/command"
+	"github.com/square/metrics/query/parser"
+	"github.com/square/metrics/testing_support/assert"
+)
+
+func TestFormat(t *testing.T) {
+	for _, test := range []struct {
+		query    string
+		expected string
+	}{
+		{"x from 0 to 0", "select x\nfrom 0\nto 0"},
+		{"SELECT   x+1 ,y*-2 from 0 to 10 resolution 1s", "select x + 1, y * -2\nfrom 0\nto 10\nresolution 1s"},
+		{"select x-1 from 0 to 0", "select x - 1\nfrom 0\nto 0"},
+		{"select aggregate.sum( x [ dc = 'west' and not host in ('a','b') ] group by dc,env ) {total} where env != \"prod\" from -1h to now sample by 'max' timezone 'UTC'",
+			"select\n  aggregate.sum(x[dc = 'west' and not host in ('a', 'b')] group by dc, env) {total}\nwhere env != \"prod\"\nfrom -1h\nto now\nsample by 'max'\ntimezone 'UTC'"},
+		{"select x|transform.abs|transform.moving_average(5m) from startofday-1d to now", "select x | transform.abs | transform.moving_average(5m)\nfrom startofday - 1d\nto now"},
+		{"select cpu.user | aggregate.sum(group by datacenter, service) | transform.moving_average(10m) | filter.highest_max(5) | transform.timeshift(-1w) from 0 to 0",
+			"select\n  cpu.user\n    | aggregate.sum(group by datacenter, service)\n    | transform.moving_average(10m)\n    | filter.highest_max(5)\n    | transform.timeshift(-1w)\nfrom 0\nto 0"},
+		{"select a_very_long_metric_name_for_testing, another_very_long_metric_name_for_testing from 0 to 0",
+			"select\n  a_very_long_metric_name_for_testing,\n  another_very_long_metric_name_for_testing\nfrom 0\nto 0"},
+		{"-- the load\nselect load -- per host\nfrom 0 /* start */ to 0\n-- end", "-- the load\nselect load -- per host\nfrom 0 /* start */\nto 0\n-- end"},
+		{"select a, -- first\n b -- second\nfrom 0 to 0", "select\n  a, -- first\n  b -- second\nfrom 0\nto 0"},
+		{"select a\n-- about b\n, b from 0 to 0", "select\n  a, -- about b\n  b\nfrom 0\nto 0"},
+		{"explain x from 0 to 0", "explain select x\nfrom 0\nto 0"},
+		{"DESCRIBE ALL MATCH 'cpu'", "describe all match 'cpu'"},
+		{"describe Functions", "describe functions"},
+		{"describe   cpu where dc='west'", "describe cpu where dc = 'west'"},
+		{"describe metrics where dc='west'", "describe metrics where dc = 'west'"},
+		{"select timezone - 1 from 0 to 0 timezone 'UTC'", "select timezone - 1\nfrom 0\nto 0\ntimezone 'UTC'"},
+		{"select `a-b`[`c-d` match '^x'] from 0 to 0", "select `a-b`[`c-d` match '^x']\nfrom 0\nto 0"},
+		{"select x[app=$app and host in $hosts]|transform.moving_average( $window ) from $start to now", "select x[app = $app and host in $hosts] | transform.moving_average($window)\nfrom $start\nto now"},
+	} {
+		a := assert.New(t).Contextf("%s", test.query)
+		formatted, err := Format(test.query)
+		a.CheckError(err)
+		a.EqString(formatted, test.expected)
+		again, err := Format(formatted)
+		a.CheckError(err)
+		a.EqString(again, formatted)
+	}
+}
+
+func TestFormat_Error(t *testing.T) {
+	for _, query := range []string{
+		"",
+		"select from 0 to 0",
+		"select x from 0 to 0 where y = 'z'",
+	} {
+		if _, err := Format(query); err == nil {
+			t.Errorf("expected formatting %q to fail", query)
+		}
+	}
+}
+
+// describeCommand gives a description of the command that is identical for
+// equivalent commands.
+func describeCommand(c command.Command) string {
+	switch c := c.(type) {
+	case *command.ExplainCommand:
+		return "explain " + describeCommand(c.Select)
+	case *command.SelectCommand:
+		expressions := make([]string, len(c.Expressions))
+		for i, expression := range c.Expressions {
+			expressions[i] = expression.ExpressionString(function.StringQuery)
+		}
+		return fmt.Sprintf("select %s where %s context %+v", strings.Join(expressions, ", "), c.Predicate.Query(), c.Context)
+	}
+	return fmt.Sprintf("%+v", c)
+}
+
+// queryGenerator generates random queries, with random spacing and comments.
+type queryGenerator struct {
+	random *rand.Rand
+	parts  []string
+}
+
+func (g *queryGenerator) emit(parts ...string) {
+	for _, part := range parts {
+		switch g.random.Intn(12) {
+		case 0:
+			g.parts = append(g.parts, "\n")
+		case 1:
+			g.parts = append(g.parts, " /* note */ ")
+		case 2:
+			g.parts = append(g.parts, " -- remark\n")
+		case 3, 4, 5:
+			g.parts = append(g.parts, " ")
+		default:
+			if len(g.parts) > 0 && adjoins(g.parts[len(g.parts)-1], part) {
+				g.parts = append(g.parts, " ")
+			}
+		}
+		g.parts = append(g.parts, part)
+	}
+}
+
+// adjoins reports whether the parts would run together without a space.
+func adjoins(previous string, next string) bool {
+	last, first := rune(previous[len(previous)-1]), rune(next[0])
+	isWord := func(c rune) bool {
+		return isIdentifierRune(c) || strings.ContainsRune("'\"`", c)
+	}
+	return (isWord(last) && isWord(first)) || (last == '-' && first == '-')
+}
+
+func (g *queryGenerator) pick(options ...string) string {
+	return options[g.random.Intn(len(options))]
+}
+
+func (g *queryGenerator) predicate(depth int) {
+	choice := g.random.Intn(6)
+	if depth >= 2 {
+		choice = 2 + g.random.Intn(4)
+	}
+	switch choice {
+	case 0:
+		g.emit("not")
+		g.predicate(depth + 1)
+	case 1:
+		g.emit("(")
+		g.predicate(depth + 1)
+		g.emit(g.pick("and", "or"))
+		g.predicate(depth + 1)
+		g.emit(")")
+	case 2:
+		g.emit(g.pick("host", "`data-center`"), "in", "(", "'a'", ",", "'b'", ")")
+	case 3:
+		g.emit(g.pick("host", "app"), "match", "'^x.*'")
+	default:
+		g.emit(g.pick("dc", "env"), g.pick("=", "!="), g.pick("'west'", "\"east\""))
+	}
+}
+
+func (g *queryGenerator) expression(depth int) {
+	choice := g.random.Intn(8)
+	if depth >= 2 {
+		choice = 4 + g.random.Intn(4)
+	}
+	switch choice {
+	case 0:
+		g.expression(depth + 1)
+		g.emit(g.pick("+", "-", "*", "/"))
+		g.expression(depth + 1)
+	case 1:
+		g.expression(depth + 1)
+		for i := g.random.Intn(4); i >= 0; i-- {
+			g.emit("|", g.pick("transform.abs", "transform.moving_average(5m)", "aggregate.sum(group by dc, env)", "filter.highest_max(3)"))
+		}
+	case 2:
+		g.emit("aggregate.max", "(")
+		g.expression(depth + 1)
+		g.emit("collapse", "by", "dc", ")")
+	case 3:
+		g.emit("(")
+		g.expression(depth + 1)
+		g.emit(")")
+		if g.random.Intn(2) == 0 {
+			g.emit("{named}")
+		}
+	case 4:
+		g.emit(g.pick("3", "-2.5", "1e3", "'text'"))
+	default:
+		g.emit(g.pick("cpu", "requests.count", "`disk-usage`"))
+		if g.random.Intn(2) == 0 {
+			g.emit("[")
+			g.predicate(1)
+			g.emit("]")
+		}
+	}
+}
+
+func (g *queryGenerator) query() string {
+	g.parts = nil
+	if g.random.Intn(5) == 0 {
+		g.emit("explain")
+	}
+	if g.random.Intn(3) != 0 {
+		g.emit(g.pick("select", "SELECT"))
+	}
+	g.expression(0)
+	for i := g.random.Intn(3); i > 0; i-- {
+		g.emit(",")
+		g.expression(0)
+	}
+	if g.random.Intn(2) == 0 {
+		g.emit("where")
+		g.predicate(0)
+	}
+	g.emit("from", g.pick("0", "1000", "'2016-1-4'"), "to", g.pick("100000", "'2016-1-6'"))
+	if g.random.Intn(2) == 0 {
+		g.emit("resolution", g.pick("30s", "1m"))
+	}
+	if g.random.Intn(2) == 0 {
+		g.emit("sample", "by", g.pick("'max'", "'mean'"))
+	}
+	return strings.Join(g.parts, "")
+}
+
+func TestFormat_RoundTrip(t *testing.T) {
+	g := queryGenerator{random: rand.New(rand.NewSource(1))}
+	for i := 0; i < 500; i++ {
+		query := g.query()
+		a := assert.New(t).Contextf("%s", query)
+		original, err := parser.Parse(query)
+		if err != nil {
+			t.Fatalf("generated query %q failed to parse: %s", query, err.Error())
+		}
+		formatted, err := Format(query)
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		reparsed, err := parser.Parse(formatted)
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		a.EqString(describeCommand(reparsed), describeCommand(original))
+		again, err := Format(formatted)
+		a.CheckError(err)
+		a.EqString(again, formatted)
+		for _, line := range strings.Split(formatted, "\n") {
+			if strings.TrimSpace(line) != line && !strings.HasPrefix(line, indentation) {
+				t.Errorf("unexpected whitespace in line %q of %q", line, formatted)
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenWord        tokenKind = iota // identifiers, including `quoted` identifiers and $variables
+	tokenKeyword                      // reserved keywords, such as "select" or "where"
+	tokenNumber                       // numbers, durations and relative timestamps
+	tokenString                       // string literals, as written
+	tokenAnnotation                   // annotations, including their braces
+	tokenPunctuation                  // operators, parentheses, brackets and commas
+)
+
+// keywords are the reserved words of the query language.
+var keywords = map[string]bool{
+	"all":        true,
+	"and":        true,
+	"as":         true,
+	"by":         true,
+	"describe":   true,
+	"group":      true,
+	"collapse":   true,
+	"in":         true,
+	"match":      true,
+	"not":        true,
+	"or":         true,
+	"select":     true,
+	"where":      true,
+	"metrics":    true,
+	"from":       true,
+	"to":         true,
+	"resolution": true,
+	"sample":     true,
+}
+
+// comment is a `-- trailing` or `/* block */` comment, as written.
+type comment struct {
+	text string
+}
+
+func (c comment) isLine() bool {
+	return strings.HasPrefix(c.text, "--")
+}
+
+type token struct {
+	kind tokenKind
+	text string
+	// leading comments appear on their own lines before the token.
+	leading []comment
+	// trailing comments appear on the same line after the token.
+	trailing []comment
+}
+
+// is reports whether the token is the given keyword or punctuation.
+func (t token) is(text string) bool {
+	switch t.kind {
+	case tokenKeyword, tokenPunctuation:
+		return t.text == text
+	case tokenWord:
+		return strings.ToLower(t.text) == text
+	}
+	return false
+}
+
+// endsOperand reports whether the token can be the last token of an operand,
+// in which case a following "-" is subtraction rather than a negative sign.
+func (t token) endsOperand() bool {
+	switch t.kind {
+	case tokenWord, tokenNumber, tokenString, tokenAnnotation:
+		return true
+	case tokenPunctuation:
+		return t.text == ")" || t.text == "]"
+	}
+	return false
+}
+
+// lex splits the query into tokens, attaching each comment to the token it
+// follows on the same line, or else to the token after it. Comments after the
+// last token are returned separately.
+func lex(query string) ([]token, []comment, error) {
+	tokens := []token{}
+	pending := []comment{}
+	newline := true // whether a newline separates the next comment from the previous token
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		start := i
+		switch {
+		case c == '\n':
+			newline = true
+			i++
+			continue
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+			continue
+		case c == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case c == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/') {
+				i++
+			}
+			if i+1 >= len(runes) {
+				return nil, nil, fmt.Errorf("unterminated comment at offset %d", start)
+			}
+			i += 2
+		}
+		if i != start {
+			text := comment{strings.TrimRight(string(runes[start:i]), " \t\r")}
+			if !newline && len(tokens) != 0 {
+				tokens[len(tokens)-1].trailing = append(tokens[len(tokens)-1].trailing, text)
+			} else {
+				pending = append(pending, text)
+			}
+			continue
+		}
+
+		kind := tokenPunctuation
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			kind = tokenString
+			if c == '`' {
+				kind = tokenWord
+			}
+			i++
+			for i < len(runes) && runes[i] != c {
+				if runes[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return nil, nil, fmt.Errorf("unterminated literal at offset %d", start)
+			}
+			i++
+		case c == '{':
+			kind = tokenAnnotation
+			for i < len(runes) && runes[i] != '}' {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, nil, fmt.Errorf("unterminated annotation at offset %d", start)
+			}
+			i++
+		case isDigit(c) || (c == '-' && i+1 < len(runes) && isDigit(runes[i+1]) && (len(tokens) == 0 || !tokens[len(tokens)-1].endsOperand())):
+			kind = tokenNumber
+			i++
+			for i < len(runes) && (isIdentifierRune(runes[i]) || runes[i] == '.' || ((runes[i] == '+' || runes[i] == '-') && (runes[i-1] == 'e' || runes[i-1] == 'E') && i+1 < len(runes) && isDigit(runes[i+1]))) {
+				i++
+			}
+		case c == '$':
+			kind = tokenWord
+			i++
+			for i < len(runes) && isIdentifierRune(runes[i]) {
+				i++
+			}
+		case isIdentifierRune(c):
+			kind = tokenWord
+			for i < len(runes) && (isIdentifierRune(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			word := strings.ToLower(string(runes[start:i]))
+			if keywords[word] || (word == "explain" && len(tokens) == 0) {
+				kind = tokenKeyword
+			}
+		case c == '!' && i+1 < len(runes) && runes[i+1] == '=':
+			i += 2
+		case strings.ContainsRune("()[],|+-*/=", c):
+			i++
+		default:
+			return nil, nil, fmt.Errorf("unexpected character %q at offset %d", c, start)
+		}
+		text := string(runes[start:i])
+		if kind == tokenKeyword {
+			text = strings.ToLower(text)
+		}
+		tokens = append(tokens, token{kind: kind, text: text, leading: pending})
+		pending = nil
+		newline = false
+	}
+	return tokens, pending, nil
+}
+
+func isDigit(c rune) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentifierRune(c rune) bool {
+	return c == '_' || isDigit(c) || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,369 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package macro defines functions in MQE itself, such as
+//
+//	define error_rate(svc) = transform.rate(errors[service = $svc]) / transform.rate(requests[service = $svc])
+//
+// The body refers to each parameter with a "$", as in $svc; a bare svc would be
+// the metric named svc. The body is parsed once when the macro is registered,
+// and each call is expanded by substituting its arguments for the parameters.
+package macro
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/log"
+	"github.com/square/metrics/query/expression"
+	"github.com/square/metrics/query/parser"
+	"github.com/square/metrics/query/predicate"
+	"gopkg.in/yaml.v2"
+)
+
+// A Macro is a function defined by an expression of its parameters.
+type Macro struct {
+	Name        string
+	Parameters  []string // the names of the parameters, without "$"
+	Body        string   // the expression, which refers to the parameters as $variables
+	Description string
+	body        function.Expression // the parsed body, with placeholders for the parameters (set by Register)
+}
+
+var definitionPattern = regexp.MustCompile(`(?s)^\s*define\s+([a-zA-Z_][a-zA-Z0-9_.]*)\s*\(([^)]*)\)\s*=(.*)$`)
+var parameterPattern = regexp.MustCompile(`^\$?([a-zA-Z_][a-zA-Z0-9_]*)$`)
+
+// Parse parses a definition of the form "define name(a, b) = body".
+func Parse(definition string) (Macro, error) {
+	match := definitionPattern.FindStringSubmatch(definition)
+	if match == nil {
+		return Macro{}, fmt.Errorf("expected a definition of the form 'define name(parameters) = expression' but got %q", definition)
+	}
+	macro := Macro{Name: match[1], Parameters: []string{}, Body: strings.TrimSpace(match[3])}
+	if strings.TrimSpace(match[2]) != "" {
+		seen := map[string]bool{}
+		for _, parameter := range strings.Split(match[2], ",") {
+			name := parameterPattern.FindStringSubmatch(strings.TrimSpace(parameter))
+			if name == nil {
+				return Macro{}, fmt.Errorf("macro %s has invalid parameter %q", macro.Name, strings.TrimSpace(parameter))
+			}
+			if seen[name[1]] {
+				return Macro{}, fmt.Errorf("macro %s has duplicate parameter %s", macro.Name, name[1])
+			}
+			seen[name[1]] = true
+			macro.Parameters = append(macro.Parameters, name[1])
+		}
+	}
+	if macro.Body == "" {
+		return Macro{}, fmt.Errorf("macro %s has an empty body", macro.Name)
+	}
+	return macro, nil
+}
+
+// parse parses the body of the macro, leaving placeholders for its parameters,
+// and checks that the functions it invokes are in the registry.
+func (m Macro) parse(registry function.Registry) (Macro, error) {
+	variables := map[string]parser.Variable{}
+	for _, parameter := range m.Parameters {
+		variables[parameter] = parser.Variable{Kind: parser.ParameterVariable}
+	}
+	body, err := parser.ParseExpression(m.Body, parser.Options{Registry: registry, Variables: variables})
+	if err != nil {
+		return Macro{}, fmt.Errorf("macro %s is invalid: %s", m.Name, err.Error())
+	}
+	m.body = body
+	return m, nil
+}
+
+// Expand substitutes the arguments for the parameters in the parsed body of the
+// macro. Arguments used in predicates must be literal strings.
+func (m Macro) Expand(arguments []function.Expression) (function.Expression, error) {
+	if len(arguments) != len(m.Parameters) {
+		return nil, function.ArgumentLengthError{Name: m.Name, ExpectedMin: len(m.Parameters), ExpectedMax: len(m.Parameters), Actual: len(arguments)}
+	}
+	if m.body == nil {
+		return nil, fmt.Errorf("macro %s has not been registered", m.Name)
+	}
+	values := map[string]function.Expression{}
+	for i, parameter := range m.Parameters {
+		values[parameter] = arguments[i]
+	}
+	body, err := substitute(m.body, values)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding macro %s: %s", m.Name, err.Error())
+	}
+	return body, nil
+}
+
+// substitute replaces the parameters in the expression with their arguments.
+func substitute(e function.Expression, arguments map[string]function.Expression) (function.Expression, error) {
+	if annotation, ok := e.(*expression.AnnotationExpression); ok {
+		content, err := substitute(annotation.Expression, arguments)
+		if err != nil {
+			return nil, err
+		}
+		return &expression.AnnotationExpression{Expression: content, Annotation: annotation.Annotation}, nil
+	}
+	if parameter, ok := e.(expression.Parameter); ok {
+		return arguments[parameter.Name], nil
+	}
+	actual, _ := function.Unmemoize(e)
+	switch actual := actual.(type) {
+	case *expression.FunctionExpression:
+		invocation := *actual
+		invocation.Arguments = make([]function.Expression, len(actual.Arguments))
+		for i, argument := range actual.Arguments {
+			substituted, err := substitute(argument, arguments)
+			if err != nil {
+				return nil, err
+			}
+			invocation.Arguments[i] = substituted
+		}
+		return function.Memoize(&invocation), nil
+	case *expression.MetricFetchExpression:
+		substituted, err := substitutePredicate(actual.Predicate, arguments)
+		if err != nil {
+			return nil, err
+		}
+		return function.Memoize(&expression.MetricFetchExpression{MetricName: actual.MetricName, Predicate: substituted}), nil
+	}
+	return e, nil
+}
+
+// substitutePredicate replaces the parameters in the predicate with their
+// arguments, which must be literal strings.
+func substitutePredicate(p predicate.Predicate, arguments map[string]function.Expression) (predicate.Predicate, error) {
+	switch p := p.(type) {
+	case predicate.AndPredicate:
+		predicates, err := substitutePredicates(p.Predicates, arguments)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.AndPredicate{Predicates: predicates}, nil
+	case predicate.OrPredicate:
+		predicates, err := substitutePredicates(p.Predicates, arguments)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.OrPredicate{Predicates: predicates}, nil
+	case predicate.NotPredicate:
+		substituted, err := substitutePredicate(p.Predicate, arguments)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.NotPredicate{Predicate: substituted}, nil
+	case predicate.ParameterMatcher:
+		argument := arguments[p.Parameter]
+		actual, _ := function.Unmemoize(argument)
+		literal, ok := actual.(expression.String)
+		if !ok {
+			return nil, fmt.Errorf("$%s is used in a predicate, so it must be a string literal but got %s", p.Parameter, argument.ExpressionString(function.StringQuery))
+		}
+		if !p.Regex {
+			return predicate.ListMatcher{Tag: p.Tag, Values: []string{literal.Value}}, nil
+		}
+		compiled, err := regexp.Compile(literal.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse the regex given for $%s: %s", p.Parameter, err.Error())
+		}
+		return predicate.RegexMatcher{Tag: p.Tag, Regex: compiled}, nil
+	}
+	return p, nil
+}
+
+func substitutePredicates(predicates []predicate.Predicate, arguments map[string]function.Expression) ([]predicate.Predicate, error) {
+	result := make([]predicate.Predicate, len(predicates))
+	for i := range predicates {
+		substituted, err := substitutePredicate(predicates[i], arguments)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = substituted
+	}
+	return result, nil
+}
+
+// Function makes the macro into a function which expands and evaluates it.
+func (m Macro) Function() function.MetricFunction {
+	parameters := make([]function.Parameter, len(m.Parameters))
+	for i := range parameters {
+		parameters[i] = function.Parameter{Kind: "expression"}
+	}
+	description := m.Description
+	if description == "" {
+		description = fmt.Sprintf("Defined as %s.", m.Body)
+	}
+	return function.MetricFunction{
+		FunctionName: m.Name,
+		MinArguments: len(m.Parameters),
+		MaxArguments: len(m.Parameters),
+		Compute: func(context function.EvaluationContext, arguments []function.Expression, groups function.Groups) (function.Value, error) {
+			body, err := m.Expand(arguments)
+			if err != nil {
+				return nil, err
+			}
+			return body.Evaluate(context)
+		},
+		Signature:   &function.Signature{Parameters: parameters, Returns: "value"},
+		Description: description,
+	}
+}
+
+// A Registry is a function registry which macros can be added to, such as a
+// registry.StandardRegistry or registry.Overlay.
+type Registry interface {
+	function.Registry
+	RegisterAll([]function.Function) error
+}
+
+// Register parses the bodies of the macros, checks that they only call existing
+// functions or each other and are not recursive, and then adds them to the
+// registry. If any macro is invalid, none are added.
+func Register(registry Registry, macros []Macro) error {
+	functions := map[string]function.Function{}
+	for _, macro := range macros {
+		if functions[macro.Name] != nil {
+			return fmt.Errorf("macro %s is defined more than once", macro.Name)
+		}
+		functions[macro.Name] = macro.Function() // only used to check the names of the functions invoked by the bodies
+	}
+	combined := combinedRegistry{registry, functions}
+	ordered := make([]function.Function, len(macros))
+	calls := map[string][]string{}
+	for i, macro := range macros {
+		parsed, err := macro.parse(combined)
+		if err != nil {
+			return err
+		}
+		for _, name := range invokedFunctions(parsed.body) {
+			if functions[name] != nil {
+				calls[macro.Name] = append(calls[macro.Name], name)
+			}
+		}
+		ordered[i] = parsed.Function()
+	}
+	for _, macro := range macros {
+		if cycle := findCycle(macro.Name, calls, []string{}); cycle != nil {
+			return fmt.Errorf("macro %s is recursive: %s", macro.Name, strings.Join(cycle, " -> "))
+		}
+	}
+	return registry.RegisterAll(ordered)
+}
+
+// invokedFunctions lists the names of the functions invoked by the expression.
+func invokedFunctions(e function.Expression) []string {
+	if annotation, ok := e.(*expression.AnnotationExpression); ok {
+		return invokedFunctions(annotation.Expression)
+	}
+	actual, _ := function.Unmemoize(e)
+	invocation, ok := actual.(*expression.FunctionExpression)
+	if !ok {
+		return nil
+	}
+	names := []string{invocation.FunctionName}
+	for _, argument := range invocation.Arguments {
+		names = append(names, invokedFunctions(argument)...)
+	}
+	return names
+}
+
+// findCycle finds a sequence of calls from the end of the path which returns
+// to the macro where the path began, if there is one.
+func findCycle(name string, calls map[string][]string, path []string) []string {
+	path = append(path, name)
+	if len(path) > 1 && name == path[0] {
+		return path
+	}
+	for _, visited := range path[:len(path)-1] {
+		if visited == name {
+			return nil // a cycle which does not include the first macro
+		}
+	}
+	for _, called := range calls[name] {
+		if cycle := findCycle(called, calls, path); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// combinedRegistry looks up functions in the registry and the macros which are
+// being added to it.
+type combinedRegistry struct {
+	registry Registry
+	macros   map[string]function.Function
+}
+
+func (r combinedRegistry) GetFunction(name string) (function.Function, bool) {
+	if fun, ok := r.macros[name]; ok {
+		return fun, true
+	}
+	return r.registry.GetFunction(name)
+}
+
+func (r combinedRegistry) All() []string {
+	names := r.registry.All()
+	for name := range r.macros {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// definitionFile is the contents of a YAML file of macros.
+type definitionFile struct {
+	Macros []struct {
+		Definition  string `yaml:"definition"`
+		Description string `yaml:"description"`
+	} `yaml:"macros"`
+}
+
+// Load parses the macros defined in the YAML files in the directory, such as
+//
+//	macros:
+//	- definition: define doubled(x) = $x * 2
+//	  description: Twice each point.
+func Load(directory string) ([]Macro, error) {
+	filenames, err := filepath.Glob(filepath.Join(directory, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(filenames)
+	macros := []Macro{}
+	for _, filename := range filenames {
+		log.Infof("Loading macros from %s", filename)
+		bytes, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("error reading file %s: %s", filename, err.Error())
+		}
+		file := definitionFile{}
+		if err := yaml.Unmarshal(bytes, &file); err != nil {
+			return nil, fmt.Errorf("error loading YAML from file %s: %s", filename, err.Error())
+		}
+		for _, definition := range file.Macros {
+			macro, err := Parse(definition.Definition)
+			if err != nil {
+				return nil, fmt.Errorf("error in file %s: %s", filename, err.Error())
+			}
+			macro.Description = definition.Description
+			macros = append(macros, macro)
+		}
+	}
+	return macros, nil
+}
@@ -0,0 +1,303 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package macro
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/function/registry"
+	"github.com/square/metrics/query/command"
+	"github.com/square/metrics/query/expression"
+	"github.com/square/metrics/query/parser"
+	"github.com/square/metrics/query/predicate"
+	"github.com/square/metrics/testing_support/assert"
+	"github.com/square/metrics/testing_support/mocks"
+
+	"golang.org/x/net/context"
+)
+
+func mustParse(t *testing.T, definitions ...string) []Macro {
+	macros := make([]Macro, len(definitions))
+	for i, definition := range definitions {
+		macro, err := Parse(definition)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %s", definition, err.Error())
+		}
+		macros[i] = macro
+	}
+	return macros
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		definition string
+		expected   Macro
+	}{
+		{"define error_ratio(svc) = errors[service = $svc] / requests[service = $svc]", Macro{Name: "error_ratio", Parameters: []string{"svc"}, Body: "errors[service = $svc] / requests[service = $svc]"}},
+		{"  define team.ratio( $a,b )=\n  $a / $b\n", Macro{Name: "team.ratio", Parameters: []string{"a", "b"}, Body: "$a / $b"}},
+		{"define everything() = requests", Macro{Name: "everything", Parameters: []string{}, Body: "requests"}},
+	} {
+		a := assert.New(t).Contextf("%s", test.definition)
+		macro, err := Parse(test.definition)
+		a.CheckError(err)
+		a.Eq(macro, test.expected)
+	}
+	for _, definition := range []string{
+		"",
+		"error_ratio(svc) = errors",
+		"define error_ratio = errors",
+		"define error_ratio(svc) =",
+		"define error_ratio(svc, svc) = errors",
+		"define error_ratio(svc, ) = errors",
+		"define error_ratio(1svc) = errors",
+	} {
+		if _, err := Parse(definition); err == nil {
+			t.Errorf("expected an error parsing %q", definition)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	a := assert.New(t)
+	macro, err := mustParse(t, "define smoothed(x, window, name) = transform.moving_average($x, $window) + cpu[app = $name]")[0].parse(nil)
+	a.CheckError(err)
+	for _, test := range []struct {
+		arguments []function.Expression
+		expected  string
+	}{
+		{
+			[]function.Expression{
+				function.Memoize(expression.String{Value: "cpu"}),
+				function.Memoize(expression.Duration{Literal: "5m"}),
+				function.Memoize(expression.String{Value: "mqe"}),
+			},
+			`(transform.moving_average("cpu", 5m) + cpu[app = "mqe"])`,
+		},
+		{
+			[]function.Expression{
+				function.Memoize(&expression.MetricFetchExpression{MetricName: "memory", Predicate: predicate.All()}),
+				function.Memoize(expression.Scalar{Value: 3}),
+				function.Memoize(expression.String{Value: "mqe"}),
+			},
+			`(transform.moving_average(memory, 3) + cpu[app = "mqe"])`,
+		},
+		{
+			[]function.Expression{
+				function.Memoize(expression.String{Value: "cpu"}),
+				function.Memoize(expression.Duration{Literal: "5m"}),
+				function.Memoize(&expression.MetricFetchExpression{MetricName: "memory", Predicate: predicate.All()}),
+			},
+			"",
+		},
+	} {
+		expanded, err := macro.Expand(test.arguments)
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("expected an error expanding %s with %+v", macro.Name, test.arguments)
+			}
+			continue
+		}
+		a.CheckError(err)
+		if err == nil {
+			a.EqString(expanded.ExpressionString(function.StringQuery), test.expected)
+		}
+	}
+	if _, err := macro.Expand(nil); err == nil {
+		t.Errorf("expected an error expanding %s without arguments", macro.Name)
+	}
+
+	// Parameters in predicates are substituted by matchers of their arguments.
+	matching, err := mustParse(t, "define matching(name) = cpu[app match $name and host != $name]")[0].parse(nil)
+	a.CheckError(err)
+	expanded, err := matching.Expand([]function.Expression{function.Memoize(expression.String{Value: "m.e"})})
+	a.CheckError(err)
+	if err == nil {
+		a.EqString(expanded.ExpressionString(function.StringQuery), `cpu[(app match "m.e" and not host = "m.e")]`)
+	}
+	if _, err := matching.Expand([]function.Expression{function.Memoize(expression.String{Value: "("})}); err == nil {
+		t.Errorf("expected an error expanding %s with an invalid regex", matching.Name)
+	}
+
+	// The body is parsed when the macro is registered.
+	if _, err := mustParse(t, "define doubled(x) = $x * 2")[0].Expand([]function.Expression{function.Memoize(expression.Scalar{Value: 1})}); err == nil {
+		t.Errorf("expected an error expanding a macro which has not been registered")
+	}
+}
+
+func TestRegister_Errors(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		definitions []string
+	}{
+		{"duplicate name", []string{"define twice(x) = $x * 2", "define twice(x) = $x + $x"}},
+		{"unknown function", []string{"define twice(x) = transform.double($x)"}},
+		{"unknown parameter", []string{"define twice(x) = $y * 2"}},
+		{"syntax error", []string{"define twice(x) = $x *"}},
+		{"list parameter", []string{"define hosts(x) = cpu[host in $x]"}},
+		{"self recursion", []string{"define twice(x) = twice($x) * 2"}},
+		{"mutual recursion", []string{"define first(x) = second($x)", "define second(x) = transform.abs(third($x))", "define third(x) = first($x) | transform.abs"}},
+	} {
+		r := registry.NewOverlay(registry.Default())
+		if err := Register(r, mustParse(t, test.definitions...)); err == nil {
+			t.Errorf("%s: expected an error registering %v", test.name, test.definitions)
+		}
+		if len(r.All()) != len(registry.Default().All()) {
+			t.Errorf("%s: expected no macros to be registered", test.name)
+		}
+	}
+}
+
+func TestRegister_Shadowing(t *testing.T) {
+	a := assert.New(t)
+	shadow := mustParse(t, "define transform.abs(x) = $x")
+	if err := Register(registry.Default(), shadow); err == nil {
+		t.Errorf("expected an error registering a builtin name in the default registry")
+	}
+	r := registry.NewOverlay(registry.Default())
+	a.CheckError(Register(r, shadow))
+	fun, ok := r.GetFunction("transform.abs")
+	a.Eq(ok, true)
+	if ok {
+		a.EqString(fun.(function.DescribedFunction).Describe().Description, "Defined as $x.")
+	}
+	if err := Register(r, shadow); err == nil {
+		t.Errorf("expected an error registering a macro twice")
+	}
+	builtin, _ := registry.Default().GetFunction("transform.abs")
+	a.Eq(builtin.(function.DescribedFunction).Describe().Description != "Defined as $x.", true)
+}
+
+func TestMacros(t *testing.T) {
+	testTimerange, err := api.NewSnappedTimerange(0, 120, 30)
+	if err != nil {
+		t.Fatalf("Error creating timerange for test: %s", err.Error())
+	}
+	comboAPI := mocks.NewComboAPI(
+		testTimerange,
+		api.Timeseries{Values: []float64{1, 2, 3, 4, 5}, TagSet: api.TagSet{"metric": "errors", "service": "api"}},
+		api.Timeseries{Values: []float64{0, 0, 1, 1, 0}, TagSet: api.TagSet{"metric": "errors", "service": "web"}},
+		api.Timeseries{Values: []float64{10, 10, 10, 10, 10}, TagSet: api.TagSet{"metric": "requests", "service": "api"}},
+		api.Timeseries{Values: []float64{5, 5, 5, 5, 5}, TagSet: api.TagSet{"metric": "requests", "service": "web"}},
+	)
+	r := registry.NewOverlay(registry.Default())
+	// The macros may call each other in any order.
+	err = Register(r, mustParse(t,
+		"define error_percent(svc) = transform.abs(error_ratio($svc) * 100)",
+		"define error_ratio(svc) = errors[service = $svc] / requests[service = $svc]",
+		"define ratio(a, b) = $a / $b",
+	))
+	if err != nil {
+		t.Fatalf("unexpected error registering macros: %s", err.Error())
+	}
+	err = Register(r, mustParse(t, "define scaled_ratio(a, b, factor) = ratio($a, $b) * $factor"))
+	if err != nil {
+		t.Fatalf("unexpected error registering macros: %s", err.Error())
+	}
+	for _, test := range []struct {
+		query       string
+		expectError bool
+		expected    []api.Timeseries
+	}{
+		{"select error_ratio('api') from 0 to 120 resolution 30ms", false, []api.Timeseries{
+			{Values: []float64{0.1, 0.2, 0.3, 0.4, 0.5}, TagSet: api.TagSet{"service": "api"}},
+		}},
+		{"select ratio(errors, requests) from 0 to 120 resolution 30ms", false, []api.Timeseries{
+			{Values: []float64{0.1, 0.2, 0.3, 0.4, 0.5}, TagSet: api.TagSet{"service": "api"}},
+			{Values: []float64{0, 0, 0.2, 0.2, 0}, TagSet: api.TagSet{"service": "web"}},
+		}},
+		{"select errors | ratio(requests) where service = 'web' from 0 to 120 resolution 30ms", false, []api.Timeseries{
+			{Values: []float64{0, 0, 0.2, 0.2, 0}, TagSet: api.TagSet{"service": "web"}},
+		}},
+		{"select scaled_ratio(errors[service = 'web'], requests, 10) from 0 to 120 resolution 30ms", false, []api.Timeseries{
+			{Values: []float64{0, 0, 2, 2, 0}, TagSet: api.TagSet{"service": "web"}},
+		}},
+		{"select error_ratio(errors) from 0 to 120 resolution 30ms", true, nil},
+		{"select error_percent('web') from 0 to 120 resolution 30ms", false, []api.Timeseries{
+			{Values: []float64{0, 0, 20, 20, 0}, TagSet: api.TagSet{"service": "web"}},
+		}},
+		{"select ratio(errors) from 0 to 120 resolution 30ms", true, nil},
+		{"select error_ratio('api' group by service) from 0 to 120 resolution 30ms", true, nil},
+	} {
+		a := assert.New(t).Contextf("%s", test.query)
+		testCommand, err := parser.Parse(test.query)
+		if err != nil {
+			a.Errorf("Unexpected error while parsing: %s", err.Error())
+			continue
+		}
+		result, err := testCommand.Execute(command.ExecutionContext{
+			TimeseriesStorageAPI: comboAPI,
+			MetricMetadataAPI:    comboAPI,
+			FetchLimit:           1000,
+			Registry:             r,
+			Ctx:                  context.Background(),
+		})
+		if test.expectError {
+			if err == nil {
+				a.Errorf("expected failure but got %+v", result.Body)
+			}
+			continue
+		}
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		series := result.Body.([]command.QueryResult)[0].Series
+		a.EqInt(len(series), len(test.expected))
+		if len(series) != len(test.expected) {
+			continue
+		}
+		for i := range series {
+			a.EqFloatArray(series[i].Values, test.expected[i].Values, 1e-7)
+			a.Eq(series[i].TagSet, test.expected[i].TagSet)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	a := assert.New(t)
+	directory, err := ioutil.TempDir("", "macros")
+	if err != nil {
+		t.Fatalf("error creating directory: %s", err.Error())
+	}
+	defer os.RemoveAll(directory)
+	files := map[string]string{
+		"b.yaml": "macros:\n- definition: define ratio(a, b) = $a / $b\n",
+		"a.yaml": "macros:\n- definition: define twice(x) = $x * 2\n  description: Twice each point.\n",
+		"c.txt":  "ignored",
+	}
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(directory, name), []byte(contents), 0644); err != nil {
+			t.Fatalf("error writing file: %s", err.Error())
+		}
+	}
+	macros, err := Load(directory)
+	a.CheckError(err)
+	a.Eq(macros, []Macro{
+		{Name: "twice", Parameters: []string{"x"}, Body: "$x * 2", Description: "Twice each point."},
+		{Name: "ratio", Parameters: []string{"a", "b"}, Body: "$a / $b"},
+	})
+
+	if err := ioutil.WriteFile(filepath.Join(directory, "d.yaml"), []byte("macros:\n- definition: twice(x) = $x * 2\n"), 0644); err != nil {
+		t.Fatalf("error writing file: %s", err.Error())
+	}
+	if _, err := Load(directory); err == nil {
+		t.Errorf("expected an error loading an invalid definition")
+	}
+}
@@ -14,7 +14,14 @@
 
 package parser
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/testing_support/assert"
+)
 
 func TestErrorMessages(t *testing.T) {
 	type sample struct {
@@ -44,11 +51,11 @@ func TestErrorMessages(t *testing.T) {
 		},
 		{
 			query:   "select crazy#2dinvalid.metric + bar\nwhere tag != 'value' and qux = 'qux'\nfrom -30m to now",
-			message: `line 1, column 13: expected key (one of 'from', 'to', 'resolution', or 'sample by') or end of input but got "#2dinvalid.metric + bar\nwhere tag != 'value' and qux = 'qux'\nfrom -30m to now" following a completed expression`,
+			message: `line 1, column 13: expected key (one of 'from', 'to', 'resolution', 'timezone', or 'sample by') or end of input but got "#2dinvalid.metric + bar\nwhere tag != 'value' and qux = 'qux'\nfrom -30m to now" following a completed expression`,
 		},
 		{
 			query:   "serlect foo from -30m to now",
-			message: `line 1, column 9: expected key (one of 'from', 'to', 'resolution', or 'sample by') or end of input but got "foo from -30m to now" following a completed expression`,
+			message: `line 1, column 9: expected key (one of 'from', 'to', 'resolution', 'timezone', or 'sample by') or end of input but got "foo from -30m to now" following a completed expression`,
 		},
 		{
 			query:   "describe all where host = 'foo'",
@@ -87,3 +94,238 @@ func TestErrorMessages(t *testing.T) {
 		}
 	}
 }
+
+func TestSyntaxErrorDetails(t *testing.T) {
+	tests := []struct {
+		query      string
+		token      string
+		start      Position
+		end        Position
+		expected   []string
+		suggestion string
+	}{
+		{
+			query:    "select foo from",
+			start:    Position{Offset: 15, Line: 1, Column: 16},
+			end:      Position{Offset: 15, Line: 1, Column: 16},
+			expected: []string{"value"},
+		},
+		{
+			query:    "select foo\nfrom -30m to mow",
+			token:    "mow",
+			start:    Position{Offset: 24, Line: 2, Column: 14},
+			end:      Position{Offset: 27, Line: 2, Column: 17},
+			expected: []string{"value"},
+		},
+		{
+			query:    "select foo[host ~ 'x'] from -30m to now",
+			token:    "~",
+			start:    Position{Offset: 16, Line: 1, Column: 17},
+			end:      Position{Offset: 17, Line: 1, Column: 18},
+			expected: []string{`"="`, `"!="`, `"match"`, `"in"`},
+		},
+		{
+			query:      "select foo form -30m to now",
+			token:      "form",
+			start:      Position{Offset: 11, Line: 1, Column: 12},
+			end:        Position{Offset: 15, Line: 1, Column: 16},
+			expected:   []string{"'from'", "'to'", "'resolution'", "'timezone'", "'sample by'", "end of input"},
+			suggestion: "from",
+		},
+		{
+			query:      "select foo from -30m to now resolutoin 1m",
+			token:      "resolutoin",
+			start:      Position{Offset: 28, Line: 1, Column: 29},
+			end:        Position{Offset: 38, Line: 1, Column: 39},
+			expected:   []string{"'from'", "'to'", "'resolution'", "'timezone'", "'sample by'", "end of input"},
+			suggestion: "resolution",
+		},
+		{
+			query:    "select 'é' + (foo from -30m to now",
+			token:    "from",
+			start:    Position{Offset: 19, Line: 1, Column: 19},
+			end:      Position{Offset: 23, Line: 1, Column: 23},
+			expected: []string{`")"`},
+		},
+		{
+			query:    "select foo {bar from 0 to 0",
+			start:    Position{Offset: 27, Line: 1, Column: 28},
+			end:      Position{Offset: 27, Line: 1, Column: 28},
+			expected: []string{`"}"`},
+		},
+		{
+			query: "select foo from 0 to 0 where x = 'y'",
+			token: "x",
+			start: Position{Offset: 29, Line: 1, Column: 30},
+			end:   Position{Offset: 30, Line: 1, Column: 31},
+		},
+	}
+	for _, test := range tests {
+		a := assert.New(t).Contextf("%s", test.query)
+		_, err := Parse(test.query)
+		syntaxErrors, ok := err.(SyntaxErrors)
+		if !ok || len(syntaxErrors) != 1 {
+			t.Errorf("expected a single syntax error for query %q but got %+v", test.query, err)
+			continue
+		}
+		syntaxError := syntaxErrors[0]
+		a.EqString(syntaxError.Token(), test.token)
+		span, ok := syntaxError.Span()
+		a.Eq(ok, true)
+		a.Eq(span, Span{Start: test.start, End: test.end})
+		a.Eq(syntaxError.Expected(), test.expected)
+		a.EqString(syntaxError.Suggestion(), test.suggestion)
+	}
+}
+
+// fakeRegistry is a registry with functions of the given names.
+type fakeRegistry []string
+
+func (r fakeRegistry) GetFunction(name string) (function.Function, bool) {
+	for _, registered := range r {
+		if registered == name {
+			return function.MetricFunction{FunctionName: name}, true
+		}
+	}
+	return nil, false
+}
+
+func (r fakeRegistry) All() []string {
+	return r
+}
+
+func TestParseWithOptions_Registry(t *testing.T) {
+	a := assert.New(t)
+	registry := fakeRegistry{"+", "transform.abs", "transform.moving_average", "aggregate.sum"}
+
+	_, err := ParseWithOptions("select transform.abs(x) + x | aggregate.sum from 0 to 0", Options{Registry: registry})
+	a.CheckError(err)
+
+	_, err = ParseWithOptions("select transform.moving_avg(x, 5m) | agregate.sum | unknown from 0 to 0", Options{Registry: registry})
+	syntaxErrors, ok := err.(SyntaxErrors)
+	if !ok {
+		t.Fatalf("expected SyntaxErrors but got %+v", err)
+	}
+	a.EqInt(len(syntaxErrors), 3)
+	messages := []string{}
+	suggestions := []string{}
+	for _, syntaxError := range syntaxErrors {
+		messages = append(messages, syntaxError.Error())
+		suggestions = append(suggestions, syntaxError.Suggestion())
+	}
+	a.Eq(messages, []string{
+		"line 1, column 8: no such function transform.moving_avg; did you mean transform.moving_average?",
+		"line 1, column 38: no such function agregate.sum; did you mean aggregate.sum?",
+		"line 1, column 53: no such function unknown",
+	})
+	a.Eq(suggestions, []string{"transform.moving_average", "aggregate.sum", ""})
+	span, _ := syntaxErrors[1].Span()
+	a.Eq(span, Span{
+		Start: Position{Offset: 37, Line: 1, Column: 38},
+		End:   Position{Offset: 49, Line: 1, Column: 50},
+	})
+
+	// Without a registry, functions are not checked.
+	_, err = Parse("select agregate.sum(x) from 0 to 0")
+	a.CheckError(err)
+}
+
+func TestSyntaxError_MarshalJSON(t *testing.T) {
+	a := assert.New(t)
+	_, err := Parse("select foo form -30m to now")
+	encoded, jsonErr := json.Marshal(err)
+	a.CheckError(jsonErr)
+	decoded := []map[string]interface{}{}
+	a.CheckError(json.Unmarshal(encoded, &decoded))
+	if len(decoded) != 1 {
+		t.Fatalf("expected a single encoded error but got %s", encoded)
+	}
+	a.EqString(decoded[0]["token"].(string), "form")
+	a.EqString(decoded[0]["suggestion"].(string), "from")
+	a.Eq(decoded[0]["span"], map[string]interface{}{
+		"start": map[string]interface{}{"offset": 11.0, "line": 1.0, "column": 12.0},
+		"end":   map[string]interface{}{"offset": 15.0, "line": 1.0, "column": 16.0},
+	})
+}
+
+func TestParsePredicate(t *testing.T) {
+	a := assert.New(t)
+	parsed, err := ParsePredicate("dc = 'west' and not host match '^canary'")
+	a.CheckError(err)
+	if err == nil {
+		a.Eq(parsed.Apply(api.TagSet{"dc": "west", "host": "web1"}), true)
+		a.Eq(parsed.Apply(api.TagSet{"dc": "west", "host": "canary1"}), false)
+		a.Eq(parsed.Apply(api.TagSet{"dc": "east", "host": "web1"}), false)
+	}
+
+	// Positions refer to the predicate alone.
+	_, err = ParsePredicate("dc = ")
+	syntaxErrors, ok := err.(SyntaxErrors)
+	if !ok || len(syntaxErrors) != 1 {
+		t.Fatalf("expected a single syntax error but got %+v", err)
+	}
+	a.EqString(syntaxErrors[0].Error(), `line 1, column 5: expected string literal to follow "="`)
+	span, ok := syntaxErrors[0].Span()
+	a.Eq(ok, true)
+	a.EqInt(span.Start.Offset, 5)
+	a.EqInt(span.Start.Column, 6)
+
+	// The predicate must be followed only by spaces.
+	_, err = ParsePredicate("  dc = 'west'  ")
+	a.CheckError(err)
+	for _, text := range []string{"dc = 'west' from 0", "dc = 'west')", "dc = 'west' and"} {
+		if _, err := ParsePredicate(text); err == nil {
+			t.Errorf("expected an error parsing %q", text)
+		}
+	}
+}
+
+func TestParseExpression(t *testing.T) {
+	a := assert.New(t)
+	argument, err := ParseExpression("cpu[app = 'mqe'] / 2", Options{})
+	a.CheckError(err)
+	if err != nil {
+		return
+	}
+	a.EqString(argument.ExpressionString(function.StringQuery), `(cpu[app = "mqe"] / 2)`)
+
+	// Expression variables are substituted as written, but only for expressions.
+	variables := map[string]Variable{
+		"load": {Kind: ExpressionVariable, Expression: argument},
+		"app":  {Kind: StringVariable, Value: "web"},
+	}
+	parsed, err := ParseExpression("transform.abs($load) + memory[app = $app]", Options{Variables: variables})
+	a.CheckError(err)
+	if err == nil {
+		a.EqString(parsed.ExpressionString(function.StringQuery), `(transform.abs((cpu[app = "mqe"] / 2)) + memory[app = "web"])`)
+	}
+	_, err = ParseExpression("memory[app = $load]", Options{Variables: variables})
+	if err == nil {
+		t.Errorf("expected an expression variable in a predicate to be rejected")
+	}
+
+	// Parameters are left in place, so that they can be substituted later.
+	parameters := map[string]Variable{
+		"load": {Kind: ParameterVariable},
+		"app":  {Kind: ParameterVariable},
+	}
+	parsed, err = ParseExpression("transform.abs($load) + memory[app = $app and host != $app and zone match $app]", Options{Variables: parameters})
+	a.CheckError(err)
+	if err == nil {
+		a.EqString(parsed.ExpressionString(function.StringQuery), `(transform.abs($load) + memory[(app = $app and (not host = $app and zone match $app))])`)
+	}
+
+	for _, text := range []string{"", "cpu, memory", "cpu where app = 'mqe'", "cpu from 0 to 0"} {
+		if _, err := ParseExpression(text, Options{}); err == nil {
+			t.Errorf("expected an error parsing %q as an expression", text)
+		}
+	}
+
+	// Positions refer to the expression alone.
+	_, err = ParseExpression("cpu[app = ]", Options{})
+	syntaxErrors, ok := err.(SyntaxErrors)
+	if !ok || len(syntaxErrors) != 1 {
+		t.Fatalf("expected a single syntax error but got %+v", err)
+	}
+	a.EqString(syntaxErrors[0].Error(), `line 1, column 10: expected string literal to follow "="`)
+}
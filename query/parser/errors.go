@@ -15,6 +15,8 @@
 package parser
 
 import (
+	"encoding/json"
+	"sort"
 	"strings"
 )
 
@@ -23,8 +25,24 @@ import (
 // * The query does not generate a valid AST.
 // * Invalid input is provided
 type SyntaxError struct {
-	token   string
-	message string
+	token      string
+	message    string
+	span       *Span    // where the error occurred, if known
+	expected   []string // what was expected instead, if known
+	suggestion string   // a replacement for the token, if one is similar enough
+}
+
+// Position is a location in a query.
+type Position struct {
+	Offset int `json:"offset"` // in bytes, from the start of the query
+	Line   int `json:"line"`   // starting from 1
+	Column int `json:"column"` // starting from 1, with tabs expanded to 4 columns
+}
+
+// Span is the part of a query from Start (inclusive) to End (exclusive).
+type Span struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
 }
 
 // AssertionError is raised when an internal invariant is violated,
@@ -42,10 +60,48 @@ func (err SyntaxError) Token() string {
 	return err.token
 }
 
+// Span returns the part of the query where the error occurred, if it is known.
+func (err SyntaxError) Span() (Span, bool) {
+	if err.span == nil {
+		return Span{}, false
+	}
+	return *err.span, true
+}
+
+// Expected returns the tokens or constructs which were expected where the
+// error occurred, if they are known.
+func (err SyntaxError) Expected() []string {
+	return err.expected
+}
+
+// Suggestion returns a replacement for the token which would likely fix the
+// error, or "" if there is none.
+func (err SyntaxError) Suggestion() string {
+	return err.suggestion
+}
+
 func (err SyntaxError) Error() string {
 	return err.message
 }
 
+// MarshalJSON encodes the error with its position, so that it can be
+// displayed alongside the query.
+func (err SyntaxError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Message    string   `json:"message"`
+		Token      string   `json:"token,omitempty"`
+		Span       *Span    `json:"span,omitempty"`
+		Expected   []string `json:"expected,omitempty"`
+		Suggestion string   `json:"suggestion,omitempty"`
+	}{
+		Message:    err.message,
+		Token:      err.token,
+		Span:       err.span,
+		Expected:   err.expected,
+		Suggestion: err.suggestion,
+	})
+}
+
 // SyntaxErrors is a slice of SyntaxErrors implementing Error() method.
 type SyntaxErrors []SyntaxError
 
@@ -58,3 +114,54 @@ func (errors SyntaxErrors) Error() string {
 }
 
 var _ error = (*SyntaxError)(nil)
+
+// suggest returns the candidate most similar to the given word, provided that
+// it is similar enough to likely be what was meant. Otherwise, it returns "".
+func suggest(word string, candidates []string) string {
+	if word == "" {
+		return ""
+	}
+	sorted := append([]string{}, candidates...)
+	sort.Strings(sorted) // ties are broken alphabetically
+	best := ""
+	bestDistance := (len(word) + 2) / 3 // up to one edit for every three characters
+	for _, candidate := range sorted {
+		if candidate == word {
+			continue
+		}
+		distance := editDistance(strings.ToLower(word), strings.ToLower(candidate))
+		if distance <= bestDistance && (best == "" || distance < bestDistance) {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	return best
+}
+
+// editDistance counts the insertions, deletions, substitutions and
+// transpositions of adjacent characters needed to turn one string into the
+// other.
+func editDistance(left string, right string) int {
+	a, b := []rune(left), []rune(right)
+	distances := make([][]int, len(a)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(b)+1)
+		distances[i][0] = i
+	}
+	for j := range distances[0] {
+		distances[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			distances[i][j] = min(min(distances[i-1][j]+1, distances[i][j-1]+1), distances[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				distances[i][j] = min(distances[i][j], distances[i-2][j-2]+1)
+			}
+		}
+	}
+	return distances[len(a)][len(b)]
+}
@@ -17,12 +17,14 @@ type pegRule uint8
 const (
 	ruleUnknown pegRule = iota
 	ruleroot
+	ruleexplainStmt
 	ruleselectStmt
 	ruledescribeStmt
 	ruledescribeAllStmt
 	ruleoptionalMatchClause
 	rulematchClause
 	ruledescribeMetrics
+	ruledescribeFunctions
 	ruledescribeSingleStmt
 	rulepropertyClause
 	ruleoptionalPredicateClause
@@ -80,6 +82,7 @@ const (
 	ruleNUMBER_INTEGER
 	ruleNUMBER_EXP
 	ruleDURATION
+	ruleVARIABLE
 	rulePAREN_OPEN
 	rulePAREN_CLOSE
 	ruleCOMMA
@@ -93,9 +96,9 @@ const (
 	ruleAction2
 	ruleAction3
 	ruleAction4
-	rulePegText
 	ruleAction5
 	ruleAction6
+	rulePegText
 	ruleAction7
 	ruleAction8
 	ruleAction9
@@ -143,6 +146,12 @@ const (
 	ruleAction51
 	ruleAction52
 	ruleAction53
+	ruleAction54
+	ruleAction55
+	ruleAction56
+	ruleAction57
+	ruleAction58
+	ruleAction59
 
 	rulePre
 	ruleIn
@@ -152,12 +161,14 @@ const (
 var rul3s = [...]string{
 	"Unknown",
 	"root",
+	"explainStmt",
 	"selectStmt",
 	"describeStmt",
 	"describeAllStmt",
 	"optionalMatchClause",
 	"matchClause",
 	"describeMetrics",
+	"describeFunctions",
 	"describeSingleStmt",
 	"propertyClause",
 	"optionalPredicateClause",
@@ -215,6 +226,7 @@ var rul3s = [...]string{
 	"NUMBER_INTEGER",
 	"NUMBER_EXP",
 	"DURATION",
+	"VARIABLE",
 	"PAREN_OPEN",
 	"PAREN_CLOSE",
 	"COMMA",
@@ -228,9 +240,9 @@ var rul3s = [...]string{
 	"Action2",
 	"Action3",
 	"Action4",
-	"PegText",
 	"Action5",
 	"Action6",
+	"PegText",
 	"Action7",
 	"Action8",
 	"Action9",
@@ -278,6 +290,12 @@ var rul3s = [...]string{
 	"Action51",
 	"Action52",
 	"Action53",
+	"Action54",
+	"Action55",
+	"Action56",
+	"Action57",
+	"Action58",
+	"Action59",
 
 	"Pre_",
 	"_In_",
@@ -581,6 +599,13 @@ type Parser struct {
 	// a non-empty list at the finish time means an invalid query is provided.
 	errors []SyntaxError
 
+	// functions invoked by the query, which are checked against the registry.
+	functions []invokedFunction
+
+	// values of the $variables in the query.
+	variables    map[string]Variable
+	allowUnbound bool // whether variables may be given no value
+
 	// errorContext describes contexts used to build error messages
 	fixedContext string
 	errorContext []string
@@ -593,7 +618,7 @@ type Parser struct {
 
 	Buffer string
 	buffer []rune
-	rules  [128]func() bool
+	rules  [137]func() bool
 	Parse  func(rule ...int) error
 	Reset  func()
 	Pretty bool
@@ -678,116 +703,128 @@ func (p *Parser) Execute() {
 			text = string(_buffer[begin:end])
 
 		case ruleAction0:
-			p.makeSelect()
+			p.makeExplain()
 		case ruleAction1:
-			p.makeDescribeAll()
+			p.makeSelect()
 		case ruleAction2:
-			p.addNullMatchClause()
+			p.makeDescribeAll()
 		case ruleAction3:
-			p.addMatchClause()
+			p.addNullMatchClause()
 		case ruleAction4:
-			p.makeDescribeMetrics()
+			p.addMatchClause()
 		case ruleAction5:
-			p.pushString(unescapeLiteral(text))
+			p.makeDescribeMetrics()
 		case ruleAction6:
-			p.makeDescribe()
+			p.makeDescribeFunctions()
 		case ruleAction7:
-			p.addEvaluationContext()
+			p.pushString(unescapeLiteral(text))
 		case ruleAction8:
-			p.addPropertyKey(text)
+			p.makeDescribe()
 		case ruleAction9:
-
-			p.addPropertyValue(text)
+			p.addEvaluationContext()
 		case ruleAction10:
-			p.insertPropertyKeyValue()
+			p.addPropertyKey(text)
 		case ruleAction11:
-			p.checkPropertyClause()
+
+			p.addPropertyValue(text)
 		case ruleAction12:
-			p.addNullPredicate()
+			p.addPropertyVariable(text, begin, end)
 		case ruleAction13:
-			p.addExpressionList()
+			p.insertPropertyKeyValue()
 		case ruleAction14:
-			p.appendExpression()
+			p.checkPropertyClause()
 		case ruleAction15:
-			p.appendExpression()
+			p.addNullPredicate()
 		case ruleAction16:
-			p.addOperatorLiteral("+")
+			p.addExpressionList()
 		case ruleAction17:
-			p.addOperatorLiteral("-")
+			p.appendExpression()
 		case ruleAction18:
-			p.addOperatorFunction()
+			p.appendExpression()
 		case ruleAction19:
-			p.addOperatorLiteral("/")
+			p.addOperatorLiteral("+")
 		case ruleAction20:
-			p.addOperatorLiteral("*")
+			p.addOperatorLiteral("-")
 		case ruleAction21:
 			p.addOperatorFunction()
 		case ruleAction22:
-			p.pushString(unescapeLiteral(text))
+			p.addOperatorLiteral("/")
 		case ruleAction23:
-			p.addExpressionList()
+			p.addOperatorLiteral("*")
 		case ruleAction24:
+			p.addOperatorFunction()
+		case ruleAction25:
+			p.pushFunctionName(unescapeLiteral(text), begin, end)
+		case ruleAction26:
+			p.addExpressionList()
+		case ruleAction27:
 
 			p.addExpressionList()
 			p.addGroupBy()
 
-		case ruleAction25:
-			p.addPipeExpression()
-		case ruleAction26:
-			p.addDurationNode(text)
-		case ruleAction27:
-			p.addNumberNode(text)
 		case ruleAction28:
-			p.addStringNode(unescapeLiteral(text))
+			p.addPipeExpression()
 		case ruleAction29:
-			p.addAnnotationExpression(text)
+			p.addDurationNode(text)
 		case ruleAction30:
-			p.addGroupBy()
+			p.addNumberNode(text)
 		case ruleAction31:
-			p.pushString(unescapeLiteral(text))
+			p.addStringNode(unescapeLiteral(text))
 		case ruleAction32:
-			p.addFunctionInvocation()
+			p.addVariableExpression(text, begin, end)
 		case ruleAction33:
-			p.pushString(unescapeLiteral(text))
+			p.addAnnotationExpression(text)
 		case ruleAction34:
-			p.addNullPredicate()
+			p.addGroupBy()
 		case ruleAction35:
-			p.addMetricExpression()
+			p.pushFunctionName(unescapeLiteral(text), begin, end)
 		case ruleAction36:
-			p.addGroupBy()
+			p.addFunctionInvocation()
 		case ruleAction37:
-			p.appendGroupTag(unescapeLiteral(text))
+			p.pushString(unescapeLiteral(text))
 		case ruleAction38:
-			p.appendGroupTag(unescapeLiteral(text))
+			p.addNullPredicate()
 		case ruleAction39:
-			p.addCollapseBy()
+			p.addMetricExpression()
 		case ruleAction40:
-			p.appendGroupTag(unescapeLiteral(text))
+			p.addGroupBy()
 		case ruleAction41:
 			p.appendGroupTag(unescapeLiteral(text))
 		case ruleAction42:
-			p.addOrPredicate()
+			p.appendGroupTag(unescapeLiteral(text))
 		case ruleAction43:
-			p.addAndPredicate()
+			p.addCollapseBy()
 		case ruleAction44:
-			p.addNotPredicate()
+			p.appendGroupTag(unescapeLiteral(text))
 		case ruleAction45:
-			p.addLiteralMatcher()
+			p.appendGroupTag(unescapeLiteral(text))
 		case ruleAction46:
-			p.addLiteralMatcher()
+			p.addOrPredicate()
 		case ruleAction47:
-			p.addNotPredicate()
+			p.addAndPredicate()
 		case ruleAction48:
-			p.addRegexMatcher()
+			p.addNotPredicate()
 		case ruleAction49:
-			p.addListMatcher()
+			p.addLiteralMatcher()
 		case ruleAction50:
-			p.pushString(unescapeLiteral(text))
+			p.addLiteralMatcher()
 		case ruleAction51:
-			p.addLiteralList()
+			p.addNotPredicate()
 		case ruleAction52:
-			p.appendLiteral(unescapeLiteral(text))
+			p.addRegexMatcher()
 		case ruleAction53:
+			p.addListMatcher()
+		case ruleAction54:
+			p.pushString(unescapeLiteral(text))
+		case ruleAction55:
+			p.pushStringVariable(text, begin, end)
+		case ruleAction56:
+			p.addListVariable(text, begin, end)
+		case ruleAction57:
+			p.addLiteralList()
+		case ruleAction58:
+			p.appendLiteral(unescapeLiteral(text))
+		case ruleAction59:
 			p.addTagLiteral(unescapeLiteral(text))
 
 		}
@@ -858,7 +895,7 @@ func (p *Parser) Init() {
 
 	_rules = [...]func() bool{
 		nil,
-		/* 0 root <- <((selectStmt / describeStmt) _ !.)> */
+		/* 0 root <- <((explainStmt / selectStmt / describeStmt) _ !.)> */
 		func() bool {
 			position0, tokenIndex0, depth0 := position, tokenIndex, depth
 			{
@@ -867,1527 +904,3100 @@ func (p *Parser) Init() {
 				{
 					position2, tokenIndex2, depth2 := position, tokenIndex, depth
 					{
-						position4 := position
+						position1012 := position
 						depth++
 						if !_rules[rule_]() {
 							goto l3
 						}
 						{
-							position5, tokenIndex5, depth5 := position, tokenIndex, depth
-							{
-								position7, tokenIndex7, depth7 := position, tokenIndex, depth
-								if buffer[position] != rune('s') {
-									goto l8
-								}
-								position++
-								goto l7
-							l8:
-								position, tokenIndex, depth = position7, tokenIndex7, depth7
-								if buffer[position] != rune('S') {
-									goto l5
-								}
-								position++
+							position1016, tokenIndex1016, depth1016 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l1017
 							}
-						l7:
-							{
-								position9, tokenIndex9, depth9 := position, tokenIndex, depth
-								if buffer[position] != rune('e') {
-									goto l10
-								}
-								position++
-								goto l9
-							l10:
-								position, tokenIndex, depth = position9, tokenIndex9, depth9
-								if buffer[position] != rune('E') {
-									goto l5
-								}
-								position++
+							position++
+							goto l1016
+						l1017:
+							position, tokenIndex, depth = position1016, tokenIndex1016, depth1016
+							if buffer[position] != rune('E') {
+								goto l3
 							}
-						l9:
-							{
-								position11, tokenIndex11, depth11 := position, tokenIndex, depth
-								if buffer[position] != rune('l') {
-									goto l12
-								}
-								position++
-								goto l11
-							l12:
-								position, tokenIndex, depth = position11, tokenIndex11, depth11
-								if buffer[position] != rune('L') {
-									goto l5
-								}
-								position++
+							position++
+						}
+					l1016:
+						{
+							position1018, tokenIndex1018, depth1018 := position, tokenIndex, depth
+							if buffer[position] != rune('x') {
+								goto l1019
 							}
-						l11:
-							{
-								position13, tokenIndex13, depth13 := position, tokenIndex, depth
-								if buffer[position] != rune('e') {
-									goto l14
-								}
-								position++
-								goto l13
-							l14:
-								position, tokenIndex, depth = position13, tokenIndex13, depth13
-								if buffer[position] != rune('E') {
-									goto l5
-								}
-								position++
+							position++
+							goto l1018
+						l1019:
+							position, tokenIndex, depth = position1018, tokenIndex1018, depth1018
+							if buffer[position] != rune('X') {
+								goto l3
 							}
-						l13:
-							{
-								position15, tokenIndex15, depth15 := position, tokenIndex, depth
-								if buffer[position] != rune('c') {
-									goto l16
-								}
-								position++
-								goto l15
-							l16:
-								position, tokenIndex, depth = position15, tokenIndex15, depth15
-								if buffer[position] != rune('C') {
-									goto l5
-								}
-								position++
+							position++
+						}
+					l1018:
+						{
+							position1020, tokenIndex1020, depth1020 := position, tokenIndex, depth
+							if buffer[position] != rune('p') {
+								goto l1021
 							}
-						l15:
-							{
-								position17, tokenIndex17, depth17 := position, tokenIndex, depth
-								if buffer[position] != rune('t') {
-									goto l18
-								}
-								position++
-								goto l17
-							l18:
-								position, tokenIndex, depth = position17, tokenIndex17, depth17
-								if buffer[position] != rune('T') {
-									goto l5
-								}
-								position++
+							position++
+							goto l1020
+						l1021:
+							position, tokenIndex, depth = position1020, tokenIndex1020, depth1020
+							if buffer[position] != rune('P') {
+								goto l3
 							}
-						l17:
-							if !_rules[ruleKEY]() {
-								goto l5
+							position++
+						}
+					l1020:
+						{
+							position1022, tokenIndex1022, depth1022 := position, tokenIndex, depth
+							if buffer[position] != rune('l') {
+								goto l1023
+							}
+							position++
+							goto l1022
+						l1023:
+							position, tokenIndex, depth = position1022, tokenIndex1022, depth1022
+							if buffer[position] != rune('L') {
+								goto l3
 							}
-							goto l6
-						l5:
-							position, tokenIndex, depth = position5, tokenIndex5, depth5
+							position++
 						}
-					l6:
-						if !_rules[ruleexpressionList]() {
-							goto l3
+					l1022:
+						{
+							position1024, tokenIndex1024, depth1024 := position, tokenIndex, depth
+							if buffer[position] != rune('a') {
+								goto l1025
+							}
+							position++
+							goto l1024
+						l1025:
+							position, tokenIndex, depth = position1024, tokenIndex1024, depth1024
+							if buffer[position] != rune('A') {
+								goto l3
+							}
+							position++
 						}
-						if !(p.setContext("after expression of select statement")) {
-							goto l3
+					l1024:
+						{
+							position1026, tokenIndex1026, depth1026 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l1027
+							}
+							position++
+							goto l1026
+						l1027:
+							position, tokenIndex, depth = position1026, tokenIndex1026, depth1026
+							if buffer[position] != rune('I') {
+								goto l3
+							}
+							position++
 						}
-						if !_rules[ruleoptionalPredicateClause]() {
-							goto l3
+					l1026:
+						{
+							position1028, tokenIndex1028, depth1028 := position, tokenIndex, depth
+							if buffer[position] != rune('n') {
+								goto l1029
+							}
+							position++
+							goto l1028
+						l1029:
+							position, tokenIndex, depth = position1028, tokenIndex1028, depth1028
+							if buffer[position] != rune('N') {
+								goto l3
+							}
+							position++
 						}
-						if !(p.setContext("")) {
+					l1028:
+						if !_rules[ruleKEY]() {
 							goto l3
 						}
 						{
-							position19 := position
-							depth++
-							{
-								add(ruleAction7, position)
+							position1013, tokenIndex1013, depth1013 := position, tokenIndex, depth
+							if !_rules[ruleselectStmt]() {
+								goto l1014
 							}
-						l21:
-							{
-								position22, tokenIndex22, depth22 := position, tokenIndex, depth
-								{
-									position23, tokenIndex23, depth23 := position, tokenIndex, depth
-									if !_rules[rule_]() {
-										goto l24
-									}
-									{
-										position25 := position
-										depth++
-										{
-											switch buffer[position] {
-											case 'S', 's':
-												{
-													position27 := position
-													depth++
-													{
-														position28, tokenIndex28, depth28 := position, tokenIndex, depth
-														if buffer[position] != rune('s') {
-															goto l29
-														}
-														position++
-														goto l28
-													l29:
-														position, tokenIndex, depth = position28, tokenIndex28, depth28
-														if buffer[position] != rune('S') {
-															goto l24
-														}
-														position++
-													}
-												l28:
-													{
-														position30, tokenIndex30, depth30 := position, tokenIndex, depth
-														if buffer[position] != rune('a') {
-															goto l31
-														}
-														position++
-														goto l30
-													l31:
-														position, tokenIndex, depth = position30, tokenIndex30, depth30
-														if buffer[position] != rune('A') {
-															goto l24
-														}
-														position++
-													}
-												l30:
-													{
-														position32, tokenIndex32, depth32 := position, tokenIndex, depth
-														if buffer[position] != rune('m') {
-															goto l33
-														}
-														position++
-														goto l32
-													l33:
-														position, tokenIndex, depth = position32, tokenIndex32, depth32
-														if buffer[position] != rune('M') {
-															goto l24
-														}
-														position++
-													}
-												l32:
-													{
-														position34, tokenIndex34, depth34 := position, tokenIndex, depth
-														if buffer[position] != rune('p') {
-															goto l35
-														}
-														position++
-														goto l34
-													l35:
-														position, tokenIndex, depth = position34, tokenIndex34, depth34
-														if buffer[position] != rune('P') {
-															goto l24
-														}
-														position++
-													}
-												l34:
-													{
-														position36, tokenIndex36, depth36 := position, tokenIndex, depth
-														if buffer[position] != rune('l') {
-															goto l37
-														}
-														position++
-														goto l36
-													l37:
-														position, tokenIndex, depth = position36, tokenIndex36, depth36
-														if buffer[position] != rune('L') {
-															goto l24
-														}
-														position++
-													}
-												l36:
-													{
-														position38, tokenIndex38, depth38 := position, tokenIndex, depth
-														if buffer[position] != rune('e') {
-															goto l39
-														}
-														position++
-														goto l38
-													l39:
-														position, tokenIndex, depth = position38, tokenIndex38, depth38
-														if buffer[position] != rune('E') {
-															goto l24
-														}
-														position++
-													}
-												l38:
-													depth--
-													add(rulePegText, position27)
-												}
-												if !_rules[ruleKEY]() {
-													goto l24
-												}
-												{
-													position40, tokenIndex40, depth40 := position, tokenIndex, depth
-													if !_rules[rule_]() {
-														goto l41
-													}
-													{
-														position42, tokenIndex42, depth42 := position, tokenIndex, depth
-														if buffer[position] != rune('b') {
-															goto l43
-														}
-														position++
-														goto l42
-													l43:
-														position, tokenIndex, depth = position42, tokenIndex42, depth42
-														if buffer[position] != rune('B') {
-															goto l41
-														}
-														position++
-													}
-												l42:
-													{
-														position44, tokenIndex44, depth44 := position, tokenIndex, depth
-														if buffer[position] != rune('y') {
-															goto l45
-														}
-														position++
-														goto l44
-													l45:
-														position, tokenIndex, depth = position44, tokenIndex44, depth44
-														if buffer[position] != rune('Y') {
-															goto l41
-														}
-														position++
-													}
-												l44:
-													if !_rules[ruleKEY]() {
-														goto l41
-													}
-													goto l40
-												l41:
-													position, tokenIndex, depth = position40, tokenIndex40, depth40
-													if !(p.errorHere(position, `expected keyword "by" to follow keyword "sample"`)) {
-														goto l24
-													}
-												}
-											l40:
-												break
-											case 'R', 'r':
-												{
-													position46 := position
-													depth++
-													{
-														position47, tokenIndex47, depth47 := position, tokenIndex, depth
-														if buffer[position] != rune('r') {
-															goto l48
-														}
-														position++
-														goto l47
-													l48:
-														position, tokenIndex, depth = position47, tokenIndex47, depth47
-														if buffer[position] != rune('R') {
-															goto l24
-														}
-														position++
-													}
-												l47:
-													{
-														position49, tokenIndex49, depth49 := position, tokenIndex, depth
-														if buffer[position] != rune('e') {
-															goto l50
-														}
-														position++
-														goto l49
-													l50:
-														position, tokenIndex, depth = position49, tokenIndex49, depth49
-														if buffer[position] != rune('E') {
-															goto l24
-														}
-														position++
-													}
-												l49:
-													{
-														position51, tokenIndex51, depth51 := position, tokenIndex, depth
-														if buffer[position] != rune('s') {
-															goto l52
-														}
-														position++
-														goto l51
-													l52:
-														position, tokenIndex, depth = position51, tokenIndex51, depth51
-														if buffer[position] != rune('S') {
-															goto l24
-														}
-														position++
-													}
-												l51:
-													{
-														position53, tokenIndex53, depth53 := position, tokenIndex, depth
-														if buffer[position] != rune('o') {
-															goto l54
-														}
-														position++
-														goto l53
-													l54:
-														position, tokenIndex, depth = position53, tokenIndex53, depth53
-														if buffer[position] != rune('O') {
-															goto l24
-														}
-														position++
-													}
-												l53:
-													{
-														position55, tokenIndex55, depth55 := position, tokenIndex, depth
-														if buffer[position] != rune('l') {
-															goto l56
-														}
-														position++
-														goto l55
-													l56:
-														position, tokenIndex, depth = position55, tokenIndex55, depth55
-														if buffer[position] != rune('L') {
-															goto l24
-														}
-														position++
-													}
-												l55:
-													{
-														position57, tokenIndex57, depth57 := position, tokenIndex, depth
-														if buffer[position] != rune('u') {
-															goto l58
-														}
-														position++
-														goto l57
-													l58:
-														position, tokenIndex, depth = position57, tokenIndex57, depth57
-														if buffer[position] != rune('U') {
-															goto l24
-														}
-														position++
-													}
-												l57:
-													{
-														position59, tokenIndex59, depth59 := position, tokenIndex, depth
-														if buffer[position] != rune('t') {
-															goto l60
-														}
-														position++
-														goto l59
-													l60:
-														position, tokenIndex, depth = position59, tokenIndex59, depth59
-														if buffer[position] != rune('T') {
-															goto l24
-														}
-														position++
-													}
-												l59:
-													{
-														position61, tokenIndex61, depth61 := position, tokenIndex, depth
-														if buffer[position] != rune('i') {
-															goto l62
-														}
-														position++
-														goto l61
-													l62:
-														position, tokenIndex, depth = position61, tokenIndex61, depth61
-														if buffer[position] != rune('I') {
-															goto l24
-														}
-														position++
-													}
-												l61:
-													{
-														position63, tokenIndex63, depth63 := position, tokenIndex, depth
-														if buffer[position] != rune('o') {
-															goto l64
-														}
-														position++
-														goto l63
-													l64:
-														position, tokenIndex, depth = position63, tokenIndex63, depth63
-														if buffer[position] != rune('O') {
-															goto l24
-														}
-														position++
-													}
-												l63:
-													{
-														position65, tokenIndex65, depth65 := position, tokenIndex, depth
-														if buffer[position] != rune('n') {
-															goto l66
-														}
-														position++
-														goto l65
-													l66:
-														position, tokenIndex, depth = position65, tokenIndex65, depth65
-														if buffer[position] != rune('N') {
-															goto l24
-														}
-														position++
-													}
-												l65:
-													depth--
-													add(rulePegText, position46)
-												}
-												if !_rules[ruleKEY]() {
-													goto l24
-												}
-												break
-											case 'T', 't':
-												{
-													position67 := position
-													depth++
-													{
-														position68, tokenIndex68, depth68 := position, tokenIndex, depth
-														if buffer[position] != rune('t') {
-															goto l69
-														}
-														position++
-														goto l68
-													l69:
-														position, tokenIndex, depth = position68, tokenIndex68, depth68
-														if buffer[position] != rune('T') {
-															goto l24
-														}
-														position++
-													}
-												l68:
-													{
-														position70, tokenIndex70, depth70 := position, tokenIndex, depth
-														if buffer[position] != rune('o') {
-															goto l71
-														}
-														position++
-														goto l70
-													l71:
-														position, tokenIndex, depth = position70, tokenIndex70, depth70
-														if buffer[position] != rune('O') {
-															goto l24
-														}
-														position++
-													}
-												l70:
-													depth--
-													add(rulePegText, position67)
-												}
-												if !_rules[ruleKEY]() {
-													goto l24
-												}
-												break
-											default:
-												{
-													position72 := position
-													depth++
-													{
-														position73, tokenIndex73, depth73 := position, tokenIndex, depth
-														if buffer[position] != rune('f') {
-															goto l74
-														}
-														position++
-														goto l73
-													l74:
-														position, tokenIndex, depth = position73, tokenIndex73, depth73
-														if buffer[position] != rune('F') {
-															goto l24
-														}
-														position++
-													}
-												l73:
-													{
-														position75, tokenIndex75, depth75 := position, tokenIndex, depth
-														if buffer[position] != rune('r') {
-															goto l76
-														}
-														position++
-														goto l75
-													l76:
-														position, tokenIndex, depth = position75, tokenIndex75, depth75
-														if buffer[position] != rune('R') {
-															goto l24
-														}
-														position++
-													}
-												l75:
-													{
-														position77, tokenIndex77, depth77 := position, tokenIndex, depth
-														if buffer[position] != rune('o') {
-															goto l78
-														}
-														position++
-														goto l77
-													l78:
-														position, tokenIndex, depth = position77, tokenIndex77, depth77
-														if buffer[position] != rune('O') {
-															goto l24
-														}
-														position++
-													}
-												l77:
-													{
-														position79, tokenIndex79, depth79 := position, tokenIndex, depth
-														if buffer[position] != rune('m') {
-															goto l80
-														}
-														position++
-														goto l79
-													l80:
-														position, tokenIndex, depth = position79, tokenIndex79, depth79
-														if buffer[position] != rune('M') {
-															goto l24
-														}
-														position++
-													}
-												l79:
-													depth--
-													add(rulePegText, position72)
-												}
-												if !_rules[ruleKEY]() {
-													goto l24
-												}
-												break
+							goto l1013
+						l1014:
+							position, tokenIndex, depth = position1013, tokenIndex1013, depth1013
+							if !(p.errorExpecting(position, expect(`select statement`), `to follow keyword "explain"`)) {
+								goto l3
+							}
+						}
+					l1013:
+						{
+							add(ruleAction0, position)
+						}
+						depth--
+						add(ruleexplainStmt, position1012)
+					}
+					goto l2
+				l3:
+					position, tokenIndex, depth = position2, tokenIndex2, depth2
+					if !_rules[ruleselectStmt]() {
+						goto l1015
+					}
+					goto l2
+				l1015:
+					position, tokenIndex, depth = position2, tokenIndex2, depth2
+					{
+						position118 := position
+						depth++
+						if !_rules[rule_]() {
+							goto l0
+						}
+						{
+							position119, tokenIndex119, depth119 := position, tokenIndex, depth
+							if buffer[position] != rune('d') {
+								goto l120
+							}
+							position++
+							goto l119
+						l120:
+							position, tokenIndex, depth = position119, tokenIndex119, depth119
+							if buffer[position] != rune('D') {
+								goto l0
+							}
+							position++
+						}
+					l119:
+						{
+							position121, tokenIndex121, depth121 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l122
+							}
+							position++
+							goto l121
+						l122:
+							position, tokenIndex, depth = position121, tokenIndex121, depth121
+							if buffer[position] != rune('E') {
+								goto l0
+							}
+							position++
+						}
+					l121:
+						{
+							position123, tokenIndex123, depth123 := position, tokenIndex, depth
+							if buffer[position] != rune('s') {
+								goto l124
+							}
+							position++
+							goto l123
+						l124:
+							position, tokenIndex, depth = position123, tokenIndex123, depth123
+							if buffer[position] != rune('S') {
+								goto l0
+							}
+							position++
+						}
+					l123:
+						{
+							position125, tokenIndex125, depth125 := position, tokenIndex, depth
+							if buffer[position] != rune('c') {
+								goto l126
+							}
+							position++
+							goto l125
+						l126:
+							position, tokenIndex, depth = position125, tokenIndex125, depth125
+							if buffer[position] != rune('C') {
+								goto l0
+							}
+							position++
+						}
+					l125:
+						{
+							position127, tokenIndex127, depth127 := position, tokenIndex, depth
+							if buffer[position] != rune('r') {
+								goto l128
+							}
+							position++
+							goto l127
+						l128:
+							position, tokenIndex, depth = position127, tokenIndex127, depth127
+							if buffer[position] != rune('R') {
+								goto l0
+							}
+							position++
+						}
+					l127:
+						{
+							position129, tokenIndex129, depth129 := position, tokenIndex, depth
+							if buffer[position] != rune('i') {
+								goto l130
+							}
+							position++
+							goto l129
+						l130:
+							position, tokenIndex, depth = position129, tokenIndex129, depth129
+							if buffer[position] != rune('I') {
+								goto l0
+							}
+							position++
+						}
+					l129:
+						{
+							position131, tokenIndex131, depth131 := position, tokenIndex, depth
+							if buffer[position] != rune('b') {
+								goto l132
+							}
+							position++
+							goto l131
+						l132:
+							position, tokenIndex, depth = position131, tokenIndex131, depth131
+							if buffer[position] != rune('B') {
+								goto l0
+							}
+							position++
+						}
+					l131:
+						{
+							position133, tokenIndex133, depth133 := position, tokenIndex, depth
+							if buffer[position] != rune('e') {
+								goto l134
+							}
+							position++
+							goto l133
+						l134:
+							position, tokenIndex, depth = position133, tokenIndex133, depth133
+							if buffer[position] != rune('E') {
+								goto l0
+							}
+							position++
+						}
+					l133:
+						if !_rules[ruleKEY]() {
+							goto l0
+						}
+						{
+							position135, tokenIndex135, depth135 := position, tokenIndex, depth
+							{
+								position137 := position
+								depth++
+								if !_rules[rule_]() {
+									goto l136
+								}
+								{
+									position138, tokenIndex138, depth138 := position, tokenIndex, depth
+									if buffer[position] != rune('a') {
+										goto l139
+									}
+									position++
+									goto l138
+								l139:
+									position, tokenIndex, depth = position138, tokenIndex138, depth138
+									if buffer[position] != rune('A') {
+										goto l136
+									}
+									position++
+								}
+							l138:
+								{
+									position140, tokenIndex140, depth140 := position, tokenIndex, depth
+									if buffer[position] != rune('l') {
+										goto l141
+									}
+									position++
+									goto l140
+								l141:
+									position, tokenIndex, depth = position140, tokenIndex140, depth140
+									if buffer[position] != rune('L') {
+										goto l136
+									}
+									position++
+								}
+							l140:
+								{
+									position142, tokenIndex142, depth142 := position, tokenIndex, depth
+									if buffer[position] != rune('l') {
+										goto l143
+									}
+									position++
+									goto l142
+								l143:
+									position, tokenIndex, depth = position142, tokenIndex142, depth142
+									if buffer[position] != rune('L') {
+										goto l136
+									}
+									position++
+								}
+							l142:
+								if !_rules[ruleKEY]() {
+									goto l136
+								}
+								if !_rules[ruleoptionalMatchClause]() {
+									goto l136
+								}
+								{
+									add(ruleAction2, position)
+								}
+								{
+									position163, tokenIndex163, depth163 := position, tokenIndex, depth
+									{
+										position164, tokenIndex164, depth164 := position, tokenIndex, depth
+										if !_rules[rule_]() {
+											goto l165
+										}
+										{
+											position166, tokenIndex166, depth166 := position, tokenIndex, depth
+											if !matchDot() {
+												goto l166
 											}
+											goto l165
+										l166:
+											position, tokenIndex, depth = position166, tokenIndex166, depth166
+										}
+										goto l164
+									l165:
+										position, tokenIndex, depth = position164, tokenIndex164, depth164
+										if !_rules[rule_]() {
+											goto l136
+										}
+										if !(p.errorExpecting(position, expect(`end of input`), `after 'describe all' and optional match clause but got %q`, p.after(position))) {
+											goto l136
+										}
+									}
+								l164:
+									position, tokenIndex, depth = position163, tokenIndex163, depth163
+								}
+								depth--
+								add(ruledescribeAllStmt, position137)
+							}
+							goto l135
+						l136:
+							position, tokenIndex, depth = position135, tokenIndex135, depth135
+							{
+								position168 := position
+								depth++
+								if !_rules[rule_]() {
+									goto l167
+								}
+								{
+									position169, tokenIndex169, depth169 := position, tokenIndex, depth
+									if buffer[position] != rune('m') {
+										goto l170
+									}
+									position++
+									goto l169
+								l170:
+									position, tokenIndex, depth = position169, tokenIndex169, depth169
+									if buffer[position] != rune('M') {
+										goto l167
+									}
+									position++
+								}
+							l169:
+								{
+									position171, tokenIndex171, depth171 := position, tokenIndex, depth
+									if buffer[position] != rune('e') {
+										goto l172
+									}
+									position++
+									goto l171
+								l172:
+									position, tokenIndex, depth = position171, tokenIndex171, depth171
+									if buffer[position] != rune('E') {
+										goto l167
+									}
+									position++
+								}
+							l171:
+								{
+									position173, tokenIndex173, depth173 := position, tokenIndex, depth
+									if buffer[position] != rune('t') {
+										goto l174
+									}
+									position++
+									goto l173
+								l174:
+									position, tokenIndex, depth = position173, tokenIndex173, depth173
+									if buffer[position] != rune('T') {
+										goto l167
+									}
+									position++
+								}
+							l173:
+								{
+									position175, tokenIndex175, depth175 := position, tokenIndex, depth
+									if buffer[position] != rune('r') {
+										goto l176
+									}
+									position++
+									goto l175
+								l176:
+									position, tokenIndex, depth = position175, tokenIndex175, depth175
+									if buffer[position] != rune('R') {
+										goto l167
+									}
+									position++
+								}
+							l175:
+								{
+									position177, tokenIndex177, depth177 := position, tokenIndex, depth
+									if buffer[position] != rune('i') {
+										goto l178
+									}
+									position++
+									goto l177
+								l178:
+									position, tokenIndex, depth = position177, tokenIndex177, depth177
+									if buffer[position] != rune('I') {
+										goto l167
+									}
+									position++
+								}
+							l177:
+								{
+									position179, tokenIndex179, depth179 := position, tokenIndex, depth
+									if buffer[position] != rune('c') {
+										goto l180
+									}
+									position++
+									goto l179
+								l180:
+									position, tokenIndex, depth = position179, tokenIndex179, depth179
+									if buffer[position] != rune('C') {
+										goto l167
+									}
+									position++
+								}
+							l179:
+								{
+									position181, tokenIndex181, depth181 := position, tokenIndex, depth
+									if buffer[position] != rune('s') {
+										goto l182
+									}
+									position++
+									goto l181
+								l182:
+									position, tokenIndex, depth = position181, tokenIndex181, depth181
+									if buffer[position] != rune('S') {
+										goto l167
+									}
+									position++
+								}
+							l181:
+								if !_rules[ruleKEY]() {
+									goto l167
+								}
+								{
+									position183, tokenIndex183, depth183 := position, tokenIndex, depth
+									if !_rules[rule_]() {
+										goto l184
+									}
+									{
+										position185, tokenIndex185, depth185 := position, tokenIndex, depth
+										if buffer[position] != rune('w') {
+											goto l186
 										}
-
-										depth--
-										add(rulePROPERTY_KEY, position25)
+										position++
+										goto l185
+									l186:
+										position, tokenIndex, depth = position185, tokenIndex185, depth185
+										if buffer[position] != rune('W') {
+											goto l184
+										}
+										position++
+									}
+								l185:
+									{
+										position187, tokenIndex187, depth187 := position, tokenIndex, depth
+										if buffer[position] != rune('h') {
+											goto l188
+										}
+										position++
+										goto l187
+									l188:
+										position, tokenIndex, depth = position187, tokenIndex187, depth187
+										if buffer[position] != rune('H') {
+											goto l184
+										}
+										position++
+									}
+								l187:
+									{
+										position189, tokenIndex189, depth189 := position, tokenIndex, depth
+										if buffer[position] != rune('e') {
+											goto l190
+										}
+										position++
+										goto l189
+									l190:
+										position, tokenIndex, depth = position189, tokenIndex189, depth189
+										if buffer[position] != rune('E') {
+											goto l184
+										}
+										position++
+									}
+								l189:
+									{
+										position191, tokenIndex191, depth191 := position, tokenIndex, depth
+										if buffer[position] != rune('r') {
+											goto l192
+										}
+										position++
+										goto l191
+									l192:
+										position, tokenIndex, depth = position191, tokenIndex191, depth191
+										if buffer[position] != rune('R') {
+											goto l184
+										}
+										position++
+									}
+								l191:
+									{
+										position193, tokenIndex193, depth193 := position, tokenIndex, depth
+										if buffer[position] != rune('e') {
+											goto l194
+										}
+										position++
+										goto l193
+									l194:
+										position, tokenIndex, depth = position193, tokenIndex193, depth193
+										if buffer[position] != rune('E') {
+											goto l184
+										}
+										position++
+									}
+								l193:
+									if !_rules[ruleKEY]() {
+										goto l184
+									}
+									goto l183
+								l184:
+									position, tokenIndex, depth = position183, tokenIndex183, depth183
+									if !(p.errorExpecting(position, expect(`"where"`), `to follow keyword "metrics" in "describe metrics" command`)) {
+										goto l167
+									}
+								}
+							l183:
+								{
+									position195, tokenIndex195, depth195 := position, tokenIndex, depth
+									if !_rules[ruletagName]() {
+										goto l196
+									}
+									goto l195
+								l196:
+									position, tokenIndex, depth = position195, tokenIndex195, depth195
+									if !(p.errorExpecting(position, expect(`tag key`), `to follow keyword "where" in "describe metrics" command`)) {
+										goto l167
+									}
+								}
+							l195:
+								{
+									position197, tokenIndex197, depth197 := position, tokenIndex, depth
+									if !_rules[rule_]() {
+										goto l198
+									}
+									if buffer[position] != rune('=') {
+										goto l198
+									}
+									position++
+									goto l197
+								l198:
+									position, tokenIndex, depth = position197, tokenIndex197, depth197
+									if !(p.errorExpecting(position, expect(`"="`), `to follow keyword "where" in "describe metrics" command`)) {
+										goto l167
+									}
+								}
+							l197:
+								{
+									position199, tokenIndex199, depth199 := position, tokenIndex, depth
+									if !_rules[ruleliteralString]() {
+										goto l200
+									}
+									goto l199
+								l200:
+									position, tokenIndex, depth = position199, tokenIndex199, depth199
+									if !(p.errorExpecting(position, expect(`string literal`), `to follow "=" in "describe metrics" command`)) {
+										goto l167
+									}
+								}
+							l199:
+								{
+									add(ruleAction5, position)
+								}
+								depth--
+								add(ruledescribeMetrics, position168)
+							}
+							goto l135
+						l167:
+							position, tokenIndex, depth = position135, tokenIndex135, depth135
+							{
+								position1031 := position
+								depth++
+								if !_rules[rule_]() {
+									goto l1030
+								}
+								{
+									position1036, tokenIndex1036, depth1036 := position, tokenIndex, depth
+									if buffer[position] != rune('f') {
+										goto l1037
+									}
+									position++
+									goto l1036
+								l1037:
+									position, tokenIndex, depth = position1036, tokenIndex1036, depth1036
+									if buffer[position] != rune('F') {
+										goto l1030
+									}
+									position++
+								}
+							l1036:
+								{
+									position1038, tokenIndex1038, depth1038 := position, tokenIndex, depth
+									if buffer[position] != rune('u') {
+										goto l1039
+									}
+									position++
+									goto l1038
+								l1039:
+									position, tokenIndex, depth = position1038, tokenIndex1038, depth1038
+									if buffer[position] != rune('U') {
+										goto l1030
+									}
+									position++
+								}
+							l1038:
+								{
+									position1040, tokenIndex1040, depth1040 := position, tokenIndex, depth
+									if buffer[position] != rune('n') {
+										goto l1041
+									}
+									position++
+									goto l1040
+								l1041:
+									position, tokenIndex, depth = position1040, tokenIndex1040, depth1040
+									if buffer[position] != rune('N') {
+										goto l1030
+									}
+									position++
+								}
+							l1040:
+								{
+									position1042, tokenIndex1042, depth1042 := position, tokenIndex, depth
+									if buffer[position] != rune('c') {
+										goto l1043
+									}
+									position++
+									goto l1042
+								l1043:
+									position, tokenIndex, depth = position1042, tokenIndex1042, depth1042
+									if buffer[position] != rune('C') {
+										goto l1030
+									}
+									position++
+								}
+							l1042:
+								{
+									position1044, tokenIndex1044, depth1044 := position, tokenIndex, depth
+									if buffer[position] != rune('t') {
+										goto l1045
+									}
+									position++
+									goto l1044
+								l1045:
+									position, tokenIndex, depth = position1044, tokenIndex1044, depth1044
+									if buffer[position] != rune('T') {
+										goto l1030
 									}
-									{
-										add(ruleAction8, position)
+									position++
+								}
+							l1044:
+								{
+									position1046, tokenIndex1046, depth1046 := position, tokenIndex, depth
+									if buffer[position] != rune('i') {
+										goto l1047
 									}
-									{
-										position82, tokenIndex82, depth82 := position, tokenIndex, depth
-										if !_rules[rule_]() {
-											goto l83
-										}
-										{
-											position84 := position
-											depth++
-											{
-												position85 := position
-												depth++
-												{
-													position86, tokenIndex86, depth86 := position, tokenIndex, depth
-													if !_rules[rule_]() {
-														goto l87
-													}
-													{
-														position88 := position
-														depth++
-														if !_rules[ruleNUMBER]() {
-															goto l87
-														}
-													l89:
-														{
-															position90, tokenIndex90, depth90 := position, tokenIndex, depth
-															{
-																position91, tokenIndex91, depth91 := position, tokenIndex, depth
-																if c := buffer[position]; c < rune('a') || c > rune('z') {
-																	goto l92
-																}
-																position++
-																goto l91
-															l92:
-																position, tokenIndex, depth = position91, tokenIndex91, depth91
-																if c := buffer[position]; c < rune('A') || c > rune('Z') {
-																	goto l90
-																}
-																position++
-															}
-														l91:
-															goto l89
-														l90:
-															position, tokenIndex, depth = position90, tokenIndex90, depth90
-														}
-														depth--
-														add(rulePegText, position88)
-													}
-													goto l86
-												l87:
-													position, tokenIndex, depth = position86, tokenIndex86, depth86
-													if !_rules[rule_]() {
-														goto l93
-													}
-													if !_rules[ruleSTRING]() {
-														goto l93
-													}
-													goto l86
-												l93:
-													position, tokenIndex, depth = position86, tokenIndex86, depth86
-													if !_rules[rule_]() {
-														goto l83
-													}
-													{
-														position94 := position
-														depth++
-														{
-															position95, tokenIndex95, depth95 := position, tokenIndex, depth
-															if buffer[position] != rune('n') {
-																goto l96
-															}
-															position++
-															goto l95
-														l96:
-															position, tokenIndex, depth = position95, tokenIndex95, depth95
-															if buffer[position] != rune('N') {
-																goto l83
-															}
-															position++
-														}
-													l95:
-														{
-															position97, tokenIndex97, depth97 := position, tokenIndex, depth
-															if buffer[position] != rune('o') {
-																goto l98
-															}
-															position++
-															goto l97
-														l98:
-															position, tokenIndex, depth = position97, tokenIndex97, depth97
-															if buffer[position] != rune('O') {
-																goto l83
-															}
-															position++
-														}
-													l97:
-														{
-															position99, tokenIndex99, depth99 := position, tokenIndex, depth
-															if buffer[position] != rune('w') {
-																goto l100
-															}
-															position++
-															goto l99
-														l100:
-															position, tokenIndex, depth = position99, tokenIndex99, depth99
-															if buffer[position] != rune('W') {
-																goto l83
-															}
-															position++
-														}
-													l99:
-														depth--
-														add(rulePegText, position94)
-													}
-													if !_rules[ruleKEY]() {
-														goto l83
-													}
-												}
-											l86:
-												depth--
-												add(ruleTIMESTAMP, position85)
-											}
-											depth--
-											add(rulePROPERTY_VALUE, position84)
-										}
-										{
-											add(ruleAction9, position)
-										}
-										goto l82
-									l83:
-										position, tokenIndex, depth = position82, tokenIndex82, depth82
-										if !(p.errorHere(position, `expected value to follow key '%s'`, p.contents(tree, tokenIndex-2))) {
-											goto l24
-										}
+									position++
+									goto l1046
+								l1047:
+									position, tokenIndex, depth = position1046, tokenIndex1046, depth1046
+									if buffer[position] != rune('I') {
+										goto l1030
 									}
-								l82:
-									{
-										add(ruleAction10, position)
+									position++
+								}
+							l1046:
+								{
+									position1048, tokenIndex1048, depth1048 := position, tokenIndex, depth
+									if buffer[position] != rune('o') {
+										goto l1049
 									}
-									goto l23
-								l24:
-									position, tokenIndex, depth = position23, tokenIndex23, depth23
-									if !_rules[rule_]() {
-										goto l103
+									position++
+									goto l1048
+								l1049:
+									position, tokenIndex, depth = position1048, tokenIndex1048, depth1048
+									if buffer[position] != rune('O') {
+										goto l1030
 									}
-									{
-										position104, tokenIndex104, depth104 := position, tokenIndex, depth
-										if buffer[position] != rune('w') {
-											goto l105
-										}
-										position++
-										goto l104
-									l105:
-										position, tokenIndex, depth = position104, tokenIndex104, depth104
-										if buffer[position] != rune('W') {
-											goto l103
-										}
-										position++
+									position++
+								}
+							l1048:
+								{
+									position1050, tokenIndex1050, depth1050 := position, tokenIndex, depth
+									if buffer[position] != rune('n') {
+										goto l1051
 									}
-								l104:
-									{
-										position106, tokenIndex106, depth106 := position, tokenIndex, depth
-										if buffer[position] != rune('h') {
-											goto l107
-										}
-										position++
-										goto l106
-									l107:
-										position, tokenIndex, depth = position106, tokenIndex106, depth106
-										if buffer[position] != rune('H') {
-											goto l103
-										}
-										position++
+									position++
+									goto l1050
+								l1051:
+									position, tokenIndex, depth = position1050, tokenIndex1050, depth1050
+									if buffer[position] != rune('N') {
+										goto l1030
 									}
-								l106:
-									{
-										position108, tokenIndex108, depth108 := position, tokenIndex, depth
-										if buffer[position] != rune('e') {
-											goto l109
-										}
-										position++
-										goto l108
-									l109:
-										position, tokenIndex, depth = position108, tokenIndex108, depth108
-										if buffer[position] != rune('E') {
-											goto l103
-										}
-										position++
+									position++
+								}
+							l1050:
+								{
+									position1052, tokenIndex1052, depth1052 := position, tokenIndex, depth
+									if buffer[position] != rune('s') {
+										goto l1053
 									}
-								l108:
+									position++
+									goto l1052
+								l1053:
+									position, tokenIndex, depth = position1052, tokenIndex1052, depth1052
+									if buffer[position] != rune('S') {
+										goto l1030
+									}
+									position++
+								}
+							l1052:
+								if !_rules[ruleKEY]() {
+									goto l1030
+								}
+								if !_rules[ruleoptionalMatchClause]() {
+									goto l1030
+								}
+								{
+									add(ruleAction6, position)
+								}
+								{
+									position1032, tokenIndex1032, depth1032 := position, tokenIndex, depth
 									{
-										position110, tokenIndex110, depth110 := position, tokenIndex, depth
-										if buffer[position] != rune('r') {
-											goto l111
+										position1033, tokenIndex1033, depth1033 := position, tokenIndex, depth
+										if !_rules[rule_]() {
+											goto l1034
 										}
-										position++
-										goto l110
-									l111:
-										position, tokenIndex, depth = position110, tokenIndex110, depth110
-										if buffer[position] != rune('R') {
-											goto l103
+										{
+											position1035, tokenIndex1035, depth1035 := position, tokenIndex, depth
+											if !matchDot() {
+												goto l1035
+											}
+											goto l1034
+										l1035:
+											position, tokenIndex, depth = position1035, tokenIndex1035, depth1035
 										}
-										position++
-									}
-								l110:
-									{
-										position112, tokenIndex112, depth112 := position, tokenIndex, depth
-										if buffer[position] != rune('e') {
-											goto l113
+										goto l1033
+									l1034:
+										position, tokenIndex, depth = position1033, tokenIndex1033, depth1033
+										if !_rules[rule_]() {
+											goto l1030
 										}
-										position++
-										goto l112
-									l113:
-										position, tokenIndex, depth = position112, tokenIndex112, depth112
-										if buffer[position] != rune('E') {
-											goto l103
+										if !(p.errorExpecting(position, expect(`end of input`), `after 'describe functions' and optional match clause but got %q`, p.after(position))) {
+											goto l1030
 										}
-										position++
-									}
-								l112:
-									if !_rules[ruleKEY]() {
-										goto l103
-									}
-									if !(p.errorHere(position, `encountered "where" after property clause; "where" blocks must go BEFORE 'from' and 'to' specifiers`)) {
-										goto l103
 									}
-									goto l23
-								l103:
-									position, tokenIndex, depth = position23, tokenIndex23, depth23
+								l1033:
+									position, tokenIndex, depth = position1032, tokenIndex1032, depth1032
+								}
+								depth--
+								add(ruledescribeFunctions, position1031)
+							}
+							goto l135
+						l1030:
+							position, tokenIndex, depth = position135, tokenIndex135, depth135
+							{
+								position202 := position
+								depth++
+								{
+									position203, tokenIndex203, depth203 := position, tokenIndex, depth
 									if !_rules[rule_]() {
-										goto l22
+										goto l204
 									}
 									{
-										position114, tokenIndex114, depth114 := position, tokenIndex, depth
+										position205 := position
+										depth++
 										{
-											position115, tokenIndex115, depth115 := position, tokenIndex, depth
-											if !matchDot() {
-												goto l115
+											position206 := position
+											depth++
+											if !_rules[ruleIDENTIFIER]() {
+												goto l204
 											}
-											goto l114
-										l115:
-											position, tokenIndex, depth = position115, tokenIndex115, depth115
+											depth--
+											add(ruleMETRIC_NAME, position206)
 										}
-										goto l22
-									l114:
-										position, tokenIndex, depth = position114, tokenIndex114, depth114
+										depth--
+										add(rulePegText, position205)
 									}
-									if !(p.errorHere(position, `expected key (one of 'from', 'to', 'resolution', or 'sample by') or end of input but got %q following a completed expression`, p.after(position))) {
-										goto l22
+									{
+										add(ruleAction7, position)
+									}
+									goto l203
+								l204:
+									position, tokenIndex, depth = position203, tokenIndex203, depth203
+									if !(p.errorExpecting(position, expect(`metric name`), `to follow "describe" in "describe" command`)) {
+										goto l0
 									}
 								}
-							l23:
-								goto l21
-							l22:
-								position, tokenIndex, depth = position22, tokenIndex22, depth22
-							}
-							{
-								add(ruleAction11, position)
+							l203:
+								if !_rules[ruleoptionalPredicateClause]() {
+									goto l0
+								}
+								{
+									add(ruleAction8, position)
+								}
+								depth--
+								add(ruledescribeSingleStmt, position202)
 							}
-							depth--
-							add(rulepropertyClause, position19)
-						}
-						{
-							add(ruleAction0, position)
 						}
+					l135:
 						depth--
-						add(ruleselectStmt, position4)
+						add(ruledescribeStmt, position118)
 					}
-					goto l2
-				l3:
-					position, tokenIndex, depth = position2, tokenIndex2, depth2
+				}
+			l2:
+				if !_rules[rule_]() {
+					goto l0
+				}
+				{
+					position209, tokenIndex209, depth209 := position, tokenIndex, depth
+					if !matchDot() {
+						goto l209
+					}
+					goto l0
+				l209:
+					position, tokenIndex, depth = position209, tokenIndex209, depth209
+				}
+				depth--
+				add(ruleroot, position1)
+			}
+			return true
+		l0:
+			position, tokenIndex, depth = position0, tokenIndex0, depth0
+			return false
+		},
+		/* 1 explainStmt <- <(_ (('e' / 'E') ('x' / 'X') ('p' / 'P') ('l' / 'L') ('a' / 'A') ('i' / 'I') ('n' / 'N')) KEY (selectStmt / &{ p.errorExpecting(position, expect(`select statement`), `to follow keyword "explain"`) }) Action0)> */
+		nil,
+		/* 2 selectStmt <- <(_ (('s' / 'S') ('e' / 'E') ('l' / 'L') ('e' / 'E') ('c' / 'C') ('t' / 'T') KEY)? expressionList &{ p.setContext("after expression of select statement") } optionalPredicateClause &{ p.setContext("") } propertyClause Action1)> */
+		func() bool {
+			position1011, tokenIndex1011, depth1011 := position, tokenIndex, depth
+			{
+				position4 := position
+				depth++
+				if !_rules[rule_]() {
+					goto l1011
+				}
+				{
+					position5, tokenIndex5, depth5 := position, tokenIndex, depth
 					{
-						position118 := position
-						depth++
-						if !_rules[rule_]() {
-							goto l0
+						position7, tokenIndex7, depth7 := position, tokenIndex, depth
+						if buffer[position] != rune('s') {
+							goto l8
 						}
-						{
-							position119, tokenIndex119, depth119 := position, tokenIndex, depth
-							if buffer[position] != rune('d') {
-								goto l120
-							}
-							position++
-							goto l119
-						l120:
-							position, tokenIndex, depth = position119, tokenIndex119, depth119
-							if buffer[position] != rune('D') {
-								goto l0
-							}
-							position++
+						position++
+						goto l7
+					l8:
+						position, tokenIndex, depth = position7, tokenIndex7, depth7
+						if buffer[position] != rune('S') {
+							goto l5
 						}
-					l119:
-						{
-							position121, tokenIndex121, depth121 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l122
-							}
-							position++
-							goto l121
-						l122:
-							position, tokenIndex, depth = position121, tokenIndex121, depth121
-							if buffer[position] != rune('E') {
-								goto l0
-							}
-							position++
+						position++
+					}
+				l7:
+					{
+						position9, tokenIndex9, depth9 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l10
 						}
-					l121:
-						{
-							position123, tokenIndex123, depth123 := position, tokenIndex, depth
-							if buffer[position] != rune('s') {
-								goto l124
-							}
-							position++
-							goto l123
-						l124:
-							position, tokenIndex, depth = position123, tokenIndex123, depth123
-							if buffer[position] != rune('S') {
-								goto l0
-							}
-							position++
+						position++
+						goto l9
+					l10:
+						position, tokenIndex, depth = position9, tokenIndex9, depth9
+						if buffer[position] != rune('E') {
+							goto l5
+						}
+						position++
+					}
+				l9:
+					{
+						position11, tokenIndex11, depth11 := position, tokenIndex, depth
+						if buffer[position] != rune('l') {
+							goto l12
 						}
-					l123:
-						{
-							position125, tokenIndex125, depth125 := position, tokenIndex, depth
-							if buffer[position] != rune('c') {
-								goto l126
-							}
-							position++
-							goto l125
-						l126:
-							position, tokenIndex, depth = position125, tokenIndex125, depth125
-							if buffer[position] != rune('C') {
-								goto l0
-							}
-							position++
+						position++
+						goto l11
+					l12:
+						position, tokenIndex, depth = position11, tokenIndex11, depth11
+						if buffer[position] != rune('L') {
+							goto l5
 						}
-					l125:
-						{
-							position127, tokenIndex127, depth127 := position, tokenIndex, depth
-							if buffer[position] != rune('r') {
-								goto l128
-							}
-							position++
-							goto l127
-						l128:
-							position, tokenIndex, depth = position127, tokenIndex127, depth127
-							if buffer[position] != rune('R') {
-								goto l0
-							}
-							position++
+						position++
+					}
+				l11:
+					{
+						position13, tokenIndex13, depth13 := position, tokenIndex, depth
+						if buffer[position] != rune('e') {
+							goto l14
 						}
-					l127:
-						{
-							position129, tokenIndex129, depth129 := position, tokenIndex, depth
-							if buffer[position] != rune('i') {
-								goto l130
-							}
-							position++
-							goto l129
-						l130:
-							position, tokenIndex, depth = position129, tokenIndex129, depth129
-							if buffer[position] != rune('I') {
-								goto l0
-							}
-							position++
+						position++
+						goto l13
+					l14:
+						position, tokenIndex, depth = position13, tokenIndex13, depth13
+						if buffer[position] != rune('E') {
+							goto l5
 						}
-					l129:
-						{
-							position131, tokenIndex131, depth131 := position, tokenIndex, depth
-							if buffer[position] != rune('b') {
-								goto l132
-							}
-							position++
-							goto l131
-						l132:
-							position, tokenIndex, depth = position131, tokenIndex131, depth131
-							if buffer[position] != rune('B') {
-								goto l0
-							}
-							position++
+						position++
+					}
+				l13:
+					{
+						position15, tokenIndex15, depth15 := position, tokenIndex, depth
+						if buffer[position] != rune('c') {
+							goto l16
 						}
-					l131:
-						{
-							position133, tokenIndex133, depth133 := position, tokenIndex, depth
-							if buffer[position] != rune('e') {
-								goto l134
-							}
-							position++
-							goto l133
-						l134:
-							position, tokenIndex, depth = position133, tokenIndex133, depth133
-							if buffer[position] != rune('E') {
-								goto l0
-							}
-							position++
+						position++
+						goto l15
+					l16:
+						position, tokenIndex, depth = position15, tokenIndex15, depth15
+						if buffer[position] != rune('C') {
+							goto l5
 						}
-					l133:
-						if !_rules[ruleKEY]() {
-							goto l0
+						position++
+					}
+				l15:
+					{
+						position17, tokenIndex17, depth17 := position, tokenIndex, depth
+						if buffer[position] != rune('t') {
+							goto l18
+						}
+						position++
+						goto l17
+					l18:
+						position, tokenIndex, depth = position17, tokenIndex17, depth17
+						if buffer[position] != rune('T') {
+							goto l5
 						}
+						position++
+					}
+				l17:
+					if !_rules[ruleKEY]() {
+						goto l5
+					}
+					goto l6
+				l5:
+					position, tokenIndex, depth = position5, tokenIndex5, depth5
+				}
+			l6:
+				if !_rules[ruleexpressionList]() {
+					goto l1011
+				}
+				if !(p.setContext("after expression of select statement")) {
+					goto l1011
+				}
+				if !_rules[ruleoptionalPredicateClause]() {
+					goto l1011
+				}
+				if !(p.setContext("")) {
+					goto l1011
+				}
+				{
+					position19 := position
+					depth++
+					{
+						add(ruleAction9, position)
+					}
+				l21:
+					{
+						position22, tokenIndex22, depth22 := position, tokenIndex, depth
 						{
-							position135, tokenIndex135, depth135 := position, tokenIndex, depth
+							position23, tokenIndex23, depth23 := position, tokenIndex, depth
+							if !_rules[rule_]() {
+								goto l24
+							}
 							{
-								position137 := position
+								position25 := position
 								depth++
-								if !_rules[rule_]() {
-									goto l136
-								}
-								{
-									position138, tokenIndex138, depth138 := position, tokenIndex, depth
-									if buffer[position] != rune('a') {
-										goto l139
-									}
-									position++
-									goto l138
-								l139:
-									position, tokenIndex, depth = position138, tokenIndex138, depth138
-									if buffer[position] != rune('A') {
-										goto l136
-									}
-									position++
-								}
-							l138:
-								{
-									position140, tokenIndex140, depth140 := position, tokenIndex, depth
-									if buffer[position] != rune('l') {
-										goto l141
-									}
-									position++
-									goto l140
-								l141:
-									position, tokenIndex, depth = position140, tokenIndex140, depth140
-									if buffer[position] != rune('L') {
-										goto l136
-									}
-									position++
-								}
-							l140:
-								{
-									position142, tokenIndex142, depth142 := position, tokenIndex, depth
-									if buffer[position] != rune('l') {
-										goto l143
-									}
-									position++
-									goto l142
-								l143:
-									position, tokenIndex, depth = position142, tokenIndex142, depth142
-									if buffer[position] != rune('L') {
-										goto l136
-									}
-									position++
-								}
-							l142:
-								if !_rules[ruleKEY]() {
-									goto l136
-								}
 								{
-									position144 := position
-									depth++
-									{
-										position145, tokenIndex145, depth145 := position, tokenIndex, depth
+									switch buffer[position] {
+									case 'S', 's':
 										{
-											position147 := position
+											position27 := position
 											depth++
+											{
+												position28, tokenIndex28, depth28 := position, tokenIndex, depth
+												if buffer[position] != rune('s') {
+													goto l29
+												}
+												position++
+												goto l28
+											l29:
+												position, tokenIndex, depth = position28, tokenIndex28, depth28
+												if buffer[position] != rune('S') {
+													goto l24
+												}
+												position++
+											}
+										l28:
+											{
+												position30, tokenIndex30, depth30 := position, tokenIndex, depth
+												if buffer[position] != rune('a') {
+													goto l31
+												}
+												position++
+												goto l30
+											l31:
+												position, tokenIndex, depth = position30, tokenIndex30, depth30
+												if buffer[position] != rune('A') {
+													goto l24
+												}
+												position++
+											}
+										l30:
+											{
+												position32, tokenIndex32, depth32 := position, tokenIndex, depth
+												if buffer[position] != rune('m') {
+													goto l33
+												}
+												position++
+												goto l32
+											l33:
+												position, tokenIndex, depth = position32, tokenIndex32, depth32
+												if buffer[position] != rune('M') {
+													goto l24
+												}
+												position++
+											}
+										l32:
+											{
+												position34, tokenIndex34, depth34 := position, tokenIndex, depth
+												if buffer[position] != rune('p') {
+													goto l35
+												}
+												position++
+												goto l34
+											l35:
+												position, tokenIndex, depth = position34, tokenIndex34, depth34
+												if buffer[position] != rune('P') {
+													goto l24
+												}
+												position++
+											}
+										l34:
+											{
+												position36, tokenIndex36, depth36 := position, tokenIndex, depth
+												if buffer[position] != rune('l') {
+													goto l37
+												}
+												position++
+												goto l36
+											l37:
+												position, tokenIndex, depth = position36, tokenIndex36, depth36
+												if buffer[position] != rune('L') {
+													goto l24
+												}
+												position++
+											}
+										l36:
+											{
+												position38, tokenIndex38, depth38 := position, tokenIndex, depth
+												if buffer[position] != rune('e') {
+													goto l39
+												}
+												position++
+												goto l38
+											l39:
+												position, tokenIndex, depth = position38, tokenIndex38, depth38
+												if buffer[position] != rune('E') {
+													goto l24
+												}
+												position++
+											}
+										l38:
+											depth--
+											add(rulePegText, position27)
+										}
+										if !_rules[ruleKEY]() {
+											goto l24
+										}
+										{
+											position40, tokenIndex40, depth40 := position, tokenIndex, depth
 											if !_rules[rule_]() {
-												goto l146
+												goto l41
+											}
+											{
+												position42, tokenIndex42, depth42 := position, tokenIndex, depth
+												if buffer[position] != rune('b') {
+													goto l43
+												}
+												position++
+												goto l42
+											l43:
+												position, tokenIndex, depth = position42, tokenIndex42, depth42
+												if buffer[position] != rune('B') {
+													goto l41
+												}
+												position++
+											}
+										l42:
+											{
+												position44, tokenIndex44, depth44 := position, tokenIndex, depth
+												if buffer[position] != rune('y') {
+													goto l45
+												}
+												position++
+												goto l44
+											l45:
+												position, tokenIndex, depth = position44, tokenIndex44, depth44
+												if buffer[position] != rune('Y') {
+													goto l41
+												}
+												position++
+											}
+										l44:
+											if !_rules[ruleKEY]() {
+												goto l41
+											}
+											goto l40
+										l41:
+											position, tokenIndex, depth = position40, tokenIndex40, depth40
+											if !(p.errorExpecting(position, expect(`keyword "by"`), `to follow keyword "sample"`)) {
+												goto l24
+											}
+										}
+									l40:
+										break
+									case 'R', 'r':
+										{
+											position46 := position
+											depth++
+											{
+												position47, tokenIndex47, depth47 := position, tokenIndex, depth
+												if buffer[position] != rune('r') {
+													goto l48
+												}
+												position++
+												goto l47
+											l48:
+												position, tokenIndex, depth = position47, tokenIndex47, depth47
+												if buffer[position] != rune('R') {
+													goto l24
+												}
+												position++
+											}
+										l47:
+											{
+												position49, tokenIndex49, depth49 := position, tokenIndex, depth
+												if buffer[position] != rune('e') {
+													goto l50
+												}
+												position++
+												goto l49
+											l50:
+												position, tokenIndex, depth = position49, tokenIndex49, depth49
+												if buffer[position] != rune('E') {
+													goto l24
+												}
+												position++
+											}
+										l49:
+											{
+												position51, tokenIndex51, depth51 := position, tokenIndex, depth
+												if buffer[position] != rune('s') {
+													goto l52
+												}
+												position++
+												goto l51
+											l52:
+												position, tokenIndex, depth = position51, tokenIndex51, depth51
+												if buffer[position] != rune('S') {
+													goto l24
+												}
+												position++
+											}
+										l51:
+											{
+												position53, tokenIndex53, depth53 := position, tokenIndex, depth
+												if buffer[position] != rune('o') {
+													goto l54
+												}
+												position++
+												goto l53
+											l54:
+												position, tokenIndex, depth = position53, tokenIndex53, depth53
+												if buffer[position] != rune('O') {
+													goto l24
+												}
+												position++
 											}
+										l53:
 											{
-												position148, tokenIndex148, depth148 := position, tokenIndex, depth
-												if buffer[position] != rune('m') {
-													goto l149
+												position55, tokenIndex55, depth55 := position, tokenIndex, depth
+												if buffer[position] != rune('l') {
+													goto l56
 												}
 												position++
-												goto l148
-											l149:
-												position, tokenIndex, depth = position148, tokenIndex148, depth148
-												if buffer[position] != rune('M') {
-													goto l146
+												goto l55
+											l56:
+												position, tokenIndex, depth = position55, tokenIndex55, depth55
+												if buffer[position] != rune('L') {
+													goto l24
 												}
 												position++
 											}
-										l148:
+										l55:
 											{
-												position150, tokenIndex150, depth150 := position, tokenIndex, depth
-												if buffer[position] != rune('a') {
-													goto l151
+												position57, tokenIndex57, depth57 := position, tokenIndex, depth
+												if buffer[position] != rune('u') {
+													goto l58
 												}
 												position++
-												goto l150
-											l151:
-												position, tokenIndex, depth = position150, tokenIndex150, depth150
-												if buffer[position] != rune('A') {
-													goto l146
+												goto l57
+											l58:
+												position, tokenIndex, depth = position57, tokenIndex57, depth57
+												if buffer[position] != rune('U') {
+													goto l24
 												}
 												position++
 											}
-										l150:
+										l57:
 											{
-												position152, tokenIndex152, depth152 := position, tokenIndex, depth
+												position59, tokenIndex59, depth59 := position, tokenIndex, depth
 												if buffer[position] != rune('t') {
-													goto l153
+													goto l60
 												}
 												position++
-												goto l152
-											l153:
-												position, tokenIndex, depth = position152, tokenIndex152, depth152
+												goto l59
+											l60:
+												position, tokenIndex, depth = position59, tokenIndex59, depth59
 												if buffer[position] != rune('T') {
-													goto l146
+													goto l24
+												}
+												position++
+											}
+										l59:
+											{
+												position61, tokenIndex61, depth61 := position, tokenIndex, depth
+												if buffer[position] != rune('i') {
+													goto l62
+												}
+												position++
+												goto l61
+											l62:
+												position, tokenIndex, depth = position61, tokenIndex61, depth61
+												if buffer[position] != rune('I') {
+													goto l24
 												}
 												position++
 											}
-										l152:
+										l61:
 											{
-												position154, tokenIndex154, depth154 := position, tokenIndex, depth
-												if buffer[position] != rune('c') {
-													goto l155
+												position63, tokenIndex63, depth63 := position, tokenIndex, depth
+												if buffer[position] != rune('o') {
+													goto l64
 												}
 												position++
-												goto l154
-											l155:
-												position, tokenIndex, depth = position154, tokenIndex154, depth154
-												if buffer[position] != rune('C') {
-													goto l146
+												goto l63
+											l64:
+												position, tokenIndex, depth = position63, tokenIndex63, depth63
+												if buffer[position] != rune('O') {
+													goto l24
 												}
 												position++
 											}
-										l154:
+										l63:
 											{
-												position156, tokenIndex156, depth156 := position, tokenIndex, depth
-												if buffer[position] != rune('h') {
-													goto l157
+												position65, tokenIndex65, depth65 := position, tokenIndex, depth
+												if buffer[position] != rune('n') {
+													goto l66
 												}
 												position++
-												goto l156
-											l157:
-												position, tokenIndex, depth = position156, tokenIndex156, depth156
-												if buffer[position] != rune('H') {
-													goto l146
+												goto l65
+											l66:
+												position, tokenIndex, depth = position65, tokenIndex65, depth65
+												if buffer[position] != rune('N') {
+													goto l24
 												}
 												position++
 											}
-										l156:
+										l65:
+											depth--
+											add(rulePegText, position46)
+										}
+										if !_rules[ruleKEY]() {
+											goto l24
+										}
+										break
+									case 'T', 't':
+										{
+											position831, tokenIndex831, depth831 := position, tokenIndex, depth
+											{
+												position67 := position
+												depth++
+												{
+													position68, tokenIndex68, depth68 := position, tokenIndex, depth
+													if buffer[position] != rune('t') {
+														goto l69
+													}
+													position++
+													goto l68
+												l69:
+													position, tokenIndex, depth = position68, tokenIndex68, depth68
+													if buffer[position] != rune('T') {
+														goto l832
+													}
+													position++
+												}
+											l68:
+												{
+													position70, tokenIndex70, depth70 := position, tokenIndex, depth
+													if buffer[position] != rune('o') {
+														goto l71
+													}
+													position++
+													goto l70
+												l71:
+													position, tokenIndex, depth = position70, tokenIndex70, depth70
+													if buffer[position] != rune('O') {
+														goto l832
+													}
+													position++
+												}
+											l70:
+												depth--
+												add(rulePegText, position67)
+											}
+											if !_rules[ruleKEY]() {
+												goto l832
+											}
+											goto l831
+										l832:
+											position, tokenIndex, depth = position831, tokenIndex831, depth831
+											{
+												position833 := position
+												depth++
+												{
+													position834, tokenIndex834, depth834 := position, tokenIndex, depth
+													if buffer[position] != rune('t') {
+														goto l835
+													}
+													position++
+													goto l834
+												l835:
+													position, tokenIndex, depth = position834, tokenIndex834, depth834
+													if buffer[position] != rune('T') {
+														goto l24
+													}
+													position++
+												}
+											l834:
+												{
+													position836, tokenIndex836, depth836 := position, tokenIndex, depth
+													if buffer[position] != rune('i') {
+														goto l837
+													}
+													position++
+													goto l836
+												l837:
+													position, tokenIndex, depth = position836, tokenIndex836, depth836
+													if buffer[position] != rune('I') {
+														goto l24
+													}
+													position++
+												}
+											l836:
+												{
+													position838, tokenIndex838, depth838 := position, tokenIndex, depth
+													if buffer[position] != rune('m') {
+														goto l839
+													}
+													position++
+													goto l838
+												l839:
+													position, tokenIndex, depth = position838, tokenIndex838, depth838
+													if buffer[position] != rune('M') {
+														goto l24
+													}
+													position++
+												}
+											l838:
+												{
+													position840, tokenIndex840, depth840 := position, tokenIndex, depth
+													if buffer[position] != rune('e') {
+														goto l841
+													}
+													position++
+													goto l840
+												l841:
+													position, tokenIndex, depth = position840, tokenIndex840, depth840
+													if buffer[position] != rune('E') {
+														goto l24
+													}
+													position++
+												}
+											l840:
+												{
+													position842, tokenIndex842, depth842 := position, tokenIndex, depth
+													if buffer[position] != rune('z') {
+														goto l843
+													}
+													position++
+													goto l842
+												l843:
+													position, tokenIndex, depth = position842, tokenIndex842, depth842
+													if buffer[position] != rune('Z') {
+														goto l24
+													}
+													position++
+												}
+											l842:
+												{
+													position844, tokenIndex844, depth844 := position, tokenIndex, depth
+													if buffer[position] != rune('o') {
+														goto l845
+													}
+													position++
+													goto l844
+												l845:
+													position, tokenIndex, depth = position844, tokenIndex844, depth844
+													if buffer[position] != rune('O') {
+														goto l24
+													}
+													position++
+												}
+											l844:
+												{
+													position846, tokenIndex846, depth846 := position, tokenIndex, depth
+													if buffer[position] != rune('n') {
+														goto l847
+													}
+													position++
+													goto l846
+												l847:
+													position, tokenIndex, depth = position846, tokenIndex846, depth846
+													if buffer[position] != rune('N') {
+														goto l24
+													}
+													position++
+												}
+											l846:
+												{
+													position848, tokenIndex848, depth848 := position, tokenIndex, depth
+													if buffer[position] != rune('e') {
+														goto l849
+													}
+													position++
+													goto l848
+												l849:
+													position, tokenIndex, depth = position848, tokenIndex848, depth848
+													if buffer[position] != rune('E') {
+														goto l24
+													}
+													position++
+												}
+											l848:
+												depth--
+												add(rulePegText, position833)
+											}
 											if !_rules[ruleKEY]() {
-												goto l146
+												goto l24
+											}
+										}
+									l831:
+										break
+									default:
+										{
+											position72 := position
+											depth++
+											{
+												position73, tokenIndex73, depth73 := position, tokenIndex, depth
+												if buffer[position] != rune('f') {
+													goto l74
+												}
+												position++
+												goto l73
+											l74:
+												position, tokenIndex, depth = position73, tokenIndex73, depth73
+												if buffer[position] != rune('F') {
+													goto l24
+												}
+												position++
+											}
+										l73:
+											{
+												position75, tokenIndex75, depth75 := position, tokenIndex, depth
+												if buffer[position] != rune('r') {
+													goto l76
+												}
+												position++
+												goto l75
+											l76:
+												position, tokenIndex, depth = position75, tokenIndex75, depth75
+												if buffer[position] != rune('R') {
+													goto l24
+												}
+												position++
+											}
+										l75:
+											{
+												position77, tokenIndex77, depth77 := position, tokenIndex, depth
+												if buffer[position] != rune('o') {
+													goto l78
+												}
+												position++
+												goto l77
+											l78:
+												position, tokenIndex, depth = position77, tokenIndex77, depth77
+												if buffer[position] != rune('O') {
+													goto l24
+												}
+												position++
+											}
+										l77:
+											{
+												position79, tokenIndex79, depth79 := position, tokenIndex, depth
+												if buffer[position] != rune('m') {
+													goto l80
+												}
+												position++
+												goto l79
+											l80:
+												position, tokenIndex, depth = position79, tokenIndex79, depth79
+												if buffer[position] != rune('M') {
+													goto l24
+												}
+												position++
+											}
+										l79:
+											depth--
+											add(rulePegText, position72)
+										}
+										if !_rules[ruleKEY]() {
+											goto l24
+										}
+										break
+									}
+								}
+
+								depth--
+								add(rulePROPERTY_KEY, position25)
+							}
+							{
+								add(ruleAction10, position)
+							}
+							{
+								position82, tokenIndex82, depth82 := position, tokenIndex, depth
+								if !_rules[rule_]() {
+									goto l83
+								}
+								{
+									position84 := position
+									depth++
+									{
+										position85 := position
+										depth++
+										{
+											position86, tokenIndex86, depth86 := position, tokenIndex, depth
+											if !_rules[rule_]() {
+												goto l87
+											}
+											{
+												position88 := position
+												depth++
+												if !_rules[ruleNUMBER]() {
+													goto l87
+												}
+											l89:
+												{
+													position90, tokenIndex90, depth90 := position, tokenIndex, depth
+													{
+														position91, tokenIndex91, depth91 := position, tokenIndex, depth
+														if c := buffer[position]; c < rune('a') || c > rune('z') {
+															goto l92
+														}
+														position++
+														goto l91
+													l92:
+														position, tokenIndex, depth = position91, tokenIndex91, depth91
+														if c := buffer[position]; c < rune('A') || c > rune('Z') {
+															goto l90
+														}
+														position++
+													}
+												l91:
+													goto l89
+												l90:
+													position, tokenIndex, depth = position90, tokenIndex90, depth90
+												}
+												depth--
+												add(rulePegText, position88)
+											}
+											goto l86
+										l87:
+											position, tokenIndex, depth = position86, tokenIndex86, depth86
+											if !_rules[rule_]() {
+												goto l93
+											}
+											if !_rules[ruleSTRING]() {
+												goto l93
+											}
+											goto l86
+										l93:
+											position, tokenIndex, depth = position86, tokenIndex86, depth86
+											if !_rules[rule_]() {
+												goto l83
 											}
 											{
-												position158, tokenIndex158, depth158 := position, tokenIndex, depth
-												if !_rules[ruleliteralString]() {
-													goto l159
+												position850 := position
+												depth++
+												{
+													position851, tokenIndex851, depth851 := position, tokenIndex, depth
+													{
+														position853, tokenIndex853, depth853 := position, tokenIndex, depth
+														if buffer[position] != rune('n') {
+															goto l854
+														}
+														position++
+														goto l853
+													l854:
+														position, tokenIndex, depth = position853, tokenIndex853, depth853
+														if buffer[position] != rune('N') {
+															goto l852
+														}
+														position++
+													}
+												l853:
+													{
+														position855, tokenIndex855, depth855 := position, tokenIndex, depth
+														if buffer[position] != rune('o') {
+															goto l856
+														}
+														position++
+														goto l855
+													l856:
+														position, tokenIndex, depth = position855, tokenIndex855, depth855
+														if buffer[position] != rune('O') {
+															goto l852
+														}
+														position++
+													}
+												l855:
+													{
+														position857, tokenIndex857, depth857 := position, tokenIndex, depth
+														if buffer[position] != rune('w') {
+															goto l858
+														}
+														position++
+														goto l857
+													l858:
+														position, tokenIndex, depth = position857, tokenIndex857, depth857
+														if buffer[position] != rune('W') {
+															goto l852
+														}
+														position++
+													}
+												l857:
+													goto l851
+												l852:
+													position, tokenIndex, depth = position851, tokenIndex851, depth851
+													{
+														position860, tokenIndex860, depth860 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l861
+														}
+														position++
+														goto l860
+													l861:
+														position, tokenIndex, depth = position860, tokenIndex860, depth860
+														if buffer[position] != rune('T') {
+															goto l859
+														}
+														position++
+													}
+												l860:
+													{
+														position862, tokenIndex862, depth862 := position, tokenIndex, depth
+														if buffer[position] != rune('o') {
+															goto l863
+														}
+														position++
+														goto l862
+													l863:
+														position, tokenIndex, depth = position862, tokenIndex862, depth862
+														if buffer[position] != rune('O') {
+															goto l859
+														}
+														position++
+													}
+												l862:
+													{
+														position864, tokenIndex864, depth864 := position, tokenIndex, depth
+														if buffer[position] != rune('d') {
+															goto l865
+														}
+														position++
+														goto l864
+													l865:
+														position, tokenIndex, depth = position864, tokenIndex864, depth864
+														if buffer[position] != rune('D') {
+															goto l859
+														}
+														position++
+													}
+												l864:
+													{
+														position866, tokenIndex866, depth866 := position, tokenIndex, depth
+														if buffer[position] != rune('a') {
+															goto l867
+														}
+														position++
+														goto l866
+													l867:
+														position, tokenIndex, depth = position866, tokenIndex866, depth866
+														if buffer[position] != rune('A') {
+															goto l859
+														}
+														position++
+													}
+												l866:
+													{
+														position868, tokenIndex868, depth868 := position, tokenIndex, depth
+														if buffer[position] != rune('y') {
+															goto l869
+														}
+														position++
+														goto l868
+													l869:
+														position, tokenIndex, depth = position868, tokenIndex868, depth868
+														if buffer[position] != rune('Y') {
+															goto l859
+														}
+														position++
+													}
+												l868:
+													goto l851
+												l859:
+													position, tokenIndex, depth = position851, tokenIndex851, depth851
+													{
+														position871, tokenIndex871, depth871 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l872
+														}
+														position++
+														goto l871
+													l872:
+														position, tokenIndex, depth = position871, tokenIndex871, depth871
+														if buffer[position] != rune('T') {
+															goto l870
+														}
+														position++
+													}
+												l871:
+													{
+														position873, tokenIndex873, depth873 := position, tokenIndex, depth
+														if buffer[position] != rune('o') {
+															goto l874
+														}
+														position++
+														goto l873
+													l874:
+														position, tokenIndex, depth = position873, tokenIndex873, depth873
+														if buffer[position] != rune('O') {
+															goto l870
+														}
+														position++
+													}
+												l873:
+													{
+														position875, tokenIndex875, depth875 := position, tokenIndex, depth
+														if buffer[position] != rune('m') {
+															goto l876
+														}
+														position++
+														goto l875
+													l876:
+														position, tokenIndex, depth = position875, tokenIndex875, depth875
+														if buffer[position] != rune('M') {
+															goto l870
+														}
+														position++
+													}
+												l875:
+													{
+														position877, tokenIndex877, depth877 := position, tokenIndex, depth
+														if buffer[position] != rune('o') {
+															goto l878
+														}
+														position++
+														goto l877
+													l878:
+														position, tokenIndex, depth = position877, tokenIndex877, depth877
+														if buffer[position] != rune('O') {
+															goto l870
+														}
+														position++
+													}
+												l877:
+													{
+														position879, tokenIndex879, depth879 := position, tokenIndex, depth
+														if buffer[position] != rune('r') {
+															goto l880
+														}
+														position++
+														goto l879
+													l880:
+														position, tokenIndex, depth = position879, tokenIndex879, depth879
+														if buffer[position] != rune('R') {
+															goto l870
+														}
+														position++
+													}
+												l879:
+													{
+														position881, tokenIndex881, depth881 := position, tokenIndex, depth
+														if buffer[position] != rune('r') {
+															goto l882
+														}
+														position++
+														goto l881
+													l882:
+														position, tokenIndex, depth = position881, tokenIndex881, depth881
+														if buffer[position] != rune('R') {
+															goto l870
+														}
+														position++
+													}
+												l881:
+													{
+														position883, tokenIndex883, depth883 := position, tokenIndex, depth
+														if buffer[position] != rune('o') {
+															goto l884
+														}
+														position++
+														goto l883
+													l884:
+														position, tokenIndex, depth = position883, tokenIndex883, depth883
+														if buffer[position] != rune('O') {
+															goto l870
+														}
+														position++
+													}
+												l883:
+													{
+														position885, tokenIndex885, depth885 := position, tokenIndex, depth
+														if buffer[position] != rune('w') {
+															goto l886
+														}
+														position++
+														goto l885
+													l886:
+														position, tokenIndex, depth = position885, tokenIndex885, depth885
+														if buffer[position] != rune('W') {
+															goto l870
+														}
+														position++
+													}
+												l885:
+													goto l851
+												l870:
+													position, tokenIndex, depth = position851, tokenIndex851, depth851
+													{
+														position888, tokenIndex888, depth888 := position, tokenIndex, depth
+														if buffer[position] != rune('y') {
+															goto l889
+														}
+														position++
+														goto l888
+													l889:
+														position, tokenIndex, depth = position888, tokenIndex888, depth888
+														if buffer[position] != rune('Y') {
+															goto l887
+														}
+														position++
+													}
+												l888:
+													{
+														position890, tokenIndex890, depth890 := position, tokenIndex, depth
+														if buffer[position] != rune('e') {
+															goto l891
+														}
+														position++
+														goto l890
+													l891:
+														position, tokenIndex, depth = position890, tokenIndex890, depth890
+														if buffer[position] != rune('E') {
+															goto l887
+														}
+														position++
+													}
+												l890:
+													{
+														position892, tokenIndex892, depth892 := position, tokenIndex, depth
+														if buffer[position] != rune('s') {
+															goto l893
+														}
+														position++
+														goto l892
+													l893:
+														position, tokenIndex, depth = position892, tokenIndex892, depth892
+														if buffer[position] != rune('S') {
+															goto l887
+														}
+														position++
+													}
+												l892:
+													{
+														position894, tokenIndex894, depth894 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l895
+														}
+														position++
+														goto l894
+													l895:
+														position, tokenIndex, depth = position894, tokenIndex894, depth894
+														if buffer[position] != rune('T') {
+															goto l887
+														}
+														position++
+													}
+												l894:
+													{
+														position896, tokenIndex896, depth896 := position, tokenIndex, depth
+														if buffer[position] != rune('e') {
+															goto l897
+														}
+														position++
+														goto l896
+													l897:
+														position, tokenIndex, depth = position896, tokenIndex896, depth896
+														if buffer[position] != rune('E') {
+															goto l887
+														}
+														position++
+													}
+												l896:
+													{
+														position898, tokenIndex898, depth898 := position, tokenIndex, depth
+														if buffer[position] != rune('r') {
+															goto l899
+														}
+														position++
+														goto l898
+													l899:
+														position, tokenIndex, depth = position898, tokenIndex898, depth898
+														if buffer[position] != rune('R') {
+															goto l887
+														}
+														position++
+													}
+												l898:
+													{
+														position900, tokenIndex900, depth900 := position, tokenIndex, depth
+														if buffer[position] != rune('d') {
+															goto l901
+														}
+														position++
+														goto l900
+													l901:
+														position, tokenIndex, depth = position900, tokenIndex900, depth900
+														if buffer[position] != rune('D') {
+															goto l887
+														}
+														position++
+													}
+												l900:
+													{
+														position902, tokenIndex902, depth902 := position, tokenIndex, depth
+														if buffer[position] != rune('a') {
+															goto l903
+														}
+														position++
+														goto l902
+													l903:
+														position, tokenIndex, depth = position902, tokenIndex902, depth902
+														if buffer[position] != rune('A') {
+															goto l887
+														}
+														position++
+													}
+												l902:
+													{
+														position904, tokenIndex904, depth904 := position, tokenIndex, depth
+														if buffer[position] != rune('y') {
+															goto l905
+														}
+														position++
+														goto l904
+													l905:
+														position, tokenIndex, depth = position904, tokenIndex904, depth904
+														if buffer[position] != rune('Y') {
+															goto l887
+														}
+														position++
+													}
+												l904:
+													goto l851
+												l887:
+													position, tokenIndex, depth = position851, tokenIndex851, depth851
+													{
+														position907, tokenIndex907, depth907 := position, tokenIndex, depth
+														if buffer[position] != rune('s') {
+															goto l908
+														}
+														position++
+														goto l907
+													l908:
+														position, tokenIndex, depth = position907, tokenIndex907, depth907
+														if buffer[position] != rune('S') {
+															goto l906
+														}
+														position++
+													}
+												l907:
+													{
+														position909, tokenIndex909, depth909 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l910
+														}
+														position++
+														goto l909
+													l910:
+														position, tokenIndex, depth = position909, tokenIndex909, depth909
+														if buffer[position] != rune('T') {
+															goto l906
+														}
+														position++
+													}
+												l909:
+													{
+														position911, tokenIndex911, depth911 := position, tokenIndex, depth
+														if buffer[position] != rune('a') {
+															goto l912
+														}
+														position++
+														goto l911
+													l912:
+														position, tokenIndex, depth = position911, tokenIndex911, depth911
+														if buffer[position] != rune('A') {
+															goto l906
+														}
+														position++
+													}
+												l911:
+													{
+														position913, tokenIndex913, depth913 := position, tokenIndex, depth
+														if buffer[position] != rune('r') {
+															goto l914
+														}
+														position++
+														goto l913
+													l914:
+														position, tokenIndex, depth = position913, tokenIndex913, depth913
+														if buffer[position] != rune('R') {
+															goto l906
+														}
+														position++
+													}
+												l913:
+													{
+														position915, tokenIndex915, depth915 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l916
+														}
+														position++
+														goto l915
+													l916:
+														position, tokenIndex, depth = position915, tokenIndex915, depth915
+														if buffer[position] != rune('T') {
+															goto l906
+														}
+														position++
+													}
+												l915:
+													{
+														position917, tokenIndex917, depth917 := position, tokenIndex, depth
+														if buffer[position] != rune('o') {
+															goto l918
+														}
+														position++
+														goto l917
+													l918:
+														position, tokenIndex, depth = position917, tokenIndex917, depth917
+														if buffer[position] != rune('O') {
+															goto l906
+														}
+														position++
+													}
+												l917:
+													{
+														position919, tokenIndex919, depth919 := position, tokenIndex, depth
+														if buffer[position] != rune('f') {
+															goto l920
+														}
+														position++
+														goto l919
+													l920:
+														position, tokenIndex, depth = position919, tokenIndex919, depth919
+														if buffer[position] != rune('F') {
+															goto l906
+														}
+														position++
+													}
+												l919:
+													{
+														position921, tokenIndex921, depth921 := position, tokenIndex, depth
+														if buffer[position] != rune('d') {
+															goto l922
+														}
+														position++
+														goto l921
+													l922:
+														position, tokenIndex, depth = position921, tokenIndex921, depth921
+														if buffer[position] != rune('D') {
+															goto l906
+														}
+														position++
+													}
+												l921:
+													{
+														position923, tokenIndex923, depth923 := position, tokenIndex, depth
+														if buffer[position] != rune('a') {
+															goto l924
+														}
+														position++
+														goto l923
+													l924:
+														position, tokenIndex, depth = position923, tokenIndex923, depth923
+														if buffer[position] != rune('A') {
+															goto l906
+														}
+														position++
+													}
+												l923:
+													{
+														position925, tokenIndex925, depth925 := position, tokenIndex, depth
+														if buffer[position] != rune('y') {
+															goto l926
+														}
+														position++
+														goto l925
+													l926:
+														position, tokenIndex, depth = position925, tokenIndex925, depth925
+														if buffer[position] != rune('Y') {
+															goto l906
+														}
+														position++
+													}
+												l925:
+													goto l851
+												l906:
+													position, tokenIndex, depth = position851, tokenIndex851, depth851
+													{
+														position928, tokenIndex928, depth928 := position, tokenIndex, depth
+														if buffer[position] != rune('s') {
+															goto l929
+														}
+														position++
+														goto l928
+													l929:
+														position, tokenIndex, depth = position928, tokenIndex928, depth928
+														if buffer[position] != rune('S') {
+															goto l927
+														}
+														position++
+													}
+												l928:
+													{
+														position930, tokenIndex930, depth930 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l931
+														}
+														position++
+														goto l930
+													l931:
+														position, tokenIndex, depth = position930, tokenIndex930, depth930
+														if buffer[position] != rune('T') {
+															goto l927
+														}
+														position++
+													}
+												l930:
+													{
+														position932, tokenIndex932, depth932 := position, tokenIndex, depth
+														if buffer[position] != rune('a') {
+															goto l933
+														}
+														position++
+														goto l932
+													l933:
+														position, tokenIndex, depth = position932, tokenIndex932, depth932
+														if buffer[position] != rune('A') {
+															goto l927
+														}
+														position++
+													}
+												l932:
+													{
+														position934, tokenIndex934, depth934 := position, tokenIndex, depth
+														if buffer[position] != rune('r') {
+															goto l935
+														}
+														position++
+														goto l934
+													l935:
+														position, tokenIndex, depth = position934, tokenIndex934, depth934
+														if buffer[position] != rune('R') {
+															goto l927
+														}
+														position++
+													}
+												l934:
+													{
+														position936, tokenIndex936, depth936 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l937
+														}
+														position++
+														goto l936
+													l937:
+														position, tokenIndex, depth = position936, tokenIndex936, depth936
+														if buffer[position] != rune('T') {
+															goto l927
+														}
+														position++
+													}
+												l936:
+													{
+														position938, tokenIndex938, depth938 := position, tokenIndex, depth
+														if buffer[position] != rune('o') {
+															goto l939
+														}
+														position++
+														goto l938
+													l939:
+														position, tokenIndex, depth = position938, tokenIndex938, depth938
+														if buffer[position] != rune('O') {
+															goto l927
+														}
+														position++
+													}
+												l938:
+													{
+														position940, tokenIndex940, depth940 := position, tokenIndex, depth
+														if buffer[position] != rune('f') {
+															goto l941
+														}
+														position++
+														goto l940
+													l941:
+														position, tokenIndex, depth = position940, tokenIndex940, depth940
+														if buffer[position] != rune('F') {
+															goto l927
+														}
+														position++
+													}
+												l940:
+													{
+														position942, tokenIndex942, depth942 := position, tokenIndex, depth
+														if buffer[position] != rune('w') {
+															goto l943
+														}
+														position++
+														goto l942
+													l943:
+														position, tokenIndex, depth = position942, tokenIndex942, depth942
+														if buffer[position] != rune('W') {
+															goto l927
+														}
+														position++
+													}
+												l942:
+													{
+														position944, tokenIndex944, depth944 := position, tokenIndex, depth
+														if buffer[position] != rune('e') {
+															goto l945
+														}
+														position++
+														goto l944
+													l945:
+														position, tokenIndex, depth = position944, tokenIndex944, depth944
+														if buffer[position] != rune('E') {
+															goto l927
+														}
+														position++
+													}
+												l944:
+													{
+														position946, tokenIndex946, depth946 := position, tokenIndex, depth
+														if buffer[position] != rune('e') {
+															goto l947
+														}
+														position++
+														goto l946
+													l947:
+														position, tokenIndex, depth = position946, tokenIndex946, depth946
+														if buffer[position] != rune('E') {
+															goto l927
+														}
+														position++
+													}
+												l946:
+													{
+														position948, tokenIndex948, depth948 := position, tokenIndex, depth
+														if buffer[position] != rune('k') {
+															goto l949
+														}
+														position++
+														goto l948
+													l949:
+														position, tokenIndex, depth = position948, tokenIndex948, depth948
+														if buffer[position] != rune('K') {
+															goto l927
+														}
+														position++
+													}
+												l948:
+													goto l851
+												l927:
+													position, tokenIndex, depth = position851, tokenIndex851, depth851
+													{
+														position951, tokenIndex951, depth951 := position, tokenIndex, depth
+														if buffer[position] != rune('s') {
+															goto l952
+														}
+														position++
+														goto l951
+													l952:
+														position, tokenIndex, depth = position951, tokenIndex951, depth951
+														if buffer[position] != rune('S') {
+															goto l950
+														}
+														position++
+													}
+												l951:
+													{
+														position953, tokenIndex953, depth953 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l954
+														}
+														position++
+														goto l953
+													l954:
+														position, tokenIndex, depth = position953, tokenIndex953, depth953
+														if buffer[position] != rune('T') {
+															goto l950
+														}
+														position++
+													}
+												l953:
+													{
+														position955, tokenIndex955, depth955 := position, tokenIndex, depth
+														if buffer[position] != rune('a') {
+															goto l956
+														}
+														position++
+														goto l955
+													l956:
+														position, tokenIndex, depth = position955, tokenIndex955, depth955
+														if buffer[position] != rune('A') {
+															goto l950
+														}
+														position++
+													}
+												l955:
+													{
+														position957, tokenIndex957, depth957 := position, tokenIndex, depth
+														if buffer[position] != rune('r') {
+															goto l958
+														}
+														position++
+														goto l957
+													l958:
+														position, tokenIndex, depth = position957, tokenIndex957, depth957
+														if buffer[position] != rune('R') {
+															goto l950
+														}
+														position++
+													}
+												l957:
+													{
+														position959, tokenIndex959, depth959 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l960
+														}
+														position++
+														goto l959
+													l960:
+														position, tokenIndex, depth = position959, tokenIndex959, depth959
+														if buffer[position] != rune('T') {
+															goto l950
+														}
+														position++
+													}
+												l959:
+													{
+														position961, tokenIndex961, depth961 := position, tokenIndex, depth
+														if buffer[position] != rune('o') {
+															goto l962
+														}
+														position++
+														goto l961
+													l962:
+														position, tokenIndex, depth = position961, tokenIndex961, depth961
+														if buffer[position] != rune('O') {
+															goto l950
+														}
+														position++
+													}
+												l961:
+													{
+														position963, tokenIndex963, depth963 := position, tokenIndex, depth
+														if buffer[position] != rune('f') {
+															goto l964
+														}
+														position++
+														goto l963
+													l964:
+														position, tokenIndex, depth = position963, tokenIndex963, depth963
+														if buffer[position] != rune('F') {
+															goto l950
+														}
+														position++
+													}
+												l963:
+													{
+														position965, tokenIndex965, depth965 := position, tokenIndex, depth
+														if buffer[position] != rune('m') {
+															goto l966
+														}
+														position++
+														goto l965
+													l966:
+														position, tokenIndex, depth = position965, tokenIndex965, depth965
+														if buffer[position] != rune('M') {
+															goto l950
+														}
+														position++
+													}
+												l965:
+													{
+														position967, tokenIndex967, depth967 := position, tokenIndex, depth
+														if buffer[position] != rune('o') {
+															goto l968
+														}
+														position++
+														goto l967
+													l968:
+														position, tokenIndex, depth = position967, tokenIndex967, depth967
+														if buffer[position] != rune('O') {
+															goto l950
+														}
+														position++
+													}
+												l967:
+													{
+														position969, tokenIndex969, depth969 := position, tokenIndex, depth
+														if buffer[position] != rune('n') {
+															goto l970
+														}
+														position++
+														goto l969
+													l970:
+														position, tokenIndex, depth = position969, tokenIndex969, depth969
+														if buffer[position] != rune('N') {
+															goto l950
+														}
+														position++
+													}
+												l969:
+													{
+														position971, tokenIndex971, depth971 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l972
+														}
+														position++
+														goto l971
+													l972:
+														position, tokenIndex, depth = position971, tokenIndex971, depth971
+														if buffer[position] != rune('T') {
+															goto l950
+														}
+														position++
+													}
+												l971:
+													{
+														position973, tokenIndex973, depth973 := position, tokenIndex, depth
+														if buffer[position] != rune('h') {
+															goto l974
+														}
+														position++
+														goto l973
+													l974:
+														position, tokenIndex, depth = position973, tokenIndex973, depth973
+														if buffer[position] != rune('H') {
+															goto l950
+														}
+														position++
+													}
+												l973:
+													goto l851
+												l950:
+													position, tokenIndex, depth = position851, tokenIndex851, depth851
+													{
+														position975, tokenIndex975, depth975 := position, tokenIndex, depth
+														if buffer[position] != rune('s') {
+															goto l976
+														}
+														position++
+														goto l975
+													l976:
+														position, tokenIndex, depth = position975, tokenIndex975, depth975
+														if buffer[position] != rune('S') {
+															goto l83
+														}
+														position++
+													}
+												l975:
+													{
+														position977, tokenIndex977, depth977 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l978
+														}
+														position++
+														goto l977
+													l978:
+														position, tokenIndex, depth = position977, tokenIndex977, depth977
+														if buffer[position] != rune('T') {
+															goto l83
+														}
+														position++
+													}
+												l977:
+													{
+														position979, tokenIndex979, depth979 := position, tokenIndex, depth
+														if buffer[position] != rune('a') {
+															goto l980
+														}
+														position++
+														goto l979
+													l980:
+														position, tokenIndex, depth = position979, tokenIndex979, depth979
+														if buffer[position] != rune('A') {
+															goto l83
+														}
+														position++
+													}
+												l979:
+													{
+														position981, tokenIndex981, depth981 := position, tokenIndex, depth
+														if buffer[position] != rune('r') {
+															goto l982
+														}
+														position++
+														goto l981
+													l982:
+														position, tokenIndex, depth = position981, tokenIndex981, depth981
+														if buffer[position] != rune('R') {
+															goto l83
+														}
+														position++
+													}
+												l981:
+													{
+														position983, tokenIndex983, depth983 := position, tokenIndex, depth
+														if buffer[position] != rune('t') {
+															goto l984
+														}
+														position++
+														goto l983
+													l984:
+														position, tokenIndex, depth = position983, tokenIndex983, depth983
+														if buffer[position] != rune('T') {
+															goto l83
+														}
+														position++
+													}
+												l983:
+													{
+														position985, tokenIndex985, depth985 := position, tokenIndex, depth
+														if buffer[position] != rune('o') {
+															goto l986
+														}
+														position++
+														goto l985
+													l986:
+														position, tokenIndex, depth = position985, tokenIndex985, depth985
+														if buffer[position] != rune('O') {
+															goto l83
+														}
+														position++
+													}
+												l985:
+													{
+														position987, tokenIndex987, depth987 := position, tokenIndex, depth
+														if buffer[position] != rune('f') {
+															goto l988
+														}
+														position++
+														goto l987
+													l988:
+														position, tokenIndex, depth = position987, tokenIndex987, depth987
+														if buffer[position] != rune('F') {
+															goto l83
+														}
+														position++
+													}
+												l987:
+													{
+														position989, tokenIndex989, depth989 := position, tokenIndex, depth
+														if buffer[position] != rune('y') {
+															goto l990
+														}
+														position++
+														goto l989
+													l990:
+														position, tokenIndex, depth = position989, tokenIndex989, depth989
+														if buffer[position] != rune('Y') {
+															goto l83
+														}
+														position++
+													}
+												l989:
+													{
+														position991, tokenIndex991, depth991 := position, tokenIndex, depth
+														if buffer[position] != rune('e') {
+															goto l992
+														}
+														position++
+														goto l991
+													l992:
+														position, tokenIndex, depth = position991, tokenIndex991, depth991
+														if buffer[position] != rune('E') {
+															goto l83
+														}
+														position++
+													}
+												l991:
+													{
+														position993, tokenIndex993, depth993 := position, tokenIndex, depth
+														if buffer[position] != rune('a') {
+															goto l994
+														}
+														position++
+														goto l993
+													l994:
+														position, tokenIndex, depth = position993, tokenIndex993, depth993
+														if buffer[position] != rune('A') {
+															goto l83
+														}
+														position++
+													}
+												l993:
+													{
+														position995, tokenIndex995, depth995 := position, tokenIndex, depth
+														if buffer[position] != rune('r') {
+															goto l996
+														}
+														position++
+														goto l995
+													l996:
+														position, tokenIndex, depth = position995, tokenIndex995, depth995
+														if buffer[position] != rune('R') {
+															goto l83
+														}
+														position++
+													}
+												l995:
 												}
-												goto l158
-											l159:
-												position, tokenIndex, depth = position158, tokenIndex158, depth158
-												if !(p.errorHere(position, `expected string literal to follow keyword "match"`)) {
-													goto l146
+											l851:
+												if !_rules[ruleKEY]() {
+													goto l83
 												}
-											}
-										l158:
-											{
-												add(ruleAction3, position)
-											}
-											depth--
-											add(rulematchClause, position147)
-										}
-										goto l145
-									l146:
-										position, tokenIndex, depth = position145, tokenIndex145, depth145
-										{
-											add(ruleAction2, position)
-										}
-									}
-								l145:
-									depth--
-									add(ruleoptionalMatchClause, position144)
-								}
-								{
-									add(ruleAction1, position)
-								}
-								{
-									position163, tokenIndex163, depth163 := position, tokenIndex, depth
-									{
-										position164, tokenIndex164, depth164 := position, tokenIndex, depth
-										if !_rules[rule_]() {
-											goto l165
-										}
-										{
-											position166, tokenIndex166, depth166 := position, tokenIndex, depth
-											if !matchDot() {
-												goto l166
-											}
-											goto l165
-										l166:
-											position, tokenIndex, depth = position166, tokenIndex166, depth166
-										}
-										goto l164
-									l165:
-										position, tokenIndex, depth = position164, tokenIndex164, depth164
-										if !_rules[rule_]() {
-											goto l136
-										}
-										if !(p.errorHere(position, `expected end of input after 'describe all' and optional match clause but got %q`, p.after(position))) {
-											goto l136
-										}
-									}
-								l164:
-									position, tokenIndex, depth = position163, tokenIndex163, depth163
-								}
-								depth--
-								add(ruledescribeAllStmt, position137)
-							}
-							goto l135
-						l136:
-							position, tokenIndex, depth = position135, tokenIndex135, depth135
-							{
-								position168 := position
-								depth++
-								if !_rules[rule_]() {
-									goto l167
-								}
-								{
-									position169, tokenIndex169, depth169 := position, tokenIndex, depth
-									if buffer[position] != rune('m') {
-										goto l170
-									}
-									position++
-									goto l169
-								l170:
-									position, tokenIndex, depth = position169, tokenIndex169, depth169
-									if buffer[position] != rune('M') {
-										goto l167
+												{
+													position997, tokenIndex997, depth997 := position, tokenIndex, depth
+													if !_rules[rule_]() {
+														goto l997
+													}
+													{
+														position998, tokenIndex998, depth998 := position, tokenIndex, depth
+														if buffer[position] != rune('+') {
+															goto l999
+														}
+														position++
+														goto l998
+													l999:
+														position, tokenIndex, depth = position998, tokenIndex998, depth998
+														if buffer[position] != rune('-') {
+															goto l997
+														}
+														position++
+													}
+												l998:
+													if !_rules[rule_]() {
+														goto l997
+													}
+													if !_rules[ruleNUMBER]() {
+														goto l997
+													}
+													{
+														position1000, tokenIndex1000, depth1000 := position, tokenIndex, depth
+														if c := buffer[position]; c < rune('a') || c > rune('z') {
+															goto l1001
+														}
+														position++
+														goto l1000
+													l1001:
+														position, tokenIndex, depth = position1000, tokenIndex1000, depth1000
+														if c := buffer[position]; c < rune('A') || c > rune('Z') {
+															goto l997
+														}
+														position++
+													}
+												l1000:
+												l1002:
+													{
+														position1003, tokenIndex1003, depth1003 := position, tokenIndex, depth
+														{
+															position1004, tokenIndex1004, depth1004 := position, tokenIndex, depth
+															if c := buffer[position]; c < rune('a') || c > rune('z') {
+																goto l1005
+															}
+															position++
+															goto l1004
+														l1005:
+															position, tokenIndex, depth = position1004, tokenIndex1004, depth1004
+															if c := buffer[position]; c < rune('A') || c > rune('Z') {
+																goto l1003
+															}
+															position++
+														}
+													l1004:
+														goto l1002
+													l1003:
+														position, tokenIndex, depth = position1003, tokenIndex1003, depth1003
+													}
+													if !_rules[ruleKEY]() {
+														goto l997
+													}
+													goto l1006
+												l997:
+													position, tokenIndex, depth = position997, tokenIndex997, depth997
+												}
+											l1006:
+												depth--
+												add(rulePegText, position850)
+											}
+										}
+									l86:
+										depth--
+										add(ruleTIMESTAMP, position85)
 									}
-									position++
+									depth--
+									add(rulePROPERTY_VALUE, position84)
 								}
-							l169:
 								{
-									position171, tokenIndex171, depth171 := position, tokenIndex, depth
-									if buffer[position] != rune('e') {
-										goto l172
-									}
-									position++
-									goto l171
-								l172:
-									position, tokenIndex, depth = position171, tokenIndex171, depth171
-									if buffer[position] != rune('E') {
-										goto l167
-									}
-									position++
+									add(ruleAction11, position)
 								}
-							l171:
-								{
-									position173, tokenIndex173, depth173 := position, tokenIndex, depth
-									if buffer[position] != rune('t') {
-										goto l174
-									}
-									position++
-									goto l173
-								l174:
-									position, tokenIndex, depth = position173, tokenIndex173, depth173
-									if buffer[position] != rune('T') {
-										goto l167
-									}
-									position++
+								goto l82
+							l83:
+								position, tokenIndex, depth = position82, tokenIndex82, depth82
+								if !_rules[rule_]() {
+									goto l1054
 								}
-							l173:
-								{
-									position175, tokenIndex175, depth175 := position, tokenIndex, depth
-									if buffer[position] != rune('r') {
-										goto l176
-									}
-									position++
-									goto l175
-								l176:
-									position, tokenIndex, depth = position175, tokenIndex175, depth175
-									if buffer[position] != rune('R') {
-										goto l167
-									}
-									position++
+								if !_rules[ruleVARIABLE]() {
+									goto l1054
 								}
-							l175:
 								{
-									position177, tokenIndex177, depth177 := position, tokenIndex, depth
-									if buffer[position] != rune('i') {
-										goto l178
-									}
-									position++
-									goto l177
-								l178:
-									position, tokenIndex, depth = position177, tokenIndex177, depth177
-									if buffer[position] != rune('I') {
-										goto l167
-									}
-									position++
+									add(ruleAction12, position)
 								}
-							l177:
-								{
-									position179, tokenIndex179, depth179 := position, tokenIndex, depth
-									if buffer[position] != rune('c') {
-										goto l180
-									}
-									position++
-									goto l179
-								l180:
-									position, tokenIndex, depth = position179, tokenIndex179, depth179
-									if buffer[position] != rune('C') {
-										goto l167
-									}
-									position++
+								goto l82
+							l1054:
+								position, tokenIndex, depth = position82, tokenIndex82, depth82
+								if !(p.errorExpecting(position, expect(`value`), `to follow key '%s'`, p.contents(tree, tokenIndex-2))) {
+									goto l24
 								}
-							l179:
-								{
-									position181, tokenIndex181, depth181 := position, tokenIndex, depth
-									if buffer[position] != rune('s') {
-										goto l182
-									}
-									position++
-									goto l181
-								l182:
-									position, tokenIndex, depth = position181, tokenIndex181, depth181
-									if buffer[position] != rune('S') {
-										goto l167
-									}
-									position++
+							}
+						l82:
+							{
+								add(ruleAction13, position)
+							}
+							goto l23
+						l24:
+							position, tokenIndex, depth = position23, tokenIndex23, depth23
+							if !_rules[rule_]() {
+								goto l103
+							}
+							{
+								position104, tokenIndex104, depth104 := position, tokenIndex, depth
+								if buffer[position] != rune('w') {
+									goto l105
 								}
-							l181:
-								if !_rules[ruleKEY]() {
-									goto l167
+								position++
+								goto l104
+							l105:
+								position, tokenIndex, depth = position104, tokenIndex104, depth104
+								if buffer[position] != rune('W') {
+									goto l103
 								}
-								{
-									position183, tokenIndex183, depth183 := position, tokenIndex, depth
-									if !_rules[rule_]() {
-										goto l184
-									}
-									{
-										position185, tokenIndex185, depth185 := position, tokenIndex, depth
-										if buffer[position] != rune('w') {
-											goto l186
-										}
-										position++
-										goto l185
-									l186:
-										position, tokenIndex, depth = position185, tokenIndex185, depth185
-										if buffer[position] != rune('W') {
-											goto l184
-										}
-										position++
-									}
-								l185:
-									{
-										position187, tokenIndex187, depth187 := position, tokenIndex, depth
-										if buffer[position] != rune('h') {
-											goto l188
-										}
-										position++
-										goto l187
-									l188:
-										position, tokenIndex, depth = position187, tokenIndex187, depth187
-										if buffer[position] != rune('H') {
-											goto l184
-										}
-										position++
-									}
-								l187:
-									{
-										position189, tokenIndex189, depth189 := position, tokenIndex, depth
-										if buffer[position] != rune('e') {
-											goto l190
-										}
-										position++
-										goto l189
-									l190:
-										position, tokenIndex, depth = position189, tokenIndex189, depth189
-										if buffer[position] != rune('E') {
-											goto l184
-										}
-										position++
-									}
-								l189:
-									{
-										position191, tokenIndex191, depth191 := position, tokenIndex, depth
-										if buffer[position] != rune('r') {
-											goto l192
-										}
-										position++
-										goto l191
-									l192:
-										position, tokenIndex, depth = position191, tokenIndex191, depth191
-										if buffer[position] != rune('R') {
-											goto l184
-										}
-										position++
-									}
-								l191:
-									{
-										position193, tokenIndex193, depth193 := position, tokenIndex, depth
-										if buffer[position] != rune('e') {
-											goto l194
-										}
-										position++
-										goto l193
-									l194:
-										position, tokenIndex, depth = position193, tokenIndex193, depth193
-										if buffer[position] != rune('E') {
-											goto l184
-										}
-										position++
-									}
-								l193:
-									if !_rules[ruleKEY]() {
-										goto l184
-									}
-									goto l183
-								l184:
-									position, tokenIndex, depth = position183, tokenIndex183, depth183
-									if !(p.errorHere(position, `expected "where" to follow keyword "metrics" in "describe metrics" command`)) {
-										goto l167
-									}
+								position++
+							}
+						l104:
+							{
+								position106, tokenIndex106, depth106 := position, tokenIndex, depth
+								if buffer[position] != rune('h') {
+									goto l107
 								}
-							l183:
-								{
-									position195, tokenIndex195, depth195 := position, tokenIndex, depth
-									if !_rules[ruletagName]() {
-										goto l196
-									}
-									goto l195
-								l196:
-									position, tokenIndex, depth = position195, tokenIndex195, depth195
-									if !(p.errorHere(position, `expected tag key to follow keyword "where" in "describe metrics" command`)) {
-										goto l167
-									}
+								position++
+								goto l106
+							l107:
+								position, tokenIndex, depth = position106, tokenIndex106, depth106
+								if buffer[position] != rune('H') {
+									goto l103
 								}
-							l195:
-								{
-									position197, tokenIndex197, depth197 := position, tokenIndex, depth
-									if !_rules[rule_]() {
-										goto l198
-									}
-									if buffer[position] != rune('=') {
-										goto l198
-									}
-									position++
-									goto l197
-								l198:
-									position, tokenIndex, depth = position197, tokenIndex197, depth197
-									if !(p.errorHere(position, `expected "=" to follow keyword "where" in "describe metrics" command`)) {
-										goto l167
-									}
+								position++
+							}
+						l106:
+							{
+								position108, tokenIndex108, depth108 := position, tokenIndex, depth
+								if buffer[position] != rune('e') {
+									goto l109
 								}
-							l197:
-								{
-									position199, tokenIndex199, depth199 := position, tokenIndex, depth
-									if !_rules[ruleliteralString]() {
-										goto l200
-									}
-									goto l199
-								l200:
-									position, tokenIndex, depth = position199, tokenIndex199, depth199
-									if !(p.errorHere(position, `expected string literal to follow "=" in "describe metrics" command`)) {
-										goto l167
-									}
+								position++
+								goto l108
+							l109:
+								position, tokenIndex, depth = position108, tokenIndex108, depth108
+								if buffer[position] != rune('E') {
+									goto l103
 								}
-							l199:
-								{
-									add(ruleAction4, position)
+								position++
+							}
+						l108:
+							{
+								position110, tokenIndex110, depth110 := position, tokenIndex, depth
+								if buffer[position] != rune('r') {
+									goto l111
 								}
-								depth--
-								add(ruledescribeMetrics, position168)
+								position++
+								goto l110
+							l111:
+								position, tokenIndex, depth = position110, tokenIndex110, depth110
+								if buffer[position] != rune('R') {
+									goto l103
+								}
+								position++
 							}
-							goto l135
-						l167:
-							position, tokenIndex, depth = position135, tokenIndex135, depth135
+						l110:
 							{
-								position202 := position
-								depth++
-								{
-									position203, tokenIndex203, depth203 := position, tokenIndex, depth
-									if !_rules[rule_]() {
-										goto l204
-									}
-									{
-										position205 := position
-										depth++
-										{
-											position206 := position
-											depth++
-											if !_rules[ruleIDENTIFIER]() {
-												goto l204
-											}
-											depth--
-											add(ruleMETRIC_NAME, position206)
-										}
-										depth--
-										add(rulePegText, position205)
-									}
-									{
-										add(ruleAction5, position)
-									}
-									goto l203
-								l204:
-									position, tokenIndex, depth = position203, tokenIndex203, depth203
-									if !(p.errorHere(position, `expected metric name to follow "describe" in "describe" command`)) {
-										goto l0
-									}
+								position112, tokenIndex112, depth112 := position, tokenIndex, depth
+								if buffer[position] != rune('e') {
+									goto l113
 								}
-							l203:
-								if !_rules[ruleoptionalPredicateClause]() {
-									goto l0
+								position++
+								goto l112
+							l113:
+								position, tokenIndex, depth = position112, tokenIndex112, depth112
+								if buffer[position] != rune('E') {
+									goto l103
 								}
+								position++
+							}
+						l112:
+							if !_rules[ruleKEY]() {
+								goto l103
+							}
+							if !(p.errorHere(position, `encountered "where" after property clause; "where" blocks must go BEFORE 'from' and 'to' specifiers`)) {
+								goto l103
+							}
+							goto l23
+						l103:
+							position, tokenIndex, depth = position23, tokenIndex23, depth23
+							if !_rules[rule_]() {
+								goto l22
+							}
+							{
+								position114, tokenIndex114, depth114 := position, tokenIndex, depth
 								{
-									add(ruleAction6, position)
+									position115, tokenIndex115, depth115 := position, tokenIndex, depth
+									if !matchDot() {
+										goto l115
+									}
+									goto l114
+								l115:
+									position, tokenIndex, depth = position115, tokenIndex115, depth115
 								}
-								depth--
-								add(ruledescribeSingleStmt, position202)
+								goto l22
+							l114:
+								position, tokenIndex, depth = position114, tokenIndex114, depth114
+							}
+							if !(p.errorAfterExpression(position)) {
+								goto l22
 							}
 						}
-					l135:
-						depth--
-						add(ruledescribeStmt, position118)
+					l23:
+						goto l21
+					l22:
+						position, tokenIndex, depth = position22, tokenIndex22, depth22
 					}
-				}
-			l2:
-				if !_rules[rule_]() {
-					goto l0
+					{
+						add(ruleAction14, position)
+					}
+					depth--
+					add(rulepropertyClause, position19)
 				}
 				{
-					position209, tokenIndex209, depth209 := position, tokenIndex, depth
-					if !matchDot() {
-						goto l209
-					}
-					goto l0
-				l209:
-					position, tokenIndex, depth = position209, tokenIndex209, depth209
+					add(ruleAction1, position)
 				}
 				depth--
-				add(ruleroot, position1)
+				add(ruleselectStmt, position4)
 			}
 			return true
-		l0:
-			position, tokenIndex, depth = position0, tokenIndex0, depth0
+		l1011:
+			position, tokenIndex, depth = position1011, tokenIndex1011, depth1011
 			return false
 		},
-		/* 1 selectStmt <- <(_ (('s' / 'S') ('e' / 'E') ('l' / 'L') ('e' / 'E') ('c' / 'C') ('t' / 'T') KEY)? expressionList &{ p.setContext("after expression of select statement") } optionalPredicateClause &{ p.setContext("") } propertyClause Action0)> */
-		nil,
-		/* 2 describeStmt <- <(_ (('d' / 'D') ('e' / 'E') ('s' / 'S') ('c' / 'C') ('r' / 'R') ('i' / 'I') ('b' / 'B') ('e' / 'E')) KEY (describeAllStmt / describeMetrics / describeSingleStmt))> */
+		/* 3 describeStmt <- <(_ (('d' / 'D') ('e' / 'E') ('s' / 'S') ('c' / 'C') ('r' / 'R') ('i' / 'I') ('b' / 'B') ('e' / 'E')) KEY (describeAllStmt / describeMetrics / describeFunctions / describeSingleStmt))> */
 		nil,
-		/* 3 describeAllStmt <- <(_ (('a' / 'A') ('l' / 'L') ('l' / 'L')) KEY optionalMatchClause Action1 &((_ !.) / (_ &{p.errorHere(position, `expected end of input after 'describe all' and optional match clause but got %q`, p.after(position) )})))> */
+		/* 4 describeAllStmt <- <(_ (('a' / 'A') ('l' / 'L') ('l' / 'L')) KEY optionalMatchClause Action2 &((_ !.) / (_ &{p.errorExpecting(position, expect(`end of input`), `after 'describe all' and optional match clause but got %q`, p.after(position) )})))> */
 		nil,
-		/* 4 optionalMatchClause <- <(matchClause / Action2)> */
+		/* 5 optionalMatchClause <- <(matchClause / Action3)> */
+		func() bool {
+			{
+				position144 := position
+				depth++
+				{
+					position145, tokenIndex145, depth145 := position, tokenIndex, depth
+					{
+						position147 := position
+						depth++
+						if !_rules[rule_]() {
+							goto l146
+						}
+						{
+							position148, tokenIndex148, depth148 := position, tokenIndex, depth
+							if buffer[position] != rune('m') {
+								goto l149
+							}
+							position++
+							goto l148
+						l149:
+							position, tokenIndex, depth = position148, tokenIndex148, depth148
+							if buffer[position] != rune('M') {
+								goto l146
+							}
+							position++
+						}
+					l148:
+						{
+							position150, tokenIndex150, depth150 := position, tokenIndex, depth
+							if buffer[position] != rune('a') {
+								goto l151
+							}
+							position++
+							goto l150
+						l151:
+							position, tokenIndex, depth = position150, tokenIndex150, depth150
+							if buffer[position] != rune('A') {
+								goto l146
+							}
+							position++
+						}
+					l150:
+						{
+							position152, tokenIndex152, depth152 := position, tokenIndex, depth
+							if buffer[position] != rune('t') {
+								goto l153
+							}
+							position++
+							goto l152
+						l153:
+							position, tokenIndex, depth = position152, tokenIndex152, depth152
+							if buffer[position] != rune('T') {
+								goto l146
+							}
+							position++
+						}
+					l152:
+						{
+							position154, tokenIndex154, depth154 := position, tokenIndex, depth
+							if buffer[position] != rune('c') {
+								goto l155
+							}
+							position++
+							goto l154
+						l155:
+							position, tokenIndex, depth = position154, tokenIndex154, depth154
+							if buffer[position] != rune('C') {
+								goto l146
+							}
+							position++
+						}
+					l154:
+						{
+							position156, tokenIndex156, depth156 := position, tokenIndex, depth
+							if buffer[position] != rune('h') {
+								goto l157
+							}
+							position++
+							goto l156
+						l157:
+							position, tokenIndex, depth = position156, tokenIndex156, depth156
+							if buffer[position] != rune('H') {
+								goto l146
+							}
+							position++
+						}
+					l156:
+						if !_rules[ruleKEY]() {
+							goto l146
+						}
+						{
+							position158, tokenIndex158, depth158 := position, tokenIndex, depth
+							if !_rules[ruleliteralString]() {
+								goto l159
+							}
+							goto l158
+						l159:
+							position, tokenIndex, depth = position158, tokenIndex158, depth158
+							if !(p.errorExpecting(position, expect(`string literal`), `to follow keyword "match"`)) {
+								goto l146
+							}
+						}
+					l158:
+						{
+							add(ruleAction4, position)
+						}
+						depth--
+						add(rulematchClause, position147)
+					}
+					goto l145
+				l146:
+					position, tokenIndex, depth = position145, tokenIndex145, depth145
+					{
+						add(ruleAction3, position)
+					}
+				}
+			l145:
+				depth--
+				add(ruleoptionalMatchClause, position144)
+			}
+			return true
+		},
+		/* 6 matchClause <- <(_ (('m' / 'M') ('a' / 'A') ('t' / 'T') ('c' / 'C') ('h' / 'H')) KEY (literalString / &{ p.errorExpecting(position, expect(`string literal`), `to follow keyword "match"`) }) Action4)> */
 		nil,
-		/* 5 matchClause <- <(_ (('m' / 'M') ('a' / 'A') ('t' / 'T') ('c' / 'C') ('h' / 'H')) KEY (literalString / &{ p.errorHere(position, `expected string literal to follow keyword "match"`) }) Action3)> */
+		/* 7 describeMetrics <- <(_ (('m' / 'M') ('e' / 'E') ('t' / 'T') ('r' / 'R') ('i' / 'I') ('c' / 'C') ('s' / 'S')) KEY ((_ (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E')) KEY) / &{ p.errorExpecting(position, expect(`"where"`), `to follow keyword "metrics" in "describe metrics" command`) }) (tagName / &{ p.errorExpecting(position, expect(`tag key`), `to follow keyword "where" in "describe metrics" command`) }) ((_ '=') / &{ p.errorExpecting(position, expect(`"="`), `to follow keyword "where" in "describe metrics" command`) }) (literalString / &{ p.errorExpecting(position, expect(`string literal`), `to follow "=" in "describe metrics" command`) }) Action5)> */
 		nil,
-		/* 6 describeMetrics <- <(_ (('m' / 'M') ('e' / 'E') ('t' / 'T') ('r' / 'R') ('i' / 'I') ('c' / 'C') ('s' / 'S')) KEY ((_ (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E')) KEY) / &{ p.errorHere(position, `expected "where" to follow keyword "metrics" in "describe metrics" command`) }) (tagName / &{ p.errorHere(position, `expected tag key to follow keyword "where" in "describe metrics" command`) }) ((_ '=') / &{ p.errorHere(position, `expected "=" to follow keyword "where" in "describe metrics" command`) }) (literalString / &{ p.errorHere(position, `expected string literal to follow "=" in "describe metrics" command`) }) Action4)> */
+		/* 8 describeFunctions <- <(_ (('f' / 'F') ('u' / 'U') ('n' / 'N') ('c' / 'C') ('t' / 'T') ('i' / 'I') ('o' / 'O') ('n' / 'N') ('s' / 'S')) KEY optionalMatchClause Action6 &((_ !.) / (_ &{p.errorExpecting(position, expect(`end of input`), `after 'describe functions' and optional match clause but got %q`, p.after(position) )})))> */
 		nil,
-		/* 7 describeSingleStmt <- <(((_ <METRIC_NAME> Action5) / &{ p.errorHere(position, `expected metric name to follow "describe" in "describe" command`) }) optionalPredicateClause Action6)> */
+		/* 9 describeSingleStmt <- <(((_ <METRIC_NAME> Action7) / &{ p.errorExpecting(position, expect(`metric name`), `to follow "describe" in "describe" command`) }) optionalPredicateClause Action8)> */
 		nil,
-		/* 8 propertyClause <- <(Action7 ((_ PROPERTY_KEY Action8 ((_ PROPERTY_VALUE Action9) / &{ p.errorHere(position, `expected value to follow key '%s'`, p.contents(tree, tokenIndex-2)) }) Action10) / (_ (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E')) KEY &{ p.errorHere(position, `encountered "where" after property clause; "where" blocks must go BEFORE 'from' and 'to' specifiers`) }) / (_ !!. &{ p.errorHere(position, `expected key (one of 'from', 'to', 'resolution', or 'sample by') or end of input but got %q following a completed expression`, p.after(position)) }))* Action11)> */
+		/* 10 propertyClause <- <(Action9 ((_ PROPERTY_KEY Action10 ((_ PROPERTY_VALUE Action11) / (_ VARIABLE Action12) / &{ p.errorExpecting(position, expect(`value`), `to follow key '%s'`, p.contents(tree, tokenIndex-2)) }) Action13) / (_ (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E')) KEY &{ p.errorHere(position, `encountered "where" after property clause; "where" blocks must go BEFORE 'from' and 'to' specifiers`) }) / (_ !!. &{ p.errorAfterExpression(position) }))* Action14)> */
 		nil,
-		/* 9 optionalPredicateClause <- <(predicateClause / Action12)> */
+		/* 11 optionalPredicateClause <- <(predicateClause / Action15)> */
 		func() bool {
 			{
 				position219 := position
@@ -2489,7 +4099,7 @@ func (p *Parser) Init() {
 							goto l233
 						l234:
 							position, tokenIndex, depth = position233, tokenIndex233, depth233
-							if !(p.errorHere(position, `expected predicate to follow "where" keyword`)) {
+							if !(p.errorExpecting(position, expect(`predicate`), `to follow "where" keyword`)) {
 								goto l221
 							}
 						}
@@ -2501,7 +4111,7 @@ func (p *Parser) Init() {
 				l221:
 					position, tokenIndex, depth = position220, tokenIndex220, depth220
 					{
-						add(ruleAction12, position)
+						add(ruleAction15, position)
 					}
 				}
 			l220:
@@ -2510,20 +4120,20 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 10 expressionList <- <(Action13 expression_start Action14 (_ COMMA (expression_start / &{ p.errorHere(position, `expected expression to follow ","`) }) Action15)*)> */
+		/* 12 expressionList <- <(Action16 expression_start Action17 (_ COMMA (expression_start / &{ p.errorExpecting(position, expect(`expression`), `to follow ","`) }) Action18)*)> */
 		func() bool {
 			position236, tokenIndex236, depth236 := position, tokenIndex, depth
 			{
 				position237 := position
 				depth++
 				{
-					add(ruleAction13, position)
+					add(ruleAction16, position)
 				}
 				if !_rules[ruleexpression_start]() {
 					goto l236
 				}
 				{
-					add(ruleAction14, position)
+					add(ruleAction17, position)
 				}
 			l240:
 				{
@@ -2542,13 +4152,13 @@ func (p *Parser) Init() {
 						goto l242
 					l243:
 						position, tokenIndex, depth = position242, tokenIndex242, depth242
-						if !(p.errorHere(position, `expected expression to follow ","`)) {
+						if !(p.errorExpecting(position, expect(`expression`), `to follow ","`)) {
 							goto l241
 						}
 					}
 				l242:
 					{
-						add(ruleAction15, position)
+						add(ruleAction18, position)
 					}
 					goto l240
 				l241:
@@ -2562,7 +4172,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position236, tokenIndex236, depth236
 			return false
 		},
-		/* 11 expression_start <- <(expression_sum add_pipe)> */
+		/* 13 expression_start <- <(expression_sum add_pipe)> */
 		func() bool {
 			position245, tokenIndex245, depth245 := position, tokenIndex, depth
 			{
@@ -2596,7 +4206,7 @@ func (p *Parser) Init() {
 								add(ruleOP_ADD, position252)
 							}
 							{
-								add(ruleAction16, position)
+								add(ruleAction19, position)
 							}
 							goto l250
 						l251:
@@ -2615,7 +4225,7 @@ func (p *Parser) Init() {
 								add(ruleOP_SUB, position254)
 							}
 							{
-								add(ruleAction17, position)
+								add(ruleAction20, position)
 							}
 						}
 					l250:
@@ -2627,13 +4237,13 @@ func (p *Parser) Init() {
 							goto l256
 						l257:
 							position, tokenIndex, depth = position256, tokenIndex256, depth256
-							if !(p.errorHere(position, `expected expression to follow operator "+" or "-"`)) {
+							if !(p.errorExpecting(position, expect(`expression`), `to follow operator "+" or "-"`)) {
 								goto l249
 							}
 						}
 					l256:
 						{
-							add(ruleAction18, position)
+							add(ruleAction21, position)
 						}
 						goto l248
 					l249:
@@ -2653,9 +4263,9 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position245, tokenIndex245, depth245
 			return false
 		},
-		/* 12 expression_sum <- <(expression_product (add_pipe ((_ OP_ADD Action16) / (_ OP_SUB Action17)) (expression_product / &{ p.errorHere(position, `expected expression to follow operator "+" or "-"`) }) Action18)*)> */
+		/* 14 expression_sum <- <(expression_product (add_pipe ((_ OP_ADD Action19) / (_ OP_SUB Action20)) (expression_product / &{ p.errorExpecting(position, expect(`expression`), `to follow operator "+" or "-"`) }) Action21)*)> */
 		nil,
-		/* 13 expression_product <- <(expression_atom (add_pipe ((_ OP_DIV Action19) / (_ OP_MULT Action20)) (expression_atom / &{ p.errorHere(position, `expected expression to follow operator "*" or "/"`) }) Action21)*)> */
+		/* 15 expression_product <- <(expression_atom (add_pipe ((_ OP_DIV Action22) / (_ OP_MULT Action23)) (expression_atom / &{ p.errorExpecting(position, expect(`expression`), `to follow operator "*" or "/"`) }) Action24)*)> */
 		func() bool {
 			position260, tokenIndex260, depth260 := position, tokenIndex, depth
 			{
@@ -2686,7 +4296,7 @@ func (p *Parser) Init() {
 							add(ruleOP_DIV, position266)
 						}
 						{
-							add(ruleAction19, position)
+							add(ruleAction22, position)
 						}
 						goto l264
 					l265:
@@ -2705,7 +4315,7 @@ func (p *Parser) Init() {
 							add(ruleOP_MULT, position268)
 						}
 						{
-							add(ruleAction20, position)
+							add(ruleAction23, position)
 						}
 					}
 				l264:
@@ -2717,13 +4327,13 @@ func (p *Parser) Init() {
 						goto l270
 					l271:
 						position, tokenIndex, depth = position270, tokenIndex270, depth270
-						if !(p.errorHere(position, `expected expression to follow operator "*" or "/"`)) {
+						if !(p.errorExpecting(position, expect(`expression`), `to follow operator "*" or "/"`)) {
 							goto l263
 						}
 					}
 				l270:
 					{
-						add(ruleAction21, position)
+						add(ruleAction24, position)
 					}
 					goto l262
 				l263:
@@ -2737,9 +4347,9 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position260, tokenIndex260, depth260
 			return false
 		},
-		/* 14 add_one_pipe <- <(_ OP_PIPE ((_ <IDENTIFIER>) / &{ p.errorHere(position, `expected function name to follow pipe "|"`) }) Action22 ((_ PAREN_OPEN (expressionList / Action23) optionalGroupBy ((_ PAREN_CLOSE) / &{ p.errorHere(position, `expected ")" to close "(" opened in pipe function call`) })) / Action24) Action25 expression_annotation)> */
+		/* 16 add_one_pipe <- <(_ OP_PIPE ((_ <IDENTIFIER>) / &{ p.errorExpecting(position, expect(`function name`), `to follow pipe "|"`) }) Action25 ((_ PAREN_OPEN (expressionList / Action26) optionalGroupBy ((_ PAREN_CLOSE) / &{ p.errorExpecting(position, expect(`")"`), `to close "(" opened in pipe function call`) })) / Action27) Action28 expression_annotation)> */
 		nil,
-		/* 15 add_pipe <- <add_one_pipe*> */
+		/* 17 add_pipe <- <add_one_pipe*> */
 		func() bool {
 			{
 				position275 := position
@@ -2780,13 +4390,13 @@ func (p *Parser) Init() {
 							goto l280
 						l281:
 							position, tokenIndex, depth = position280, tokenIndex280, depth280
-							if !(p.errorHere(position, `expected function name to follow pipe "|"`)) {
+							if !(p.errorExpecting(position, expect(`function name`), `to follow pipe "|"`)) {
 								goto l277
 							}
 						}
 					l280:
 						{
-							add(ruleAction22, position)
+							add(ruleAction25, position)
 						}
 						{
 							position284, tokenIndex284, depth284 := position, tokenIndex, depth
@@ -2805,7 +4415,7 @@ func (p *Parser) Init() {
 							l287:
 								position, tokenIndex, depth = position286, tokenIndex286, depth286
 								{
-									add(ruleAction23, position)
+									add(ruleAction26, position)
 								}
 							}
 						l286:
@@ -2823,7 +4433,7 @@ func (p *Parser) Init() {
 								goto l289
 							l290:
 								position, tokenIndex, depth = position289, tokenIndex289, depth289
-								if !(p.errorHere(position, `expected ")" to close "(" opened in pipe function call`)) {
+								if !(p.errorExpecting(position, expect(`")"`), `to close "(" opened in pipe function call`)) {
 									goto l285
 								}
 							}
@@ -2832,12 +4442,12 @@ func (p *Parser) Init() {
 						l285:
 							position, tokenIndex, depth = position284, tokenIndex284, depth284
 							{
-								add(ruleAction24, position)
+								add(ruleAction27, position)
 							}
 						}
 					l284:
 						{
-							add(ruleAction25, position)
+							add(ruleAction28, position)
 						}
 						if !_rules[ruleexpression_annotation]() {
 							goto l277
@@ -2854,7 +4464,7 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 16 expression_atom <- <(expression_atom_raw expression_annotation)> */
+		/* 18 expression_atom <- <(expression_atom_raw expression_annotation)> */
 		func() bool {
 			position293, tokenIndex293, depth293 := position, tokenIndex, depth
 			{
@@ -2881,7 +4491,7 @@ func (p *Parser) Init() {
 								add(rulePegText, position299)
 							}
 							{
-								add(ruleAction31, position)
+								add(ruleAction35, position)
 							}
 							if !_rules[rule_]() {
 								goto l297
@@ -2897,7 +4507,7 @@ func (p *Parser) Init() {
 								goto l301
 							l302:
 								position, tokenIndex, depth = position301, tokenIndex301, depth301
-								if !(p.errorHere(position, `expected expression list to follow "(" in function call`)) {
+								if !(p.errorExpecting(position, expect(`expression list`), `to follow "(" in function call`)) {
 									goto l297
 								}
 							}
@@ -2916,13 +4526,13 @@ func (p *Parser) Init() {
 								goto l303
 							l304:
 								position, tokenIndex, depth = position303, tokenIndex303, depth303
-								if !(p.errorHere(position, `expected ")" to close "(" opened by function call`)) {
+								if !(p.errorExpecting(position, expect(`")"`), `to close "(" opened by function call`)) {
 									goto l297
 								}
 							}
 						l303:
 							{
-								add(ruleAction32, position)
+								add(ruleAction36, position)
 							}
 							depth--
 							add(ruleexpression_function, position298)
@@ -2946,7 +4556,7 @@ func (p *Parser) Init() {
 								add(rulePegText, position308)
 							}
 							{
-								add(ruleAction33, position)
+								add(ruleAction37, position)
 							}
 							{
 								position310, tokenIndex310, depth310 := position, tokenIndex, depth
@@ -2965,7 +4575,7 @@ func (p *Parser) Init() {
 									goto l312
 								l313:
 									position, tokenIndex, depth = position312, tokenIndex312, depth312
-									if !(p.errorHere(position, `expected predicate to follow "[" after metric`)) {
+									if !(p.errorExpecting(position, expect(`predicate`), `to follow "[" after metric`)) {
 										goto l311
 									}
 								}
@@ -2982,7 +4592,7 @@ func (p *Parser) Init() {
 									goto l314
 								l315:
 									position, tokenIndex, depth = position314, tokenIndex314, depth314
-									if !(p.errorHere(position, `expected "]" to close "[" opened to apply predicate`)) {
+									if !(p.errorExpecting(position, expect(`"]"`), `to close "[" opened to apply predicate`)) {
 										goto l311
 									}
 								}
@@ -2991,12 +4601,12 @@ func (p *Parser) Init() {
 							l311:
 								position, tokenIndex, depth = position310, tokenIndex310, depth310
 								{
-									add(ruleAction34, position)
+									add(ruleAction38, position)
 								}
 							}
 						l310:
 							{
-								add(ruleAction35, position)
+								add(ruleAction39, position)
 							}
 							depth--
 							add(ruleexpression_metric, position307)
@@ -3018,7 +4628,7 @@ func (p *Parser) Init() {
 							goto l319
 						l320:
 							position, tokenIndex, depth = position319, tokenIndex319, depth319
-							if !(p.errorHere(position, `expected expression to follow "("`)) {
+							if !(p.errorExpecting(position, expect(`expression`), `to follow "("`)) {
 								goto l318
 							}
 						}
@@ -3034,7 +4644,7 @@ func (p *Parser) Init() {
 							goto l321
 						l322:
 							position, tokenIndex, depth = position321, tokenIndex321, depth321
-							if !(p.errorHere(position, `expected ")" to close "("`)) {
+							if !(p.errorExpecting(position, expect(`")"`), `to close "("`)) {
 								goto l318
 							}
 						}
@@ -3079,7 +4689,7 @@ func (p *Parser) Init() {
 							add(rulePegText, position324)
 						}
 						{
-							add(ruleAction26, position)
+							add(ruleAction29, position)
 						}
 						goto l296
 					l323:
@@ -3097,19 +4707,31 @@ func (p *Parser) Init() {
 							add(rulePegText, position330)
 						}
 						{
-							add(ruleAction27, position)
+							add(ruleAction30, position)
 						}
 						goto l296
 					l329:
 						position, tokenIndex, depth = position296, tokenIndex296, depth296
 						if !_rules[rule_]() {
-							goto l293
+							goto l1055
 						}
 						if !_rules[ruleSTRING]() {
+							goto l1055
+						}
+						{
+							add(ruleAction31, position)
+						}
+						goto l296
+					l1055:
+						position, tokenIndex, depth = position296, tokenIndex296, depth296
+						if !_rules[rule_]() {
+							goto l293
+						}
+						if !_rules[ruleVARIABLE]() {
 							goto l293
 						}
 						{
-							add(ruleAction28, position)
+							add(ruleAction32, position)
 						}
 					}
 				l296:
@@ -3127,11 +4749,11 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position293, tokenIndex293, depth293
 			return false
 		},
-		/* 17 expression_atom_raw <- <(expression_function / expression_metric / (_ PAREN_OPEN (expression_start / &{ p.errorHere(position, `expected expression to follow "("`) }) ((_ PAREN_CLOSE) / &{ p.errorHere(position, `expected ")" to close "("`) })) / (_ <DURATION> Action26) / (_ <NUMBER> Action27) / (_ STRING Action28))> */
+		/* 19 expression_atom_raw <- <(expression_function / expression_metric / (_ PAREN_OPEN (expression_start / &{ p.errorExpecting(position, expect(`expression`), `to follow "("`) }) ((_ PAREN_CLOSE) / &{ p.errorExpecting(position, expect(`")"`), `to close "("`) })) / (_ <DURATION> Action29) / (_ <NUMBER> Action30) / (_ STRING Action31) / (_ VARIABLE Action32))> */
 		nil,
-		/* 18 expression_annotation_required <- <(_ '{' <(!'}' .)*> ('}' / &{ p.errorHere(position, `expected "$CLOSEBRACE$" to close "$OPENBRACE$" opened for annotation`) }) Action29)> */
+		/* 20 expression_annotation_required <- <(_ '{' <(!'}' .)*> ('}' / &{ p.errorExpecting(position, expect(`"$CLOSEBRACE$"`), `to close "$OPENBRACE$" opened for annotation`) }) Action33)> */
 		nil,
-		/* 19 expression_annotation <- <expression_annotation_required?> */
+		/* 21 expression_annotation <- <expression_annotation_required?> */
 		func() bool {
 			{
 				position336 := position
@@ -3183,13 +4805,13 @@ func (p *Parser) Init() {
 							goto l344
 						l345:
 							position, tokenIndex, depth = position344, tokenIndex344, depth344
-							if !(p.errorHere(position, `expected "$CLOSEBRACE$" to close "$OPENBRACE$" opened for annotation`)) {
+							if !(p.errorExpecting(position, expect(`"$CLOSEBRACE$"`), `to close "$OPENBRACE$" opened for annotation`)) {
 								goto l337
 							}
 						}
 					l344:
 						{
-							add(ruleAction29, position)
+							add(ruleAction33, position)
 						}
 						depth--
 						add(ruleexpression_annotation_required, position339)
@@ -3204,7 +4826,7 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 20 optionalGroupBy <- <(groupByClause / collapseByClause / Action30)?> */
+		/* 22 optionalGroupBy <- <(groupByClause / collapseByClause / Action34)?> */
 		func() bool {
 			{
 				position348 := position
@@ -3338,7 +4960,7 @@ func (p *Parser) Init() {
 								goto l364
 							l365:
 								position, tokenIndex, depth = position364, tokenIndex364, depth364
-								if !(p.errorHere(position, `expected keyword "by" to follow keyword "group" in "group by" clause`)) {
+								if !(p.errorExpecting(position, expect(`keyword "by"`), `to follow keyword "group" in "group by" clause`)) {
 									goto l352
 								}
 							}
@@ -3360,16 +4982,16 @@ func (p *Parser) Init() {
 								goto l370
 							l371:
 								position, tokenIndex, depth = position370, tokenIndex370, depth370
-								if !(p.errorHere(position, `expected tag key identifier to follow "group by" keywords in "group by" clause`)) {
+								if !(p.errorExpecting(position, expect(`tag key identifier`), `to follow "group by" keywords in "group by" clause`)) {
 									goto l352
 								}
 							}
 						l370:
 							{
-								add(ruleAction36, position)
+								add(ruleAction40, position)
 							}
 							{
-								add(ruleAction37, position)
+								add(ruleAction41, position)
 							}
 						l375:
 							{
@@ -3397,13 +5019,13 @@ func (p *Parser) Init() {
 									goto l377
 								l378:
 									position, tokenIndex, depth = position377, tokenIndex377, depth377
-									if !(p.errorHere(position, `expected tag key identifier to follow "," in "group by" clause`)) {
+									if !(p.errorExpecting(position, expect(`tag key identifier`), `to follow "," in "group by" clause`)) {
 										goto l376
 									}
 								}
 							l377:
 								{
-									add(ruleAction38, position)
+									add(ruleAction42, position)
 								}
 								goto l375
 							l376:
@@ -3585,7 +5207,7 @@ func (p *Parser) Init() {
 								goto l399
 							l400:
 								position, tokenIndex, depth = position399, tokenIndex399, depth399
-								if !(p.errorHere(position, `expected keyword "by" to follow keyword "collapse" in "collapse by" clause`)) {
+								if !(p.errorExpecting(position, expect(`keyword "by"`), `to follow keyword "collapse" in "collapse by" clause`)) {
 									goto l381
 								}
 							}
@@ -3607,16 +5229,16 @@ func (p *Parser) Init() {
 								goto l405
 							l406:
 								position, tokenIndex, depth = position405, tokenIndex405, depth405
-								if !(p.errorHere(position, `expected tag key identifier to follow "collapse by" keywords in "collapse by" clause`)) {
+								if !(p.errorExpecting(position, expect(`tag key identifier`), `to follow "collapse by" keywords in "collapse by" clause`)) {
 									goto l381
 								}
 							}
 						l405:
 							{
-								add(ruleAction39, position)
+								add(ruleAction43, position)
 							}
 							{
-								add(ruleAction40, position)
+								add(ruleAction44, position)
 							}
 						l410:
 							{
@@ -3644,13 +5266,13 @@ func (p *Parser) Init() {
 									goto l412
 								l413:
 									position, tokenIndex, depth = position412, tokenIndex412, depth412
-									if !(p.errorHere(position, `expected tag key identifier to follow "," in "collapse by" clause`)) {
+									if !(p.errorExpecting(position, expect(`tag key identifier`), `to follow "," in "collapse by" clause`)) {
 										goto l411
 									}
 								}
 							l412:
 								{
-									add(ruleAction41, position)
+									add(ruleAction45, position)
 								}
 								goto l410
 							l411:
@@ -3663,7 +5285,7 @@ func (p *Parser) Init() {
 					l381:
 						position, tokenIndex, depth = position351, tokenIndex351, depth351
 						{
-							add(ruleAction30, position)
+							add(ruleAction34, position)
 						}
 					}
 				l351:
@@ -3677,17 +5299,17 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 21 expression_function <- <(_ <IDENTIFIER> Action31 _ PAREN_OPEN (expressionList / &{ p.errorHere(position, `expected expression list to follow "(" in function call`) }) optionalGroupBy ((_ PAREN_CLOSE) / &{ p.errorHere(position, `expected ")" to close "(" opened by function call`) }) Action32)> */
+		/* 23 expression_function <- <(_ <IDENTIFIER> Action35 _ PAREN_OPEN (expressionList / &{ p.errorExpecting(position, expect(`expression list`), `to follow "(" in function call`) }) optionalGroupBy ((_ PAREN_CLOSE) / &{ p.errorExpecting(position, expect(`")"`), `to close "(" opened by function call`) }) Action36)> */
 		nil,
-		/* 22 expression_metric <- <(_ <IDENTIFIER> Action33 ((_ '[' (predicate_1 / &{ p.errorHere(position, `expected predicate to follow "[" after metric`) }) ((_ ']') / &{ p.errorHere(position, `expected "]" to close "[" opened to apply predicate`) })) / Action34) Action35)> */
+		/* 24 expression_metric <- <(_ <IDENTIFIER> Action37 ((_ '[' (predicate_1 / &{ p.errorExpecting(position, expect(`predicate`), `to follow "[" after metric`) }) ((_ ']') / &{ p.errorExpecting(position, expect(`"]"`), `to close "[" opened to apply predicate`) })) / Action38) Action39)> */
 		nil,
-		/* 23 groupByClause <- <(_ (('g' / 'G') ('r' / 'R') ('o' / 'O') ('u' / 'U') ('p' / 'P')) KEY ((_ (('b' / 'B') ('y' / 'Y')) KEY) / &{ p.errorHere(position, `expected keyword "by" to follow keyword "group" in "group by" clause`) }) ((_ <COLUMN_NAME>) / &{ p.errorHere(position, `expected tag key identifier to follow "group by" keywords in "group by" clause`) }) Action36 Action37 (_ COMMA ((_ <COLUMN_NAME>) / &{ p.errorHere(position, `expected tag key identifier to follow "," in "group by" clause`) }) Action38)*)> */
+		/* 25 groupByClause <- <(_ (('g' / 'G') ('r' / 'R') ('o' / 'O') ('u' / 'U') ('p' / 'P')) KEY ((_ (('b' / 'B') ('y' / 'Y')) KEY) / &{ p.errorExpecting(position, expect(`keyword "by"`), `to follow keyword "group" in "group by" clause`) }) ((_ <COLUMN_NAME>) / &{ p.errorExpecting(position, expect(`tag key identifier`), `to follow "group by" keywords in "group by" clause`) }) Action40 Action41 (_ COMMA ((_ <COLUMN_NAME>) / &{ p.errorExpecting(position, expect(`tag key identifier`), `to follow "," in "group by" clause`) }) Action42)*)> */
 		nil,
-		/* 24 collapseByClause <- <(_ (('c' / 'C') ('o' / 'O') ('l' / 'L') ('l' / 'L') ('a' / 'A') ('p' / 'P') ('s' / 'S') ('e' / 'E')) KEY ((_ (('b' / 'B') ('y' / 'Y')) KEY) / &{ p.errorHere(position, `expected keyword "by" to follow keyword "collapse" in "collapse by" clause`) }) ((_ <COLUMN_NAME>) / &{ p.errorHere(position, `expected tag key identifier to follow "collapse by" keywords in "collapse by" clause`) }) Action39 Action40 (_ COMMA ((_ <COLUMN_NAME>) / &{ p.errorHere(position, `expected tag key identifier to follow "," in "collapse by" clause`) }) Action41)*)> */
+		/* 26 collapseByClause <- <(_ (('c' / 'C') ('o' / 'O') ('l' / 'L') ('l' / 'L') ('a' / 'A') ('p' / 'P') ('s' / 'S') ('e' / 'E')) KEY ((_ (('b' / 'B') ('y' / 'Y')) KEY) / &{ p.errorExpecting(position, expect(`keyword "by"`), `to follow keyword "collapse" in "collapse by" clause`) }) ((_ <COLUMN_NAME>) / &{ p.errorExpecting(position, expect(`tag key identifier`), `to follow "collapse by" keywords in "collapse by" clause`) }) Action43 Action44 (_ COMMA ((_ <COLUMN_NAME>) / &{ p.errorExpecting(position, expect(`tag key identifier`), `to follow "," in "collapse by" clause`) }) Action45)*)> */
 		nil,
-		/* 25 predicateClause <- <(_ (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E')) KEY ((_ predicate_1) / &{ p.errorHere(position, `expected predicate to follow "where" keyword`) }))> */
+		/* 27 predicateClause <- <(_ (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E')) KEY ((_ predicate_1) / &{ p.errorExpecting(position, expect(`predicate`), `to follow "where" keyword`) }))> */
 		nil,
-		/* 26 predicate_1 <- <((predicate_2 _ OP_OR (predicate_1 / &{ p.errorHere(position, `expected predicate to follow "or" operator`) }) Action42) / predicate_2)> */
+		/* 28 predicate_1 <- <((predicate_2 _ OP_OR (predicate_1 / &{ p.errorExpecting(position, expect(`predicate`), `to follow "or" operator`) }) Action46) / predicate_2)> */
 		func() bool {
 			position422, tokenIndex422, depth422 := position, tokenIndex, depth
 			{
@@ -3748,13 +5370,13 @@ func (p *Parser) Init() {
 						goto l431
 					l432:
 						position, tokenIndex, depth = position431, tokenIndex431, depth431
-						if !(p.errorHere(position, `expected predicate to follow "or" operator`)) {
+						if !(p.errorExpecting(position, expect(`predicate`), `to follow "or" operator`)) {
 							goto l425
 						}
 					}
 				l431:
 					{
-						add(ruleAction42, position)
+						add(ruleAction46, position)
 					}
 					goto l424
 				l425:
@@ -3772,7 +5394,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position422, tokenIndex422, depth422
 			return false
 		},
-		/* 27 predicate_2 <- <((predicate_3 _ OP_AND (predicate_2 / &{ p.errorHere(position, `expected predicate to follow "and" operator`) }) Action43) / predicate_3)> */
+		/* 29 predicate_2 <- <((predicate_3 _ OP_AND (predicate_2 / &{ p.errorExpecting(position, expect(`predicate`), `to follow "and" operator`) }) Action47) / predicate_3)> */
 		func() bool {
 			position434, tokenIndex434, depth434 := position, tokenIndex, depth
 			{
@@ -3848,13 +5470,13 @@ func (p *Parser) Init() {
 						goto l445
 					l446:
 						position, tokenIndex, depth = position445, tokenIndex445, depth445
-						if !(p.errorHere(position, `expected predicate to follow "and" operator`)) {
+						if !(p.errorExpecting(position, expect(`predicate`), `to follow "and" operator`)) {
 							goto l437
 						}
 					}
 				l445:
 					{
-						add(ruleAction43, position)
+						add(ruleAction47, position)
 					}
 					goto l436
 				l437:
@@ -3872,7 +5494,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position434, tokenIndex434, depth434
 			return false
 		},
-		/* 28 predicate_3 <- <((_ OP_NOT (predicate_3 / &{ p.errorHere(position, `expected predicate to follow "not" operator`) }) Action44) / (_ PAREN_OPEN (predicate_1 / &{ p.errorHere(position, `expected predicate to follow "("`) }) ((_ PAREN_CLOSE) / &{ p.errorHere(position, `expected ")" to close "(" opened in predicate`) })) / tagMatcher)> */
+		/* 30 predicate_3 <- <((_ OP_NOT (predicate_3 / &{ p.errorExpecting(position, expect(`predicate`), `to follow "not" operator`) }) Action48) / (_ PAREN_OPEN (predicate_1 / &{ p.errorExpecting(position, expect(`predicate`), `to follow "("`) }) ((_ PAREN_CLOSE) / &{ p.errorExpecting(position, expect(`")"`), `to close "(" opened in predicate`) })) / tagMatcher)> */
 		func() bool {
 			position448, tokenIndex448, depth448 := position, tokenIndex, depth
 			{
@@ -3945,13 +5567,13 @@ func (p *Parser) Init() {
 						goto l459
 					l460:
 						position, tokenIndex, depth = position459, tokenIndex459, depth459
-						if !(p.errorHere(position, `expected predicate to follow "not" operator`)) {
+						if !(p.errorExpecting(position, expect(`predicate`), `to follow "not" operator`)) {
 							goto l451
 						}
 					}
 				l459:
 					{
-						add(ruleAction44, position)
+						add(ruleAction48, position)
 					}
 					goto l450
 				l451:
@@ -3970,7 +5592,7 @@ func (p *Parser) Init() {
 						goto l463
 					l464:
 						position, tokenIndex, depth = position463, tokenIndex463, depth463
-						if !(p.errorHere(position, `expected predicate to follow "("`)) {
+						if !(p.errorExpecting(position, expect(`predicate`), `to follow "("`)) {
 							goto l462
 						}
 					}
@@ -3986,7 +5608,7 @@ func (p *Parser) Init() {
 						goto l465
 					l466:
 						position, tokenIndex, depth = position465, tokenIndex465, depth465
-						if !(p.errorHere(position, `expected ")" to close "(" opened in predicate`)) {
+						if !(p.errorExpecting(position, expect(`")"`), `to close "(" opened in predicate`)) {
 							goto l462
 						}
 					}
@@ -4017,13 +5639,13 @@ func (p *Parser) Init() {
 								goto l470
 							l471:
 								position, tokenIndex, depth = position470, tokenIndex470, depth470
-								if !(p.errorHere(position, `expected string literal to follow "="`)) {
+								if !(p.errorExpecting(position, expect(`string literal`), `to follow "="`)) {
 									goto l469
 								}
 							}
 						l470:
 							{
-								add(ruleAction45, position)
+								add(ruleAction49, position)
 							}
 							goto l468
 						l469:
@@ -4047,16 +5669,16 @@ func (p *Parser) Init() {
 								goto l474
 							l475:
 								position, tokenIndex, depth = position474, tokenIndex474, depth474
-								if !(p.errorHere(position, `expected string literal to follow "!="`)) {
+								if !(p.errorExpecting(position, expect(`string literal`), `to follow "!="`)) {
 									goto l473
 								}
 							}
 						l474:
 							{
-								add(ruleAction46, position)
+								add(ruleAction50, position)
 							}
 							{
-								add(ruleAction47, position)
+								add(ruleAction51, position)
 							}
 							goto l468
 						l473:
@@ -4150,13 +5772,13 @@ func (p *Parser) Init() {
 								goto l489
 							l490:
 								position, tokenIndex, depth = position489, tokenIndex489, depth489
-								if !(p.errorHere(position, `expected regex string literal to follow "match"`)) {
+								if !(p.errorExpecting(position, expect(`regex string literal`), `to follow "match"`)) {
 									goto l478
 								}
 							}
 						l489:
 							{
-								add(ruleAction48, position)
+								add(ruleAction52, position)
 							}
 							goto l468
 						l478:
@@ -4203,87 +5825,103 @@ func (p *Parser) Init() {
 									position499 := position
 									depth++
 									{
-										add(ruleAction51, position)
-									}
-									if !_rules[rule_]() {
-										goto l498
-									}
-									if !_rules[rulePAREN_OPEN]() {
-										goto l498
-									}
-									{
-										position501, tokenIndex501, depth501 := position, tokenIndex, depth
-										if !_rules[ruleliteralListString]() {
-											goto l502
+										position1058, tokenIndex1058, depth1058 := position, tokenIndex, depth
+										if !_rules[rule_]() {
+											goto l1059
 										}
-										goto l501
-									l502:
-										position, tokenIndex, depth = position501, tokenIndex501, depth501
-										if !(p.errorHere(position, `expected string literal to follow "(" in literal list`)) {
-											goto l498
+										if !_rules[ruleVARIABLE]() {
+											goto l1059
+										}
+										{
+											add(ruleAction56, position)
+										}
+										goto l1058
+									l1059:
+										position, tokenIndex, depth = position1058, tokenIndex1058, depth1058
+										{
+											add(ruleAction57, position)
 										}
-									}
-								l501:
-								l503:
-									{
-										position504, tokenIndex504, depth504 := position, tokenIndex, depth
 										if !_rules[rule_]() {
-											goto l504
+											goto l498
 										}
-										if !_rules[ruleCOMMA]() {
-											goto l504
+										if !_rules[rulePAREN_OPEN]() {
+											goto l498
 										}
 										{
-											position505, tokenIndex505, depth505 := position, tokenIndex, depth
+											position501, tokenIndex501, depth501 := position, tokenIndex, depth
 											if !_rules[ruleliteralListString]() {
-												goto l506
+												goto l502
 											}
-											goto l505
-										l506:
-											position, tokenIndex, depth = position505, tokenIndex505, depth505
-											if !(p.errorHere(position, `expected string literal to follow "," in literal list`)) {
-												goto l504
+											goto l501
+										l502:
+											position, tokenIndex, depth = position501, tokenIndex501, depth501
+											if !(p.errorExpecting(position, expect(`string literal`), `to follow "(" in literal list`)) {
+												goto l498
 											}
 										}
-									l505:
-										goto l503
-									l504:
-										position, tokenIndex, depth = position504, tokenIndex504, depth504
-									}
-									{
-										position507, tokenIndex507, depth507 := position, tokenIndex, depth
-										if !_rules[rule_]() {
-											goto l508
-										}
-										if !_rules[rulePAREN_CLOSE]() {
-											goto l508
+									l501:
+									l503:
+										{
+											position504, tokenIndex504, depth504 := position, tokenIndex, depth
+											if !_rules[rule_]() {
+												goto l504
+											}
+											if !_rules[ruleCOMMA]() {
+												goto l504
+											}
+											{
+												position505, tokenIndex505, depth505 := position, tokenIndex, depth
+												if !_rules[ruleliteralListString]() {
+													goto l506
+												}
+												goto l505
+											l506:
+												position, tokenIndex, depth = position505, tokenIndex505, depth505
+												if !(p.errorExpecting(position, expect(`string literal`), `to follow "," in literal list`)) {
+													goto l504
+												}
+											}
+										l505:
+											goto l503
+										l504:
+											position, tokenIndex, depth = position504, tokenIndex504, depth504
 										}
-										goto l507
-									l508:
-										position, tokenIndex, depth = position507, tokenIndex507, depth507
-										if !(p.errorHere(position, `expected ")" to close "(" for literal list`)) {
-											goto l498
+										{
+											position507, tokenIndex507, depth507 := position, tokenIndex, depth
+											if !_rules[rule_]() {
+												goto l508
+											}
+											if !_rules[rulePAREN_CLOSE]() {
+												goto l508
+											}
+											goto l507
+										l508:
+											position, tokenIndex, depth = position507, tokenIndex507, depth507
+											if !(p.errorExpecting(position, expect(`")"`), `to close "(" for literal list`)) {
+												goto l498
+											}
 										}
+									l507:
 									}
-								l507:
+								l1058:
 									depth--
 									add(ruleliteralList, position499)
 								}
 								goto l497
 							l498:
 								position, tokenIndex, depth = position497, tokenIndex497, depth497
-								if !(p.errorHere(position, `expected string literal list to follow "in" keyword`)) {
+								if !(p.errorExpecting(position, expect(`string literal list`), `to follow "in" keyword`)) {
 									goto l492
 								}
 							}
 						l497:
 							{
-								add(ruleAction49, position)
+								add(ruleAction53, position)
 							}
 							goto l468
 						l492:
 							position, tokenIndex, depth = position468, tokenIndex468, depth468
-							if !(p.errorHere(position, `expected "=", "!=", "match", or "in" to follow tag key in predicate`)) {
+							if !(p.errorExpecting(position, expect(`"="`, `"!="`, `"match"`, `"in"`), `to follow tag key in predicate`)) {
 								goto l448
 							}
 						}
@@ -4301,23 +5939,39 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position448, tokenIndex448, depth448
 			return false
 		},
-		/* 29 tagMatcher <- <(tagName ((_ '=' (literalString / &{ p.errorHere(position, `expected string literal to follow "="`) }) Action45) / (_ ('!' '=') (literalString / &{ p.errorHere(position, `expected string literal to follow "!="`) }) Action46 Action47) / (_ (('m' / 'M') ('a' / 'A') ('t' / 'T') ('c' / 'C') ('h' / 'H')) KEY (literalString / &{ p.errorHere(position, `expected regex string literal to follow "match"`) }) Action48) / (_ (('i' / 'I') ('n' / 'N')) KEY (literalList / &{ p.errorHere(position, `expected string literal list to follow "in" keyword`) }) Action49) / &{ p.errorHere(position, `expected "=", "!=", "match", or "in" to follow tag key in predicate`) }))> */
+		/* 31 tagMatcher <- <(tagName ((_ '=' (literalString / &{ p.errorExpecting(position, expect(`string literal`), `to follow "="`) }) Action49) / (_ ('!' '=') (literalString / &{ p.errorExpecting(position, expect(`string literal`), `to follow "!="`) }) Action50 Action51) / (_ (('m' / 'M') ('a' / 'A') ('t' / 'T') ('c' / 'C') ('h' / 'H')) KEY (literalString / &{ p.errorExpecting(position, expect(`regex string literal`), `to follow "match"`) }) Action52) / (_ (('i' / 'I') ('n' / 'N')) KEY (literalList / &{ p.errorExpecting(position, expect(`string literal list`), `to follow "in" keyword`) }) Action53) / &{ p.errorExpecting(position, expect(`"="`, `"!="`, `"match"`, `"in"`), `to follow tag key in predicate`) }))> */
 		nil,
-		/* 30 literalString <- <(_ STRING Action50)> */
+		/* 32 literalString <- <((_ STRING Action54) / (_ VARIABLE Action55))> */
 		func() bool {
 			position511, tokenIndex511, depth511 := position, tokenIndex, depth
 			{
 				position512 := position
 				depth++
-				if !_rules[rule_]() {
-					goto l511
-				}
-				if !_rules[ruleSTRING]() {
-					goto l511
-				}
 				{
-					add(ruleAction50, position)
+					position1056, tokenIndex1056, depth1056 := position, tokenIndex, depth
+					if !_rules[rule_]() {
+						goto l1057
+					}
+					if !_rules[ruleSTRING]() {
+						goto l1057
+					}
+					{
+						add(ruleAction54, position)
+					}
+					goto l1056
+				l1057:
+					position, tokenIndex, depth = position1056, tokenIndex1056, depth1056
+					if !_rules[rule_]() {
+						goto l511
+					}
+					if !_rules[ruleVARIABLE]() {
+						goto l511
+					}
+					{
+						add(ruleAction55, position)
+					}
 				}
+			l1056:
 				depth--
 				add(ruleliteralString, position512)
 			}
@@ -4326,9 +5980,9 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position511, tokenIndex511, depth511
 			return false
 		},
-		/* 31 literalList <- <(Action51 _ PAREN_OPEN (literalListString / &{ p.errorHere(position, `expected string literal to follow "(" in literal list`) }) (_ COMMA (literalListString / &{ p.errorHere(position, `expected string literal to follow "," in literal list`) }))* ((_ PAREN_CLOSE) / &{ p.errorHere(position, `expected ")" to close "(" for literal list`) }))> */
+		/* 33 literalList <- <((_ VARIABLE Action56) / (Action57 _ PAREN_OPEN (literalListString / &{ p.errorExpecting(position, expect(`string literal`), `to follow "(" in literal list`) }) (_ COMMA (literalListString / &{ p.errorExpecting(position, expect(`string literal`), `to follow "," in literal list`) }))* ((_ PAREN_CLOSE) / &{ p.errorExpecting(position, expect(`")"`), `to close "(" for literal list`) })))> */
 		nil,
-		/* 32 literalListString <- <(_ STRING Action52)> */
+		/* 34 literalListString <- <(_ STRING Action58)> */
 		func() bool {
 			position515, tokenIndex515, depth515 := position, tokenIndex, depth
 			{
@@ -4341,7 +5995,7 @@ func (p *Parser) Init() {
 					goto l515
 				}
 				{
-					add(ruleAction52, position)
+					add(ruleAction58, position)
 				}
 				depth--
 				add(ruleliteralListString, position516)
@@ -4351,7 +6005,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position515, tokenIndex515, depth515
 			return false
 		},
-		/* 33 tagName <- <(_ <TAG_NAME> Action53)> */
+		/* 35 tagName <- <(_ <TAG_NAME> Action59)> */
 		func() bool {
 			position518, tokenIndex518, depth518 := position, tokenIndex, depth
 			{
@@ -4376,7 +6030,7 @@ func (p *Parser) Init() {
 					add(rulePegText, position520)
 				}
 				{
-					add(ruleAction53, position)
+					add(ruleAction59, position)
 				}
 				depth--
 				add(ruletagName, position519)
@@ -4386,7 +6040,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position518, tokenIndex518, depth518
 			return false
 		},
-		/* 34 COLUMN_NAME <- <IDENTIFIER> */
+		/* 36 COLUMN_NAME <- <IDENTIFIER> */
 		func() bool {
 			position523, tokenIndex523, depth523 := position, tokenIndex, depth
 			{
@@ -4403,11 +6057,11 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position523, tokenIndex523, depth523
 			return false
 		},
-		/* 35 METRIC_NAME <- <IDENTIFIER> */
+		/* 37 METRIC_NAME <- <IDENTIFIER> */
 		nil,
-		/* 36 TAG_NAME <- <IDENTIFIER> */
+		/* 38 TAG_NAME <- <IDENTIFIER> */
 		nil,
-		/* 37 IDENTIFIER <- <(('`' CHAR* ('`' / &{ p.errorHere(position, "expected \"`\" to end identifier") })) / (!(KEYWORD KEY) ID_SEGMENT ('.' (ID_SEGMENT / &{ p.errorHere(position, `expected identifier segment to follow "."`) }))*))> */
+		/* 39 IDENTIFIER <- <(('`' CHAR* ('`' / &{ p.errorExpecting(position, expect("\"`\""), `to end identifier`) })) / (!(KEYWORD KEY) ID_SEGMENT ('.' (ID_SEGMENT / &{ p.errorExpecting(position, expect(`identifier segment`), `to follow "."`) }))*))> */
 		func() bool {
 			position527, tokenIndex527, depth527 := position, tokenIndex, depth
 			{
@@ -4438,7 +6092,7 @@ func (p *Parser) Init() {
 						goto l533
 					l534:
 						position, tokenIndex, depth = position533, tokenIndex533, depth533
-						if !(p.errorHere(position, "expected \"`\" to end identifier")) {
+						if !(p.errorExpecting(position, expect("\"`\""), `to end identifier`)) {
 							goto l530
 						}
 					}
@@ -5773,7 +7427,7 @@ func (p *Parser) Init() {
 							goto l711
 						l712:
 							position, tokenIndex, depth = position711, tokenIndex711, depth711
-							if !(p.errorHere(position, `expected identifier segment to follow "."`)) {
+							if !(p.errorExpecting(position, expect(`identifier segment`), `to follow "."`)) {
 								goto l710
 							}
 						}
@@ -5792,9 +7446,9 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position527, tokenIndex527, depth527
 			return false
 		},
-		/* 38 TIMESTAMP <- <((_ <(NUMBER ([a-z] / [A-Z])*)>) / (_ STRING) / (_ <(('n' / 'N') ('o' / 'O') ('w' / 'W'))> KEY))> */
+		/* 40 TIMESTAMP <- <((_ <(NUMBER ([a-z] / [A-Z])*)>) / (_ STRING) / (_ <((('n' / 'N') ('o' / 'O') ('w' / 'W')) / (('t' / 'T') ('o' / 'O') ('d' / 'D') ('a' / 'A') ('y' / 'Y')) / (('t' / 'T') ('o' / 'O') ('m' / 'M') ('o' / 'O') ('r' / 'R') ('r' / 'R') ('o' / 'O') ('w' / 'W')) / (('y' / 'Y') ('e' / 'E') ('s' / 'S') ('t' / 'T') ('e' / 'E') ('r' / 'R') ('d' / 'D') ('a' / 'A') ('y' / 'Y')) / (('s' / 'S') ('t' / 'T') ('a' / 'A') ('r' / 'R') ('t' / 'T') ('o' / 'O') ('f' / 'F') ('d' / 'D') ('a' / 'A') ('y' / 'Y')) / (('s' / 'S') ('t' / 'T') ('a' / 'A') ('r' / 'R') ('t' / 'T') ('o' / 'O') ('f' / 'F') ('w' / 'W') ('e' / 'E') ('e' / 'E') ('k' / 'K')) / (('s' / 'S') ('t' / 'T') ('a' / 'A') ('r' / 'R') ('t' / 'T') ('o' / 'O') ('f' / 'F') ('m' / 'M') ('o' / 'O') ('n' / 'N') ('t' / 'T') ('h' / 'H')) / (('s' / 'S') ('t' / 'T') ('a' / 'A') ('r' / 'R') ('t' / 'T') ('o' / 'O') ('f' / 'F') ('y' / 'Y') ('e' / 'E') ('a' / 'A') ('r' / 'R'))) KEY (_ ('+' / '-') _ NUMBER ([a-z] / [A-Z])+ KEY)?)>))> */
 		nil,
-		/* 39 ID_SEGMENT <- <(ID_START ID_CONT*)> */
+		/* 41 ID_SEGMENT <- <(ID_START ID_CONT*)> */
 		func() bool {
 			position714, tokenIndex714, depth714 := position, tokenIndex, depth
 			{
@@ -5821,7 +7475,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position714, tokenIndex714, depth714
 			return false
 		},
-		/* 40 ID_START <- <((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))> */
+		/* 42 ID_START <- <((&('_') '_') | (&('A' | 'B' | 'C' | 'D' | 'E' | 'F' | 'G' | 'H' | 'I' | 'J' | 'K' | 'L' | 'M' | 'N' | 'O' | 'P' | 'Q' | 'R' | 'S' | 'T' | 'U' | 'V' | 'W' | 'X' | 'Y' | 'Z') [A-Z]) | (&('a' | 'b' | 'c' | 'd' | 'e' | 'f' | 'g' | 'h' | 'i' | 'j' | 'k' | 'l' | 'm' | 'n' | 'o' | 'p' | 'q' | 'r' | 's' | 't' | 'u' | 'v' | 'w' | 'x' | 'y' | 'z') [a-z]))> */
 		func() bool {
 			position718, tokenIndex718, depth718 := position, tokenIndex, depth
 			{
@@ -5858,7 +7512,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position718, tokenIndex718, depth718
 			return false
 		},
-		/* 41 ID_CONT <- <(ID_START / [0-9])> */
+		/* 43 ID_CONT <- <(ID_START / [0-9])> */
 		func() bool {
 			position721, tokenIndex721, depth721 := position, tokenIndex, depth
 			{
@@ -5886,29 +7540,29 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position721, tokenIndex721, depth721
 			return false
 		},
-		/* 42 PROPERTY_KEY <- <((&('S' | 's') (<(('s' / 'S') ('a' / 'A') ('m' / 'M') ('p' / 'P') ('l' / 'L') ('e' / 'E'))> KEY ((_ (('b' / 'B') ('y' / 'Y')) KEY) / &{ p.errorHere(position, `expected keyword "by" to follow keyword "sample"`) }))) | (&('R' | 'r') (<(('r' / 'R') ('e' / 'E') ('s' / 'S') ('o' / 'O') ('l' / 'L') ('u' / 'U') ('t' / 'T') ('i' / 'I') ('o' / 'O') ('n' / 'N'))> KEY)) | (&('T' | 't') (<(('t' / 'T') ('o' / 'O'))> KEY)) | (&('F' | 'f') (<(('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M'))> KEY)))> */
+		/* 44 PROPERTY_KEY <- <((&('S' | 's') (<(('s' / 'S') ('a' / 'A') ('m' / 'M') ('p' / 'P') ('l' / 'L') ('e' / 'E'))> KEY ((_ (('b' / 'B') ('y' / 'Y')) KEY) / &{ p.errorExpecting(position, expect(`keyword "by"`), `to follow keyword "sample"`) }))) | (&('R' | 'r') (<(('r' / 'R') ('e' / 'E') ('s' / 'S') ('o' / 'O') ('l' / 'L') ('u' / 'U') ('t' / 'T') ('i' / 'I') ('o' / 'O') ('n' / 'N'))> KEY)) | (&('T' | 't') ((<(('t' / 'T') ('o' / 'O'))> KEY) / (<(('t' / 'T') ('i' / 'I') ('m' / 'M') ('e' / 'E') ('z' / 'Z') ('o' / 'O') ('n' / 'N') ('e' / 'E'))> KEY))) | (&('F' | 'f') (<(('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M'))> KEY)))> */
 		nil,
-		/* 43 PROPERTY_VALUE <- <TIMESTAMP> */
+		/* 45 PROPERTY_VALUE <- <TIMESTAMP> */
 		nil,
-		/* 44 KEYWORD <- <((('a' / 'A') ('l' / 'L') ('l' / 'L')) / (('a' / 'A') ('n' / 'N') ('d' / 'D')) / (('m' / 'M') ('a' / 'A') ('t' / 'T') ('c' / 'C') ('h' / 'H')) / (('s' / 'S') ('e' / 'E') ('l' / 'L') ('e' / 'E') ('c' / 'C') ('t' / 'T')) / ((&('S' | 's') (('s' / 'S') ('a' / 'A') ('m' / 'M') ('p' / 'P') ('l' / 'L') ('e' / 'E'))) | (&('R' | 'r') (('r' / 'R') ('e' / 'E') ('s' / 'S') ('o' / 'O') ('l' / 'L') ('u' / 'U') ('t' / 'T') ('i' / 'I') ('o' / 'O') ('n' / 'N'))) | (&('T' | 't') (('t' / 'T') ('o' / 'O'))) | (&('F' | 'f') (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M'))) | (&('M' | 'm') (('m' / 'M') ('e' / 'E') ('t' / 'T') ('r' / 'R') ('i' / 'I') ('c' / 'C') ('s' / 'S'))) | (&('W' | 'w') (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E'))) | (&('O' | 'o') (('o' / 'O') ('r' / 'R'))) | (&('N' | 'n') (('n' / 'N') ('o' / 'O') ('t' / 'T'))) | (&('I' | 'i') (('i' / 'I') ('n' / 'N'))) | (&('C' | 'c') (('c' / 'C') ('o' / 'O') ('l' / 'L') ('l' / 'L') ('a' / 'A') ('p' / 'P') ('s' / 'S') ('e' / 'E'))) | (&('G' | 'g') (('g' / 'G') ('r' / 'R') ('o' / 'O') ('u' / 'U') ('p' / 'P'))) | (&('D' | 'd') (('d' / 'D') ('e' / 'E') ('s' / 'S') ('c' / 'C') ('r' / 'R') ('i' / 'I') ('b' / 'B') ('e' / 'E'))) | (&('B' | 'b') (('b' / 'B') ('y' / 'Y'))) | (&('A' | 'a') (('a' / 'A') ('s' / 'S')))))> */
+		/* 46 KEYWORD <- <((('a' / 'A') ('l' / 'L') ('l' / 'L')) / (('a' / 'A') ('n' / 'N') ('d' / 'D')) / (('m' / 'M') ('a' / 'A') ('t' / 'T') ('c' / 'C') ('h' / 'H')) / (('s' / 'S') ('e' / 'E') ('l' / 'L') ('e' / 'E') ('c' / 'C') ('t' / 'T')) / ((&('S' | 's') (('s' / 'S') ('a' / 'A') ('m' / 'M') ('p' / 'P') ('l' / 'L') ('e' / 'E'))) | (&('R' | 'r') (('r' / 'R') ('e' / 'E') ('s' / 'S') ('o' / 'O') ('l' / 'L') ('u' / 'U') ('t' / 'T') ('i' / 'I') ('o' / 'O') ('n' / 'N'))) | (&('T' | 't') (('t' / 'T') ('o' / 'O'))) | (&('F' | 'f') (('f' / 'F') ('r' / 'R') ('o' / 'O') ('m' / 'M'))) | (&('M' | 'm') (('m' / 'M') ('e' / 'E') ('t' / 'T') ('r' / 'R') ('i' / 'I') ('c' / 'C') ('s' / 'S'))) | (&('W' | 'w') (('w' / 'W') ('h' / 'H') ('e' / 'E') ('r' / 'R') ('e' / 'E'))) | (&('O' | 'o') (('o' / 'O') ('r' / 'R'))) | (&('N' | 'n') (('n' / 'N') ('o' / 'O') ('t' / 'T'))) | (&('I' | 'i') (('i' / 'I') ('n' / 'N'))) | (&('C' | 'c') (('c' / 'C') ('o' / 'O') ('l' / 'L') ('l' / 'L') ('a' / 'A') ('p' / 'P') ('s' / 'S') ('e' / 'E'))) | (&('G' | 'g') (('g' / 'G') ('r' / 'R') ('o' / 'O') ('u' / 'U') ('p' / 'P'))) | (&('D' | 'd') (('d' / 'D') ('e' / 'E') ('s' / 'S') ('c' / 'C') ('r' / 'R') ('i' / 'I') ('b' / 'B') ('e' / 'E'))) | (&('B' | 'b') (('b' / 'B') ('y' / 'Y'))) | (&('A' | 'a') (('a' / 'A') ('s' / 'S')))))> */
 		nil,
-		/* 45 OP_PIPE <- <'|'> */
+		/* 47 OP_PIPE <- <'|'> */
 		nil,
-		/* 46 OP_ADD <- <'+'> */
+		/* 48 OP_ADD <- <'+'> */
 		nil,
-		/* 47 OP_SUB <- <'-'> */
+		/* 49 OP_SUB <- <'-'> */
 		nil,
-		/* 48 OP_MULT <- <'*'> */
+		/* 50 OP_MULT <- <'*'> */
 		nil,
-		/* 49 OP_DIV <- <'/'> */
+		/* 51 OP_DIV <- <'/'> */
 		nil,
-		/* 50 OP_AND <- <(('a' / 'A') ('n' / 'N') ('d' / 'D') KEY)> */
+		/* 52 OP_AND <- <(('a' / 'A') ('n' / 'N') ('d' / 'D') KEY)> */
 		nil,
-		/* 51 OP_OR <- <(('o' / 'O') ('r' / 'R') KEY)> */
+		/* 53 OP_OR <- <(('o' / 'O') ('r' / 'R') KEY)> */
 		nil,
-		/* 52 OP_NOT <- <(('n' / 'N') ('o' / 'O') ('t' / 'T') KEY)> */
+		/* 54 OP_NOT <- <(('n' / 'N') ('o' / 'O') ('t' / 'T') KEY)> */
 		nil,
-		/* 53 QUOTE_SINGLE <- <'\''> */
+		/* 55 QUOTE_SINGLE <- <'\''> */
 		func() bool {
 			position736, tokenIndex736, depth736 := position, tokenIndex, depth
 			{
@@ -5926,7 +7580,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position736, tokenIndex736, depth736
 			return false
 		},
-		/* 54 QUOTE_DOUBLE <- <'"'> */
+		/* 56 QUOTE_DOUBLE <- <'"'> */
 		func() bool {
 			position738, tokenIndex738, depth738 := position, tokenIndex, depth
 			{
@@ -5944,7 +7598,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position738, tokenIndex738, depth738
 			return false
 		},
-		/* 55 STRING <- <((QUOTE_SINGLE <(!QUOTE_SINGLE CHAR)*> (QUOTE_SINGLE / &{ p.errorHere(position, `expected "'" to close string`) })) / (QUOTE_DOUBLE <(!QUOTE_DOUBLE CHAR)*> (QUOTE_DOUBLE / &{ p.errorHere(position, `expected '"' to close string`) })))> */
+		/* 57 STRING <- <((QUOTE_SINGLE <(!QUOTE_SINGLE CHAR)*> (QUOTE_SINGLE / &{ p.errorExpecting(position, expect(`"'"`), `to close string`) })) / (QUOTE_DOUBLE <(!QUOTE_DOUBLE CHAR)*> (QUOTE_DOUBLE / &{ p.errorExpecting(position, expect(`'"'`), `to close string`) })))> */
 		func() bool {
 			position740, tokenIndex740, depth740 := position, tokenIndex, depth
 			{
@@ -5988,7 +7642,7 @@ func (p *Parser) Init() {
 						goto l748
 					l749:
 						position, tokenIndex, depth = position748, tokenIndex748, depth748
-						if !(p.errorHere(position, `expected "'" to close string`)) {
+						if !(p.errorExpecting(position, expect(`"'"`), `to close string`)) {
 							goto l743
 						}
 					}
@@ -6032,7 +7686,7 @@ func (p *Parser) Init() {
 						goto l754
 					l755:
 						position, tokenIndex, depth = position754, tokenIndex754, depth754
-						if !(p.errorHere(position, `expected '"' to close string`)) {
+						if !(p.errorExpecting(position, expect(`'"'`), `to close string`)) {
 							goto l740
 						}
 					}
@@ -6047,7 +7701,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position740, tokenIndex740, depth740
 			return false
 		},
-		/* 56 CHAR <- <(('\\' ((&('"') (QUOTE_DOUBLE / &{ p.errorHere(position, "expected \"\\\", \"'\", \"`\", or '\"' to follow \"\\\" in string literal") })) | (&('\'') QUOTE_SINGLE) | (&('\\' | '`') ESCAPE_CLASS))) / (!ESCAPE_CLASS .))> */
+		/* 58 CHAR <- <(('\\' ((&('"') (QUOTE_DOUBLE / &{ p.errorExpecting(position, expect(`"\"`, `"'"`, "\"`\"", `'"'`), `to follow "\" in string literal`) })) | (&('\'') QUOTE_SINGLE) | (&('\\' | '`') ESCAPE_CLASS))) / (!ESCAPE_CLASS .))> */
 		func() bool {
 			position756, tokenIndex756, depth756 := position, tokenIndex, depth
 			{
@@ -6070,7 +7724,7 @@ func (p *Parser) Init() {
 								goto l761
 							l762:
 								position, tokenIndex, depth = position761, tokenIndex761, depth761
-								if !(p.errorHere(position, "expected \"\\\", \"'\", \"`\", or '\"' to follow \"\\\" in string literal")) {
+								if !(p.errorExpecting(position, expect(`"\"`, `"'"`, "\"`\"", `'"'`), `to follow "\" in string literal`)) {
 									goto l759
 								}
 							}
@@ -6114,7 +7768,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position756, tokenIndex756, depth756
 			return false
 		},
-		/* 57 ESCAPE_CLASS <- <('`' / '\\')> */
+		/* 59 ESCAPE_CLASS <- <('`' / '\\')> */
 		func() bool {
 			position764, tokenIndex764, depth764 := position, tokenIndex, depth
 			{
@@ -6143,7 +7797,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position764, tokenIndex764, depth764
 			return false
 		},
-		/* 58 NUMBER <- <(NUMBER_INTEGER NUMBER_FRACTION? NUMBER_EXP?)> */
+		/* 60 NUMBER <- <(NUMBER_INTEGER NUMBER_FRACTION? NUMBER_EXP?)> */
 		func() bool {
 			position768, tokenIndex768, depth768 := position, tokenIndex, depth
 			{
@@ -6292,7 +7946,7 @@ func (p *Parser) Init() {
 							goto l792
 						l793:
 							position, tokenIndex, depth = position792, tokenIndex792, depth792
-							if !(p.errorHere(position, `expected exponent`)) {
+							if !(p.errorExpecting(position, expect(`exponent`), ``)) {
 								goto l783
 							}
 						}
@@ -6313,17 +7967,44 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position768, tokenIndex768, depth768
 			return false
 		},
-		/* 59 NUMBER_NATURAL <- <('0' / ([1-9] [0-9]*))> */
+		/* 61 NUMBER_NATURAL <- <('0' / ([1-9] [0-9]*))> */
 		nil,
-		/* 60 NUMBER_FRACTION <- <('.' [0-9]+)> */
+		/* 62 NUMBER_FRACTION <- <('.' [0-9]+)> */
 		nil,
-		/* 61 NUMBER_INTEGER <- <('-'? NUMBER_NATURAL)> */
+		/* 63 NUMBER_INTEGER <- <('-'? NUMBER_NATURAL)> */
 		nil,
-		/* 62 NUMBER_EXP <- <(('e' / 'E') ('+' / '-')? ([0-9]+ / &{ p.errorHere(position, `expected exponent`) }))> */
+		/* 64 NUMBER_EXP <- <(('e' / 'E') ('+' / '-')? ([0-9]+ / &{ p.errorExpecting(position, expect(`exponent`), ``) }))> */
 		nil,
-		/* 63 DURATION <- <(NUMBER [a-z]+ KEY)> */
+		/* 65 DURATION <- <(NUMBER [a-z]+ KEY)> */
 		nil,
-		/* 64 PAREN_OPEN <- <'('> */
+		/* 66 VARIABLE <- <('$' <ID_SEGMENT>)> */
+		func() bool {
+			position1060, tokenIndex1060, depth1060 := position, tokenIndex, depth
+			{
+				position1061 := position
+				depth++
+				if buffer[position] != rune('$') {
+					goto l1060
+				}
+				position++
+				{
+					position1062 := position
+					depth++
+					if !_rules[ruleID_SEGMENT]() {
+						goto l1060
+					}
+					depth--
+					add(rulePegText, position1062)
+				}
+				depth--
+				add(ruleVARIABLE, position1061)
+			}
+			return true
+		l1060:
+			position, tokenIndex, depth = position1060, tokenIndex1060, depth1060
+			return false
+		},
+		/* 67 PAREN_OPEN <- <'('> */
 		func() bool {
 			position801, tokenIndex801, depth801 := position, tokenIndex, depth
 			{
@@ -6341,7 +8022,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position801, tokenIndex801, depth801
 			return false
 		},
-		/* 65 PAREN_CLOSE <- <')'> */
+		/* 68 PAREN_CLOSE <- <')'> */
 		func() bool {
 			position803, tokenIndex803, depth803 := position, tokenIndex, depth
 			{
@@ -6359,7 +8040,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position803, tokenIndex803, depth803
 			return false
 		},
-		/* 66 COMMA <- <','> */
+		/* 69 COMMA <- <','> */
 		func() bool {
 			position805, tokenIndex805, depth805 := position, tokenIndex, depth
 			{
@@ -6377,7 +8058,7 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position805, tokenIndex805, depth805
 			return false
 		},
-		/* 67 _ <- <((&('/') COMMENT_BLOCK) | (&('-') COMMENT_TRAIL) | (&('\t' | '\n' | ' ') SPACE))*> */
+		/* 70 _ <- <((&('/') COMMENT_BLOCK) | (&('-') COMMENT_TRAIL) | (&('\t' | '\n' | ' ') SPACE))*> */
 		func() bool {
 			{
 				position808 := position
@@ -6514,11 +8195,11 @@ func (p *Parser) Init() {
 			}
 			return true
 		},
-		/* 68 COMMENT_TRAIL <- <('-' '-' (!'\n' .)*)> */
+		/* 71 COMMENT_TRAIL <- <('-' '-' (!'\n' .)*)> */
 		nil,
-		/* 69 COMMENT_BLOCK <- <('/' '*' (!('*' '/') .)* ('*' '/'))> */
+		/* 72 COMMENT_BLOCK <- <('/' '*' (!('*' '/') .)* ('*' '/'))> */
 		nil,
-		/* 70 KEY <- <!ID_CONT> */
+		/* 73 KEY <- <!ID_CONT> */
 		func() bool {
 			position824, tokenIndex824, depth824 := position, tokenIndex, depth
 			{
@@ -6541,120 +8222,132 @@ func (p *Parser) Init() {
 			position, tokenIndex, depth = position824, tokenIndex824, depth824
 			return false
 		},
-		/* 71 SPACE <- <((&('\t') '\t') | (&('\n') '\n') | (&(' ') ' '))> */
+		/* 74 SPACE <- <((&('\t') '\t') | (&('\n') '\n') | (&(' ') ' '))> */
+		nil,
+		/* 76 Action0 <- <{ p.makeExplain() }> */
+		nil,
+		/* 77 Action1 <- <{ p.makeSelect() }> */
 		nil,
-		/* 73 Action0 <- <{ p.makeSelect() }> */
+		/* 78 Action2 <- <{ p.makeDescribeAll() }> */
 		nil,
-		/* 74 Action1 <- <{ p.makeDescribeAll() }> */
+		/* 79 Action3 <- <{ p.addNullMatchClause() }> */
 		nil,
-		/* 75 Action2 <- <{ p.addNullMatchClause() }> */
+		/* 80 Action4 <- <{ p.addMatchClause() }> */
 		nil,
-		/* 76 Action3 <- <{ p.addMatchClause() }> */
+		/* 81 Action5 <- <{ p.makeDescribeMetrics() }> */
 		nil,
-		/* 77 Action4 <- <{ p.makeDescribeMetrics() }> */
+		/* 82 Action6 <- <{ p.makeDescribeFunctions() }> */
 		nil,
 		nil,
-		/* 79 Action5 <- <{ p.pushString(unescapeLiteral(text)) }> */
+		/* 84 Action7 <- <{ p.pushString(unescapeLiteral(text)) }> */
 		nil,
-		/* 80 Action6 <- <{ p.makeDescribe() }> */
+		/* 85 Action8 <- <{ p.makeDescribe() }> */
 		nil,
-		/* 81 Action7 <- <{ p.addEvaluationContext() }> */
+		/* 86 Action9 <- <{ p.addEvaluationContext() }> */
 		nil,
-		/* 82 Action8 <- <{ p.addPropertyKey(text) }> */
+		/* 87 Action10 <- <{ p.addPropertyKey(text) }> */
 		nil,
-		/* 83 Action9 <- <{
+		/* 88 Action11 <- <{
 		   p.addPropertyValue(text) }> */
 		nil,
-		/* 84 Action10 <- <{ p.insertPropertyKeyValue() }> */
+		/* 89 Action12 <- <{ p.addPropertyVariable(text, begin, end) }> */
 		nil,
-		/* 85 Action11 <- <{ p.checkPropertyClause() }> */
+		/* 90 Action13 <- <{ p.insertPropertyKeyValue() }> */
 		nil,
-		/* 86 Action12 <- <{ p.addNullPredicate() }> */
+		/* 91 Action14 <- <{ p.checkPropertyClause() }> */
 		nil,
-		/* 87 Action13 <- <{ p.addExpressionList() }> */
+		/* 92 Action15 <- <{ p.addNullPredicate() }> */
 		nil,
-		/* 88 Action14 <- <{ p.appendExpression() }> */
+		/* 93 Action16 <- <{ p.addExpressionList() }> */
 		nil,
-		/* 89 Action15 <- <{ p.appendExpression() }> */
+		/* 94 Action17 <- <{ p.appendExpression() }> */
 		nil,
-		/* 90 Action16 <- <{ p.addOperatorLiteral("+") }> */
+		/* 95 Action18 <- <{ p.appendExpression() }> */
 		nil,
-		/* 91 Action17 <- <{ p.addOperatorLiteral("-") }> */
+		/* 96 Action19 <- <{ p.addOperatorLiteral("+") }> */
 		nil,
-		/* 92 Action18 <- <{ p.addOperatorFunction() }> */
+		/* 97 Action20 <- <{ p.addOperatorLiteral("-") }> */
 		nil,
-		/* 93 Action19 <- <{ p.addOperatorLiteral("/") }> */
+		/* 98 Action21 <- <{ p.addOperatorFunction() }> */
 		nil,
-		/* 94 Action20 <- <{ p.addOperatorLiteral("*") }> */
+		/* 99 Action22 <- <{ p.addOperatorLiteral("/") }> */
 		nil,
-		/* 95 Action21 <- <{ p.addOperatorFunction() }> */
+		/* 100 Action23 <- <{ p.addOperatorLiteral("*") }> */
 		nil,
-		/* 96 Action22 <- <{ p.pushString(unescapeLiteral(text)) }> */
+		/* 101 Action24 <- <{ p.addOperatorFunction() }> */
 		nil,
-		/* 97 Action23 <- <{p.addExpressionList()}> */
+		/* 102 Action25 <- <{ p.pushFunctionName(unescapeLiteral(text), begin, end) }> */
 		nil,
-		/* 98 Action24 <- <{
+		/* 103 Action26 <- <{p.addExpressionList()}> */
+		nil,
+		/* 104 Action27 <- <{
 		   p.addExpressionList()
 		   p.addGroupBy()
 		 }> */
 		nil,
-		/* 99 Action25 <- <{ p.addPipeExpression() }> */
+		/* 105 Action28 <- <{ p.addPipeExpression() }> */
+		nil,
+		/* 106 Action29 <- <{ p.addDurationNode(text) }> */
+		nil,
+		/* 107 Action30 <- <{ p.addNumberNode(text) }> */
+		nil,
+		/* 108 Action31 <- <{ p.addStringNode(unescapeLiteral(text)) }> */
 		nil,
-		/* 100 Action26 <- <{ p.addDurationNode(text) }> */
+		/* 109 Action32 <- <{ p.addVariableExpression(text, begin, end) }> */
 		nil,
-		/* 101 Action27 <- <{ p.addNumberNode(text) }> */
+		/* 110 Action33 <- <{ p.addAnnotationExpression(text) }> */
 		nil,
-		/* 102 Action28 <- <{ p.addStringNode(unescapeLiteral(text)) }> */
+		/* 111 Action34 <- <{ p.addGroupBy() }> */
 		nil,
-		/* 103 Action29 <- <{ p.addAnnotationExpression(text) }> */
+		/* 112 Action35 <- <{ p.pushFunctionName(unescapeLiteral(text), begin, end) }> */
 		nil,
-		/* 104 Action30 <- <{ p.addGroupBy() }> */
+		/* 113 Action36 <- <{ p.addFunctionInvocation() }> */
 		nil,
-		/* 105 Action31 <- <{ p.pushString(unescapeLiteral(text)) }> */
+		/* 114 Action37 <- <{ p.pushString(unescapeLiteral(text)) }> */
 		nil,
-		/* 106 Action32 <- <{ p.addFunctionInvocation() }> */
+		/* 115 Action38 <- <{ p.addNullPredicate() }> */
 		nil,
-		/* 107 Action33 <- <{ p.pushString(unescapeLiteral(text)) }> */
+		/* 116 Action39 <- <{ p.addMetricExpression() }> */
 		nil,
-		/* 108 Action34 <- <{ p.addNullPredicate() }> */
+		/* 117 Action40 <- <{ p.addGroupBy() }> */
 		nil,
-		/* 109 Action35 <- <{ p.addMetricExpression() }> */
+		/* 118 Action41 <- <{ p.appendGroupTag(unescapeLiteral(text)) }> */
 		nil,
-		/* 110 Action36 <- <{ p.addGroupBy() }> */
+		/* 119 Action42 <- <{ p.appendGroupTag(unescapeLiteral(text)) }> */
 		nil,
-		/* 111 Action37 <- <{ p.appendGroupTag(unescapeLiteral(text)) }> */
+		/* 120 Action43 <- <{ p.addCollapseBy() }> */
 		nil,
-		/* 112 Action38 <- <{ p.appendGroupTag(unescapeLiteral(text)) }> */
+		/* 121 Action44 <- <{ p.appendGroupTag(unescapeLiteral(text)) }> */
 		nil,
-		/* 113 Action39 <- <{ p.addCollapseBy() }> */
+		/* 122 Action45 <- <{ p.appendGroupTag(unescapeLiteral(text)) }> */
 		nil,
-		/* 114 Action40 <- <{ p.appendGroupTag(unescapeLiteral(text)) }> */
+		/* 123 Action46 <- <{ p.addOrPredicate() }> */
 		nil,
-		/* 115 Action41 <- <{ p.appendGroupTag(unescapeLiteral(text)) }> */
+		/* 124 Action47 <- <{ p.addAndPredicate() }> */
 		nil,
-		/* 116 Action42 <- <{ p.addOrPredicate() }> */
+		/* 125 Action48 <- <{ p.addNotPredicate() }> */
 		nil,
-		/* 117 Action43 <- <{ p.addAndPredicate() }> */
+		/* 126 Action49 <- <{ p.addLiteralMatcher() }> */
 		nil,
-		/* 118 Action44 <- <{ p.addNotPredicate() }> */
+		/* 127 Action50 <- <{ p.addLiteralMatcher() }> */
 		nil,
-		/* 119 Action45 <- <{ p.addLiteralMatcher() }> */
+		/* 128 Action51 <- <{ p.addNotPredicate() }> */
 		nil,
-		/* 120 Action46 <- <{ p.addLiteralMatcher() }> */
+		/* 129 Action52 <- <{ p.addRegexMatcher() }> */
 		nil,
-		/* 121 Action47 <- <{ p.addNotPredicate() }> */
+		/* 130 Action53 <- <{ p.addListMatcher() }> */
 		nil,
-		/* 122 Action48 <- <{ p.addRegexMatcher() }> */
+		/* 131 Action54 <- <{ p.pushString(unescapeLiteral(text)) }> */
 		nil,
-		/* 123 Action49 <- <{ p.addListMatcher() }> */
+		/* 132 Action55 <- <{ p.pushStringVariable(text, begin, end) }> */
 		nil,
-		/* 124 Action50 <- <{ p.pushString(unescapeLiteral(text)) }> */
+		/* 133 Action56 <- <{ p.addListVariable(text, begin, end) }> */
 		nil,
-		/* 125 Action51 <- <{ p.addLiteralList() }> */
+		/* 134 Action57 <- <{ p.addLiteralList() }> */
 		nil,
-		/* 126 Action52 <- <{ p.appendLiteral(unescapeLiteral(text)) }> */
+		/* 135 Action58 <- <{ p.appendLiteral(unescapeLiteral(text)) }> */
 		nil,
-		/* 127 Action53 <- <{ p.addTagLiteral(unescapeLiteral(text)) }> */
+		/* 136 Action59 <- <{ p.addTagLiteral(unescapeLiteral(text)) }> */
 		nil,
 	}
 	p.rules = _rules
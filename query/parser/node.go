@@ -14,7 +14,11 @@
 
 package parser
 
-import "github.com/square/metrics/timeseries"
+import (
+	"time"
+
+	"github.com/square/metrics/timeseries"
+)
 
 type any interface{} // fixes a bug in gopeg
 
@@ -33,6 +37,14 @@ type tagLiteral string
 // a single operator
 type operatorLiteral string
 
+// invokedFunction is the name of an invoked function, along with the range of
+// runes in the query where it is written.
+type invokedFunction struct {
+	name  string
+	begin int
+	end   int
+}
+
 // evaluationContextKey represents a key (from, to, sampleby) for the evaluation context.
 type evaluationContextKey string
 
@@ -41,9 +53,11 @@ type evaluationContextValue string
 
 // evaluationContextMap represents a collection of key-value pairs that form the evaluation context.
 type evaluationContextNode struct {
-	Start        int64                         // Start of data timerange
-	End          int64                         // End of data timerange
-	Resolution   int64                         // Resolution of data timerange
-	SampleMethod timeseries.SampleMethod       // to use when up/downsampling to match requested resolution
-	assigned     map[evaluationContextKey]bool // a map for knowing which elements of the context have been assigned
+	Start        int64                                           // Start of data timerange
+	End          int64                                           // End of data timerange
+	Resolution   int64                                           // Resolution of data timerange
+	SampleMethod timeseries.SampleMethod                         // to use when up/downsampling to match requested resolution
+	Timezone     *time.Location                                  // Timezone used to interpret dates and align slots
+	assigned     map[evaluationContextKey]bool                   // a map for knowing which elements of the context have been assigned
+	dates        map[evaluationContextKey]evaluationContextValue // unparsed dates, which are resolved once the timezone is known
 }
@@ -25,6 +25,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/square/metrics/api"
 	"github.com/square/metrics/function"
@@ -103,7 +105,70 @@ var dateFormats = []string{
 	time.RFC822Z,
 }
 
+// calendarRegexp matches calendar expressions such as "today" or "startofmonth - 1mo".
+var calendarRegexp = regexp.MustCompile(`^([a-zA-Z]+)(?:\s*([+-])\s*([0-9]+[a-zA-Z]+))?$`)
+
+// calendarOffsetRegexp matches offsets which are measured in calendar days, months or years.
+var calendarOffsetRegexp = regexp.MustCompile(`^([+-]?[0-9]+)(d|w|M|mo|y|yr)$`)
+
+// calendarAnchor returns the time named by the given anchor, relative to now (in now's location).
+func calendarAnchor(anchor string, now time.Time) (time.Time, bool) {
+	year, month, day := now.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(anchor) {
+	case "now":
+		return now, true
+	case "today", "startofday":
+		return midnight, true
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), true
+	case "tomorrow":
+		return midnight.AddDate(0, 0, 1), true
+	case "startofweek":
+		// Weeks begin on Monday.
+		return midnight.AddDate(0, 0, -(int(now.Weekday())+6)%7), true
+	case "startofmonth":
+		return time.Date(year, month, 1, 0, 0, 0, 0, now.Location()), true
+	case "startofyear":
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, now.Location()), true
+	}
+	return time.Time{}, false
+}
+
+// addCalendarOffset adds the given relative offset to the time.
+// Days, weeks, months and years are added according to the calendar of t's location,
+// so that (for example) "-1mo" from March 31st is the last day of February.
+func addCalendarOffset(t time.Time, offset string) (time.Time, error) {
+	if matches := calendarOffsetRegexp.FindStringSubmatch(offset); matches != nil {
+		n, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+		switch matches[2] {
+		case "d":
+			return t.AddDate(0, 0, n), nil
+		case "w":
+			return t.AddDate(0, 0, 7*n), nil
+		case "M", "mo":
+			result := t.AddDate(0, n, 0)
+			if result.Day() != t.Day() {
+				// The month was too short; clamp to its last day instead of overflowing.
+				result = result.AddDate(0, 0, -result.Day())
+			}
+			return result, nil
+		default:
+			return t.AddDate(n, 0, 0), nil
+		}
+	}
+	duration, err := function.StringToDuration(offset)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(duration), nil
+}
+
 // parseDate converts the given datestring (from one of the allowable formats) into a millisecond offset from the Unix epoch.
+// Calendar expressions and dates without an explicit timezone are interpreted in now's location.
 func parseDate(date string, now time.Time) (int64, error) {
 	if date == "now" {
 		return now.Unix() * 1000, nil
@@ -114,15 +179,27 @@ func parseDate(date string, now time.Time) (int64, error) {
 		return epoch, nil
 	}
 
-	relativeTime, err := function.StringToDuration(date)
-	if err == nil {
+	if relativeTime, err := addCalendarOffset(now, date); err == nil {
 		// A relative date.
-		return now.Add(relativeTime).Unix() * 1000, nil
+		return relativeTime.Unix() * 1000, nil
+	}
+
+	if matches := calendarRegexp.FindStringSubmatch(date); matches != nil {
+		if anchor, ok := calendarAnchor(matches[1], now); ok {
+			if matches[2] == "" {
+				return anchor.Unix() * 1000, nil
+			}
+			result, err := addCalendarOffset(anchor, matches[2]+matches[3])
+			if err != nil {
+				return -1, fmt.Errorf("Expected relative time to follow '%s' but got '%s'", matches[1], matches[3])
+			}
+			return result.Unix() * 1000, nil
+		}
 	}
 
-	errorMessage := fmt.Sprintf("Expected formatted date or relative time but got '%s'", date)
+	errorMessage := fmt.Sprintf("Expected formatted date, calendar expression or relative time but got '%s'", date)
 	for _, format := range dateFormats {
-		t, err := time.Parse(format, date)
+		t, err := time.ParseInLocation(format, date, now.Location())
 		if err == nil {
 			return t.Unix()*1000 + int64(t.Nanosecond()/1000000), nil
 		}
@@ -138,8 +215,52 @@ type Assert struct {
 // A ParserError wraps an error raised during parser execution.
 type ParserError error
 
-func Parse(query string) (commandResult command.Command, finalErr error) {
-	p := Parser{Buffer: query}
+// Options configure the parsing of a query.
+type Options struct {
+	// Registry, unless nil, is used to report an error for each function
+	// invoked by the query which is not registered.
+	Registry function.Registry
+	// Variables are the values of the $variables in the query.
+	Variables map[string]Variable
+	// AllowUnboundVariables permits $variables without values, so that the
+	// syntax of a query template can be checked without its values.
+	AllowUnboundVariables bool
+}
+
+// Parse parses the query into a command. If the query is invalid, the error
+// returned is SyntaxErrors.
+func Parse(query string) (command.Command, error) {
+	return ParseWithOptions(query, Options{})
+}
+
+// ParseWithOptions parses the query like Parse, using the given options.
+func ParseWithOptions(query string, options Options) (command.Command, error) {
+	p, err := parseRule(query, ruleroot, "query", options, nil)
+	if err != nil {
+		return nil, err
+	}
+	if p.command == nil {
+		// after parsing has finished, there should be a command available.
+		return nil, AssertionError{"No command"}
+	}
+	return p.command, nil
+}
+
+// ParsePredicate parses a predicate written as in the where clause of a query.
+// If the predicate is invalid, the error returned is SyntaxErrors.
+func ParsePredicate(text string) (predicate.Predicate, error) {
+	var result predicate.Predicate
+	if _, err := parseRule(text, rulepredicate_1, "predicate", Options{}, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parseRule parses the text as the given rule of the grammar (described by
+// `what` in errors), which must match all of the text. Unless the target is
+// nil, the node which the rule produces is popped into it.
+func parseRule(text string, rule pegRule, what string, options Options, target interface{}) (parser *Parser, finalErr error) {
+	p := &Parser{Buffer: text, variables: options.Variables, allowUnbound: options.AllowUnboundVariables}
 	p.Init()
 	defer func() {
 		r := recover()
@@ -150,37 +271,69 @@ func Parse(query string) (commandResult command.Command, finalErr error) {
 			finalErr = message
 			return
 		}
+		if syntaxError, ok := r.(SyntaxError); ok {
+			finalErr = SyntaxErrors{syntaxError}
+			return
+		}
 		if parserError, ok := r.(ParserError); ok {
 			finalErr = error(parserError)
 			return
 		}
 		panic(r) // Can't catch it
 	}()
-	if err := p.Parse(); err != nil {
+	if err := p.Parse(int(rule)); err != nil {
 		// Parsing error - invalid syntax.
 		// TODO - return the token where the error is occurring.
 		if _, ok := err.(*parseError); ok {
-			return nil, SyntaxErrors([]SyntaxError{{
+			syntaxError := SyntaxError{
 				token:   "",
-				message: customParseError(&p),
-			}})
+				message: customParseError(p),
+			}
+			for _, token := range p.tokens32.Error() {
+				if token.pegRule != ruleUnknown {
+					syntaxError.span = p.spanOf(int(token.begin), int(token.end))
+					break
+				}
+			}
+			return nil, SyntaxErrors([]SyntaxError{syntaxError})
 		}
 		// generic error (should not occur).
 		return nil, AssertionError{"Non-parse error raised"}
 	}
+	if rule != ruleroot {
+		// The root rule checks for the end of input itself; other rules match a prefix of the text, which is the
+		// outermost (and so the last) token.
+		end := p.tokens32.tree[len(p.tokens32.tree)-1].end
+		if strings.TrimSpace(p.after(end)) != "" {
+			p.errorExpecting(end, expect("end of input"), "after %s but got %q", what, p.after(end))
+		}
+	}
 	p.Execute() // Execute runs code associated with the AST.
+	if target != nil {
+		p.popNodeInto(target)
+	}
 	if len(p.nodeStack) > 0 {
 		return nil, AssertionError{"Node stack is not empty"}
 	}
+	if options.Registry != nil {
+		p.checkFunctions(options.Registry)
+	}
 	if len(p.errors) > 0 {
 		// user error - an invalid query is provided.
 		return nil, SyntaxErrors(p.errors)
 	}
-	if p.command == nil {
-		// after parsing has finished, there should be a command available.
-		return nil, AssertionError{"No command"}
+	return p, nil
+}
+
+// ParseExpression parses a single expression written as in the select clause
+// of a query, using the given options. If the expression is invalid, the error
+// returned is SyntaxErrors.
+func ParseExpression(text string, options Options) (function.Expression, error) {
+	var result function.Expression
+	if _, err := parseRule(text, ruleexpression_start, "expression", options, &result); err != nil {
+		return nil, err
 	}
-	return p.command, nil
+	return result, nil
 }
 
 // Error functions
@@ -192,6 +345,26 @@ func (p *Parser) flagSyntaxError(err SyntaxError) {
 	p.errors = append(p.errors, err)
 }
 
+// checkFunctions flags each invoked function which is not in the registry,
+// suggesting a registered function with a similar name where there is one.
+func (p *Parser) checkFunctions(registry function.Registry) {
+	for _, invoked := range p.functions {
+		if _, ok := registry.GetFunction(invoked.name); ok {
+			continue
+		}
+		err := SyntaxError{
+			token:      invoked.name,
+			message:    fmt.Sprintf("%s: no such function %s", p.currentPosition(uint32(invoked.begin)), invoked.name),
+			span:       p.spanOf(invoked.begin, invoked.end),
+			suggestion: suggest(invoked.name, registry.All()),
+		}
+		if err.suggestion != "" {
+			err.message += fmt.Sprintf("; did you mean %s?", err.suggestion)
+		}
+		p.flagSyntaxError(err)
+	}
+}
+
 // Generic Stack Operation
 // =======================
 func (p *Parser) popNodeInto(target interface{}) {
@@ -230,6 +403,13 @@ func (p *Parser) pushString(node string) {
 	p.pushNode(node)
 }
 
+// pushFunctionName pushes the name of an invoked function, remembering where
+// it appears so that it can be checked against the registry.
+func (p *Parser) pushFunctionName(name string, begin int, end int) {
+	p.functions = append(p.functions, invokedFunction{name: name, begin: begin, end: end})
+	p.pushNode(name)
+}
+
 // pushExpression is just a type-safe way to push an expression
 func (p *Parser) pushExpression(node function.Expression) {
 	p.pushNode(node)
@@ -269,10 +449,19 @@ func (p *Parser) makeSelect() {
 			End:          contextNode.End,
 			Resolution:   contextNode.Resolution,
 			SampleMethod: contextNode.SampleMethod,
+			Timezone:     contextNode.Timezone,
 		},
 	}
 }
 
+func (p *Parser) makeExplain() {
+	selectCommand, ok := p.command.(*command.SelectCommand)
+	if !ok {
+		panic(Assert{fmt.Errorf("expected select command to explain but got %+v", p.command)})
+	}
+	p.command = &command.ExplainCommand{Select: selectCommand}
+}
+
 func (p *Parser) addNullMatchClause() {
 	p.pushNode(regexp.MustCompile(""))
 }
@@ -288,6 +477,12 @@ func (p *Parser) makeDescribeAll() {
 	p.command = &command.DescribeAllCommand{Matcher: matcher}
 }
 
+func (p *Parser) makeDescribeFunctions() {
+	var matcher *regexp.Regexp
+	p.popNodeInto(&matcher)
+	p.command = &command.DescribeFunctionsCommand{Matcher: matcher}
+}
+
 func (p *Parser) makeDescribeMetrics() {
 	// Pop off the value.
 	var literal string
@@ -331,7 +526,9 @@ func (p *Parser) addEvaluationContext() {
 	p.pushNode(&evaluationContextNode{
 		0, 0, 30000,
 		timeseries.SampleMean,
+		nil,
 		make(map[evaluationContextKey]bool),
+		make(map[evaluationContextKey]evaluationContextValue),
 	})
 }
 
@@ -344,7 +541,7 @@ func (p *Parser) insertPropertyKeyValue() {
 	p.popNodeInto(&contextNode)
 
 	// Authenticate the validity of the given key and value...
-	// The key must be one of "sample"(by), "from", "to", "resolution"
+	// The key must be one of "sample"(by), "from", "to", "resolution", "timezone"
 
 	// First check that the key has been assigned only once:
 	if contextNode.assigned[key] {
@@ -373,20 +570,17 @@ func (p *Parser) insertPropertyKeyValue() {
 			})
 		}
 	case "from", "to":
-		var unix int64
-		var err error
-		now := time.Now()
-		if unix, err = parseDate(string(value), now); err != nil {
+		// Dates are parsed by checkPropertyClause, since they depend on the timezone.
+		contextNode.dates[key] = value
+	case "timezone":
+		location, err := time.LoadLocation(string(value))
+		if err != nil {
 			p.flagSyntaxError(SyntaxError{
 				token:   string(value),
-				message: err.Error(),
+				message: fmt.Sprintf("Expected timezone name (such as 'America/New_York') but got '%s'", value),
 			})
 		}
-		if key == "from" {
-			contextNode.Start = unix
-		} else {
-			contextNode.End = unix
-		}
+		contextNode.Timezone = location
 	case "resolution":
 		// The value must be determined to be an int if the key is "resolution".
 		if intValue, err := strconv.ParseInt(string(value), 10, 64); err == nil {
@@ -421,6 +615,28 @@ func (p *Parser) checkPropertyClause() {
 			})
 		}
 	}
+	now := time.Now().UTC()
+	if contextNode.Timezone != nil {
+		now = now.In(contextNode.Timezone)
+	}
+	for _, key := range mandatoryFields {
+		value, ok := contextNode.dates[key]
+		if !ok {
+			continue
+		}
+		unix, err := parseDate(string(value), now)
+		if err != nil {
+			p.flagSyntaxError(SyntaxError{
+				token:   string(value),
+				message: err.Error(),
+			})
+		}
+		if key == "from" {
+			contextNode.Start = unix
+		} else {
+			contextNode.End = unix
+		}
+	}
 	p.pushNode(contextNode)
 }
 
@@ -493,6 +709,10 @@ func (p *Parser) appendExpression() {
 }
 
 func (p *Parser) addLiteralMatcher() {
+	if parameter, ok := p.popParameter(); ok {
+		p.addParameterMatcher(parameter, false)
+		return
+	}
 	var literal string
 	p.popNodeInto(&literal)
 	var tag tagLiteral
@@ -517,6 +737,10 @@ func (p *Parser) addListMatcher() {
 }
 
 func (p *Parser) addRegexMatcher() {
+	if parameter, ok := p.popParameter(); ok {
+		p.addParameterMatcher(parameter, true)
+		return
+	}
 	compiled := p.popRegex()
 	var tag tagLiteral
 	p.popNodeInto(&tag)
@@ -527,6 +751,17 @@ func (p *Parser) addRegexMatcher() {
 	})
 }
 
+func (p *Parser) addParameterMatcher(parameter string, regex bool) {
+	var tag tagLiteral
+	p.popNodeInto(&tag)
+
+	p.pushPredicate(predicate.ParameterMatcher{
+		Tag:       string(tag),
+		Parameter: parameter,
+		Regex:     regex,
+	})
+}
+
 func (p *Parser) addTagLiteral(tag string) {
 	p.pushNode(tagLiteral(tag))
 }
@@ -751,9 +986,63 @@ func (p *Parser) after(position uint32) string {
 	return string(p.buffer[position : len(p.buffer)-1])
 }
 
-// errorHere raises a typed panic with the provided error message, incorporating
+// errorHere raises a SyntaxError with the provided error message, incorporating
 // the current line and column and the context of the error.
 func (p *Parser) errorHere(position uint32, format string, arguments ...interface{}) bool {
+	panic(p.syntaxErrorHere(position, unescapeBraces(fmt.Sprintf(format, arguments...))))
+}
+
+// expect lists the alternatives given to errorExpecting. (Braces cannot be
+// written in the grammar's code, so a slice literal cannot be used there.)
+func expect(alternatives ...string) []string {
+	return alternatives
+}
+
+// errorExpecting raises a SyntaxError saying that one of the alternatives was
+// expected, followed by the context, such as
+// `expected "=", "!=", "match", or "in" to follow tag key in predicate`.
+func (p *Parser) errorExpecting(position uint32, alternatives []string, format string, arguments ...interface{}) bool {
+	expected := make([]string, len(alternatives))
+	for i := range alternatives {
+		expected[i] = unescapeBraces(alternatives[i])
+	}
+	listed := strings.Join(expected, " or ")
+	if len(expected) > 2 {
+		listed = strings.Join(expected[:len(expected)-1], ", ") + ", or " + expected[len(expected)-1]
+	}
+	description := "expected " + listed
+	if format != "" {
+		description += " " + unescapeBraces(fmt.Sprintf(format, arguments...))
+	}
+	err := p.syntaxErrorHere(position, description)
+	err.expected = expected
+	panic(err)
+}
+
+// unescapeBraces replaces the placeholders for braces, which cannot be written
+// in the grammar's code.
+func unescapeBraces(text string) string {
+	text = strings.Replace(text, "$OPENBRACE$", "{", -1)
+	return strings.Replace(text, "$CLOSEBRACE$", "}", -1)
+}
+
+// errorAfterExpression raises a SyntaxError for unexpected input following a
+// completed expression, suggesting a property key if the input resembles one.
+func (p *Parser) errorAfterExpression(position uint32) bool {
+	description := fmt.Sprintf("expected key (one of 'from', 'to', 'resolution', 'timezone', or 'sample by') or end of input but got %q following a completed expression", p.after(position))
+	begin, end := p.nextToken(int(position))
+	suggestion := suggest(string(p.buffer[begin:end]), []string{"from", "to", "resolution", "timezone", "sample"})
+	if suggestion != "" {
+		description += fmt.Sprintf("; did you mean %q?", suggestion)
+	}
+	err := p.syntaxErrorHere(position, description)
+	err.expected = []string{"'from'", "'to'", "'resolution'", "'timezone'", "'sample by'", "end of input"}
+	err.suggestion = suggestion
+	panic(err)
+}
+
+// syntaxErrorHere creates a SyntaxError for the token following the position.
+func (p *Parser) syntaxErrorHere(position uint32, description string) SyntaxError {
 	additionalContext := ""
 	if len(p.errorContext) > 0 {
 		additionalContext += "; " + strings.Join(p.errorContext, "; ")
@@ -761,10 +1050,30 @@ func (p *Parser) errorHere(position uint32, format string, arguments ...interfac
 	if len(p.fixedContext) > 0 {
 		additionalContext += " " + p.fixedContext
 	}
-	message := fmt.Sprintf("%s: %s%s", p.currentPosition(position), fmt.Sprintf(format, arguments...), additionalContext)
-	message = strings.Replace(message, "$OPENBRACE$", "{", -1)
-	message = strings.Replace(message, "$CLOSEBRACE$", "}", -1)
-	panic(ParserError(fmt.Errorf("%s", message)))
+	begin, end := p.nextToken(int(position))
+	return SyntaxError{
+		token:   string(p.buffer[begin:end]),
+		message: fmt.Sprintf("%s: %s%s", p.currentPosition(position), description, additionalContext),
+		span:    p.spanOf(begin, end),
+	}
+}
+
+// nextToken finds the range of runes of the token following the position,
+// skipping any whitespace. The token is empty at the end of the input.
+func (p *Parser) nextToken(position int) (int, int) {
+	length := len(p.buffer) - 1 // the buffer ends with a sentinel
+	begin := position
+	for begin < length && unicode.IsSpace(p.buffer[begin]) {
+		begin++
+	}
+	end := begin
+	for end < length && (p.buffer[end] == '_' || p.buffer[end] == '.' || unicode.IsLetter(p.buffer[end]) || unicode.IsDigit(p.buffer[end])) {
+		end++
+	}
+	if end == begin && end < length {
+		end++
+	}
+	return begin, end
 }
 
 // contents will give the token contents to the caller
@@ -773,12 +1082,20 @@ func (p *Parser) contents(tree tokens32, tokenIndex int) string {
 }
 
 func (p *Parser) currentPosition(position uint32) string {
+	current := p.positionOf(int(position))
+	return fmt.Sprintf("line %d, column %d", current.Line, current.Column)
+}
+
+// positionOf locates the rune with the given index in the query.
+func (p *Parser) positionOf(index int) Position {
+	offset := 0
 	line := 0
 	column := 0
 	for i, c := range p.buffer {
-		if uint32(i) >= position {
+		if i >= index {
 			break
 		}
+		offset += utf8.RuneLen(c)
 		switch c {
 		case '\n':
 			column = 0
@@ -791,7 +1108,12 @@ func (p *Parser) currentPosition(position uint32) string {
 			column++
 		}
 	}
-	return fmt.Sprintf("line %d, column %d", line+1, column+1)
+	return Position{Offset: offset, Line: line + 1, Column: column + 1}
+}
+
+// spanOf locates the runes from begin (inclusive) to end (exclusive) in the query.
+func (p *Parser) spanOf(begin int, end int) *Span {
+	return &Span{Start: p.positionOf(begin), End: p.positionOf(end)}
 }
 
 func min(x, y int) int {
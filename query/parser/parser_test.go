@@ -59,6 +59,54 @@ func Test_parseRelativeTime(t *testing.T) {
 	}
 }
 
+func Test_parseCalendarTime(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Unable to load timezone: %s", err.Error())
+	}
+	// Thursday, March 31st 2016 (after the start of daylight saving time on March 13th).
+	now := time.Date(2016, 3, 31, 15, 4, 5, 0, location)
+	local := func(year int, month time.Month, day, hour int) int64 {
+		return time.Date(year, month, day, hour, 0, 0, 0, location).Unix() * 1000
+	}
+
+	for _, c := range []struct {
+		timeString        string
+		expectedTimestamp int64
+	}{
+		{"now", now.Unix() * 1000},
+		{"today", local(2016, 3, 31, 0)},
+		{"TODAY", local(2016, 3, 31, 0)},
+		{"startofday", local(2016, 3, 31, 0)},
+		{"yesterday", local(2016, 3, 30, 0)},
+		{"tomorrow", local(2016, 4, 1, 0)},
+		{"startofweek", local(2016, 3, 28, 0)},
+		{"startofmonth", local(2016, 3, 1, 0)},
+		{"startofyear", local(2016, 1, 1, 0)},
+		{"startofmonth - 1mo", local(2016, 2, 1, 0)},
+		{"startofmonth-1M", local(2016, 2, 1, 0)},
+		{"startofyear - 1y", local(2015, 1, 1, 0)},
+		{"startofweek - 2w", local(2016, 3, 14, 0)},
+		{"today + 9h", local(2016, 3, 31, 9)},
+		{"today - 1d", local(2016, 3, 30, 0)},
+		{"-1mo", time.Date(2016, 2, 29, 15, 4, 5, 0, location).Unix() * 1000},
+		{"-1y", time.Date(2015, 3, 31, 15, 4, 5, 0, location).Unix() * 1000},
+		{"2016-3-1", local(2016, 3, 1, 0)},
+		{"2016-3-1 12 UTC", time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC).Unix() * 1000},
+	} {
+		a := assert.New(t).Contextf("%s", c.timeString)
+		ts, err := parseDate(c.timeString, now)
+		a.CheckError(err)
+		a.Eq(ts, c.expectedTimestamp)
+	}
+
+	for _, bad := range []string{"startofmonth - 1", "startofmonth - 1q", "soon", "startofdecade"} {
+		if _, err := parseDate(bad, now); err == nil {
+			t.Errorf("Expected parseDate(%q) to fail", bad)
+		}
+	}
+}
+
 func TestUnescapeLiteral(t *testing.T) {
 	a := assert.New(t)
 	a.EqString(unescapeLiteral("'foo'"), "foo")
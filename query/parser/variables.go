@@ -0,0 +1,215 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/query/expression"
+)
+
+// VariableKind is the type of the value of a variable.
+type VariableKind int
+
+const (
+	StringVariable     VariableKind = iota // a string, such as 'west'
+	NumberVariable                         // a number, such as 3.5
+	DurationVariable                       // a duration, such as 5m
+	ListVariable                           // a list of strings, such as ('a', 'b')
+	ExpressionVariable                     // an expression, such as the argument of a macro
+	ParameterVariable                      // a parameter of a macro, which is left in place to be substituted later
+)
+
+func (kind VariableKind) String() string {
+	switch kind {
+	case StringVariable:
+		return "string"
+	case NumberVariable:
+		return "number"
+	case DurationVariable:
+		return "duration"
+	case ListVariable:
+		return "list"
+	case ExpressionVariable:
+		return "expression"
+	case ParameterVariable:
+		return "parameter"
+	}
+	return fmt.Sprintf("VariableKind(%d)", int(kind))
+}
+
+// A Variable is a typed value which is substituted for a $variable in a query.
+// Values are substituted as literals, so they never need to be escaped.
+type Variable struct {
+	Kind       VariableKind
+	Value      string              // the string, or the duration as written (such as "5m")
+	Number     float64             // the number
+	List       []string            // the strings of the list
+	Expression function.Expression // the expression, which can only be substituted for an expression
+}
+
+// UnmarshalJSON decodes a JSON string, number or list of strings into a
+// variable of the corresponding kind. Durations are written as an object such
+// as {"duration": "5m"}.
+func (v *Variable) UnmarshalJSON(encoded []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return err
+	}
+	switch value := value.(type) {
+	case string:
+		*v = Variable{Kind: StringVariable, Value: value}
+		return nil
+	case float64:
+		*v = Variable{Kind: NumberVariable, Number: value}
+		return nil
+	case []interface{}:
+		list := make([]string, len(value))
+		for i, element := range value {
+			str, ok := element.(string)
+			if !ok {
+				return fmt.Errorf("expected list variable to contain only strings but got %s", encoded)
+			}
+			list[i] = str
+		}
+		*v = Variable{Kind: ListVariable, List: list}
+		return nil
+	case map[string]interface{}:
+		if duration, ok := value["duration"].(string); ok && len(value) == 1 {
+			*v = Variable{Kind: DurationVariable, Value: duration}
+			return nil
+		}
+	}
+	return fmt.Errorf(`expected variable to be a string, number, list of strings or {"duration": ...} but got %s`, encoded)
+}
+
+// variable looks up the value of the variable, flagging an error if it has not
+// been given a value (unless that is allowed), or if its value is not one of
+// the expected kinds.
+func (p *Parser) variable(name string, begin int, end int, kinds ...VariableKind) (Variable, bool) {
+	begin-- // include the "$"
+	value, ok := p.variables[name]
+	message := ""
+	if !ok && p.allowUnbound {
+		return Variable{}, false
+	}
+	if !ok {
+		message = fmt.Sprintf("no value given for variable $%s", name)
+	} else {
+		for _, kind := range kinds {
+			if value.Kind == kind {
+				return value, true
+			}
+		}
+		names := make([]string, len(kinds))
+		for i, kind := range kinds {
+			names[i] = kind.String()
+		}
+		expected := names[len(names)-1]
+		if len(names) > 1 {
+			expected = strings.Join(names[:len(names)-1], ", ") + " or " + expected
+		}
+		message = fmt.Sprintf("variable $%s must be a %s but is a %s", name, expected, value.Kind)
+	}
+	p.flagSyntaxError(SyntaxError{
+		token:   "$" + name,
+		message: fmt.Sprintf("%s: %s", p.currentPosition(uint32(begin)), message),
+		span:    p.spanOf(begin, end),
+	})
+	return Variable{}, false
+}
+
+// parameterLiteral is pushed in place of a string literal for a parameter of a
+// macro, so that the predicate using it can be substituted later.
+type parameterLiteral string
+
+func (p *Parser) pushStringVariable(name string, begin int, end int) {
+	if value, ok := p.variables[name]; ok && value.Kind == ParameterVariable {
+		p.pushNode(parameterLiteral(name))
+		return
+	}
+	value, _ := p.variable(name, begin, end, StringVariable)
+	p.pushString(value.Value)
+}
+
+// popParameter pops the parameter pushed in place of a string literal, if the
+// top of the stack is one.
+func (p *Parser) popParameter() (string, bool) {
+	if l := len(p.nodeStack); l > 0 {
+		if parameter, ok := p.nodeStack[l-1].(parameterLiteral); ok {
+			p.nodeStack = p.nodeStack[:l-1]
+			return string(parameter), true
+		}
+	}
+	return "", false
+}
+
+func (p *Parser) addListVariable(name string, begin int, end int) {
+	value, _ := p.variable(name, begin, end, ListVariable)
+	p.pushNode(value.List)
+}
+
+func (p *Parser) addVariableExpression(name string, begin int, end int) {
+	if value, ok := p.variables[name]; ok && value.Kind == ExpressionVariable {
+		// Expressions are only given by macros, so they are not suggested in errors.
+		p.pushExpression(value.Expression)
+		return
+	}
+	if value, ok := p.variables[name]; ok && value.Kind == ParameterVariable {
+		p.pushExpression(expression.Parameter{Name: name})
+		return
+	}
+	value, ok := p.variable(name, begin, end, StringVariable, NumberVariable, DurationVariable)
+	switch {
+	case !ok:
+		p.pushExpression(function.Memoize(expression.String{})) // an error has been flagged, so this is never evaluated
+	case value.Kind == DurationVariable:
+		p.addDurationNode(value.Value)
+	case value.Kind == NumberVariable:
+		p.pushExpression(function.Memoize(expression.Scalar{Value: value.Number}))
+	default:
+		p.addStringNode(value.Value)
+	}
+}
+
+// placeholderValues are valid values for each property key, used in place of
+// variables without a valid value so that no further errors are flagged.
+var placeholderValues = map[evaluationContextKey]string{
+	"from":       "0",
+	"to":         "0",
+	"resolution": "0",
+	"sample":     "mean",
+	"timezone":   "UTC",
+}
+
+func (p *Parser) addPropertyVariable(name string, begin int, end int) {
+	var key evaluationContextKey
+	p.popNodeInto(&key)
+	p.pushNode(key)
+	value, ok := p.variable(name, begin, end, StringVariable, NumberVariable, DurationVariable)
+	if !ok {
+		p.addPropertyValue(placeholderValues[key])
+		return
+	}
+	if value.Kind == NumberVariable {
+		p.addPropertyValue(strconv.FormatFloat(value.Number, 'f', -1, 64))
+		return
+	}
+	p.addPropertyValue(value.Value)
+}
@@ -0,0 +1,143 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/square/metrics/function"
+	"github.com/square/metrics/query/command"
+	"github.com/square/metrics/testing_support/assert"
+)
+
+// describeSelect describes the select command so that equivalent commands
+// have identical descriptions.
+func describeSelect(c command.Command) string {
+	selectCommand, ok := c.(*command.SelectCommand)
+	if !ok {
+		return fmt.Sprintf("%+v", c)
+	}
+	expressions := make([]string, len(selectCommand.Expressions))
+	for i, expression := range selectCommand.Expressions {
+		expressions[i] = expression.ExpressionString(function.StringQuery)
+	}
+	return fmt.Sprintf("select %s where %s context %+v", strings.Join(expressions, ", "), selectCommand.Predicate.Query(), selectCommand.Context)
+}
+
+func TestVariables(t *testing.T) {
+	variables := map[string]Variable{
+		"app":    {Kind: StringVariable, Value: "it's"},
+		"hosts":  {Kind: ListVariable, List: []string{"a", "b'c"}},
+		"window": {Kind: DurationVariable, Value: "5m"},
+		"limit":  {Kind: NumberVariable, Number: 2.5},
+		"start":  {Kind: NumberVariable, Number: 1000},
+		"end":    {Kind: StringVariable, Value: "2016-1-6"},
+		"sample": {Kind: StringVariable, Value: "max"},
+	}
+	for _, test := range []struct {
+		template string
+		query    string
+	}{
+		{
+			"select cpu[app = $app] from 0 to 0",
+			`select cpu[app = "it's"] from 0 to 0`,
+		},
+		{
+			"select cpu[host in $hosts and not app != $app] from 0 to 0",
+			`select cpu[host in ('a', "b'c") and not app != "it's"] from 0 to 0`,
+		},
+		{
+			"select cpu | transform.moving_average($window) * $limit + $app where app match $app from 0 to 0",
+			`select cpu | transform.moving_average(5m) * 2.5 + "it's" where app match "it's" from 0 to 0`,
+		},
+		{
+			"select cpu from $start to $end sample by $sample resolution $window",
+			"select cpu from 1000 to '2016-1-6' sample by 'max' resolution 5m",
+		},
+	} {
+		a := assert.New(t).Contextf("%s", test.template)
+		substituted, err := ParseWithOptions(test.template, Options{Variables: variables})
+		a.CheckError(err)
+		expected, err := Parse(test.query)
+		a.CheckError(err)
+		if substituted == nil || expected == nil {
+			continue
+		}
+		a.EqString(describeSelect(substituted), describeSelect(expected))
+	}
+}
+
+func TestVariables_Errors(t *testing.T) {
+	variables := map[string]Variable{
+		"app":    {Kind: StringVariable, Value: "mqe"},
+		"hosts":  {Kind: ListVariable, List: []string{"a"}},
+		"window": {Kind: DurationVariable, Value: "5q"},
+	}
+	for _, test := range []struct {
+		query    string
+		messages []string
+	}{
+		{"select cpu[app = $missing] from 0 to 0", []string{"line 1, column 18: no value given for variable $missing"}},
+		{"select cpu[app = $hosts] from 0 to 0", []string{"line 1, column 18: variable $hosts must be a string but is a list"}},
+		{"select cpu[app in $app] from 0 to 0", []string{"line 1, column 19: variable $app must be a list but is a string"}},
+		{"select cpu + $hosts from 0 to 0", []string{"line 1, column 14: variable $hosts must be a string, number or duration but is a list"}},
+		{"select cpu from $hosts to $missing", []string{
+			"line 1, column 17: variable $hosts must be a string, number or duration but is a list",
+			"line 1, column 27: no value given for variable $missing",
+		}},
+		{"select transform.moving_average(cpu, $window) from 0 to 0", []string{"'5q' is not a valid duration: expected duration to be of the form `^([+-]?[0-9]+)([smhdwMy]|ms|hr|mo|yr)$`"}},
+	} {
+		a := assert.New(t).Contextf("%s", test.query)
+		_, err := ParseWithOptions(test.query, Options{Variables: variables})
+		syntaxErrors, ok := err.(SyntaxErrors)
+		if !ok {
+			t.Errorf("expected SyntaxErrors for query %q but got %+v", test.query, err)
+			continue
+		}
+		messages := []string{}
+		for _, syntaxError := range syntaxErrors {
+			messages = append(messages, syntaxError.Error())
+		}
+		a.Eq(messages, test.messages)
+	}
+
+	// Unbound variables are permitted, but wrongly-typed variables are not.
+	_, err := ParseWithOptions("select cpu[host in $hosts] + $limit from $start to now", Options{AllowUnboundVariables: true})
+	assert.New(t).CheckError(err)
+	_, err = ParseWithOptions("select cpu[host in $app] from 0 to 0", Options{Variables: variables, AllowUnboundVariables: true})
+	if err == nil {
+		t.Errorf("expected wrongly-typed variable to be rejected")
+	}
+}
+
+func TestVariable_UnmarshalJSON(t *testing.T) {
+	a := assert.New(t)
+	variables := map[string]Variable{}
+	a.CheckError(json.Unmarshal([]byte(`{"app": "mqe", "limit": 3, "hosts": ["a", "b"], "window": {"duration": "5m"}}`), &variables))
+	a.Eq(variables, map[string]Variable{
+		"app":    {Kind: StringVariable, Value: "mqe"},
+		"limit":  {Kind: NumberVariable, Number: 3},
+		"hosts":  {Kind: ListVariable, List: []string{"a", "b"}},
+		"window": {Kind: DurationVariable, Value: "5m"},
+	})
+	for _, invalid := range []string{`{"x": [1]}`, `{"x": {"seconds": 5}}`, `{"x": null}`, `{"x": true}`} {
+		if err := json.Unmarshal([]byte(invalid), &variables); err == nil {
+			t.Errorf("expected %s to fail to decode", invalid)
+		}
+	}
+}
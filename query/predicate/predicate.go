@@ -32,6 +32,10 @@ type Predicate interface {
 	Query() string
 }
 
+// A Parser parses a predicate written as in the where clause of a query, such
+// as "dc = 'west' and not host match '^canary'".
+type Parser func(text string) (Predicate, error)
+
 // TruePredicate is always true
 type TruePredicate struct{}
 
@@ -183,3 +187,23 @@ func (p RegexMatcher) Apply(tagset api.TagSet) bool {
 func (p RegexMatcher) Query() string {
 	return fmt.Sprintf("%s match %q", util.EscapeIdentifier(p.Tag), p.Regex.String())
 }
+
+// ParameterMatcher compares a tag to a parameter of a macro. It is replaced by a
+// ListMatcher (or a RegexMatcher) when the macro is expanded, and matches
+// nothing until then.
+type ParameterMatcher struct {
+	Tag       string
+	Parameter string
+	Regex     bool
+}
+
+func (p ParameterMatcher) Apply(tagset api.TagSet) bool {
+	return false
+}
+func (p ParameterMatcher) Query() string {
+	operator := "="
+	if p.Regex {
+		operator = "match"
+	}
+	return fmt.Sprintf("%s %s $%s", util.EscapeIdentifier(p.Tag), operator, p.Parameter)
+}
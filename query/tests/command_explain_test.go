@@ -0,0 +1,91 @@
+// Copyright 2015 - 2016 Square Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Integration test for the query execution.
+package tests
+
+import (
+	"testing"
+
+	"github.com/square/metrics/api"
+	"github.com/square/metrics/query/command"
+	"github.com/square/metrics/query/parser"
+	"github.com/square/metrics/testing_support/assert"
+	"github.com/square/metrics/testing_support/mocks"
+
+	"golang.org/x/net/context"
+)
+
+func TestCommand_Explain(t *testing.T) {
+	a := assert.New(t)
+	testTimerange, err := api.NewSnappedTimerange(0, 120, 30)
+	a.CheckError(err)
+
+	comboAPI := mocks.NewComboAPI(
+		testTimerange,
+		api.Timeseries{Values: []float64{1, 2, 3, 4, 5}, TagSet: api.TagSet{"metric": "series_1", "dc": "west"}},
+		api.Timeseries{Values: []float64{1, 2, 3, 4, 5}, TagSet: api.TagSet{"metric": "series_2", "dc": "west"}},
+		api.Timeseries{Values: []float64{3, 0, 3, 6, 2}, TagSet: api.TagSet{"metric": "series_2", "dc": "east"}},
+		api.Timeseries{Values: []float64{5, 5, 5, 2, 2}, TagSet: api.TagSet{"metric": "series_2", "dc": "north"}},
+	)
+
+	testCommand, err := parser.Parse("explain select series_2 + series_2, aggregate.sum(series_2[dc != 'north']), series_1 from 0 to 120 resolution 30ms")
+	a.CheckError(err)
+	if err != nil {
+		return
+	}
+	a.EqString(testCommand.Name(), "explain")
+
+	rawResult, err := testCommand.Execute(command.ExecutionContext{
+		TimeseriesStorageAPI: comboAPI,
+		MetricMetadataAPI:    comboAPI,
+		FetchLimit:           5,
+		SlotLimit:            100,
+		Ctx:                  context.Background(),
+	})
+	// The query fetches more than 5 series in total, but explaining it must not fail.
+	a.CheckError(err)
+	if err != nil {
+		return
+	}
+	result := rawResult.Body.(command.ExplainResult)
+
+	a.EqInt(result.Slots, 5)
+	a.EqInt(result.SlotLimit, 100)
+	a.EqInt(result.FetchLimit, 5)
+	a.EqInt(result.Fetches, 6)
+	a.EqInt(len(result.Expressions), 3)
+
+	sum := result.Expressions[0]
+	a.EqString(sum.Kind, "function")
+	a.EqInt(len(sum.Arguments), 2)
+	a.EqString(sum.Arguments[0].Kind, "metric")
+	a.EqInt(sum.Arguments[0].Fetch.MatchedTagSets, 3)
+	a.Eq(sum.Arguments[0].Shared, false)
+	a.Eq(sum.Arguments[1].Shared, true)
+
+	aggregate := result.Expressions[1]
+	a.EqString(aggregate.Kind, "function")
+	a.EqInt(len(aggregate.Arguments), 1)
+	a.EqInt(aggregate.Arguments[0].Fetch.MatchedTagSets, 2)
+
+	a.EqInt(result.Expressions[2].Fetch.MatchedTagSets, 1)
+
+	a.EqInt(len(result.FetchRequests), 3)
+	for _, request := range result.FetchRequests {
+		a.Eq(request.Timerange, testTimerange)
+	}
+	a.EqString(result.FetchRequests[0].Metric, "series_1")
+	a.EqInt(result.FetchRequests[0].Series, 1)
+}
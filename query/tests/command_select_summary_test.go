@@ -48,6 +48,8 @@ func TestSelectSummary(t *testing.T) {
 		api.Timeseries{Values: []float64{3, n, 7, n, n}, TagSet: api.TagSet{"metric": "series_b", "dc": "west"}},
 		api.Timeseries{Values: []float64{n, n, 5, 2, 2}, TagSet: api.TagSet{"metric": "series_b", "dc": "east"}},
 		api.Timeseries{Values: []float64{n, n, n, n, n}, TagSet: api.TagSet{"metric": "series_b", "dc": "miss"}},
+		// series_c
+		api.Timeseries{Values: []float64{1, 0, 2, 3, 4}, TagSet: api.TagSet{"metric": "series_c", "dc": "west"}},
 	)
 
 	type test struct {
@@ -234,6 +236,22 @@ func TestSelectSummary(t *testing.T) {
 				api.TagSet{"dc": "miss"}.Serialize(): 0,
 			},
 		},
+		{
+			query: "select series_b | summarize.coverage from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"dc": "west"}.Serialize(): 0.4,
+				api.TagSet{"dc": "east"}.Serialize(): 0.6,
+				api.TagSet{"dc": "miss"}.Serialize(): 0,
+			},
+		},
+		{
+			query: "select series_b | summarize.coverage(60s) from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"dc": "west"}.Serialize(): 1.0 / 3,
+				api.TagSet{"dc": "east"}.Serialize(): 1,
+				api.TagSet{"dc": "miss"}.Serialize(): 0,
+			},
+		},
 		{
 			query: "select series_b | summarize.total from 0 to 120000",
 			expected: map[string]float64{
@@ -242,6 +260,80 @@ func TestSelectSummary(t *testing.T) {
 				api.TagSet{"dc": "miss"}.Serialize(): 5,
 			},
 		},
+		{
+			query: "select series_a | summarize.dominant_period(1m) from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"app": "web", "dc": "west"}.Serialize():  n,
+				api.TagSet{"app": "web", "dc": "east"}.Serialize():  60000,
+				api.TagSet{"app": "fun", "dc": "north"}.Serialize(): 60000,
+			},
+		},
+		{
+			query: "select series_a | summarize.during_hours('mean', '00:00', '00:01', 'fri,sat') from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"app": "web", "dc": "west"}.Serialize():  1,
+				api.TagSet{"app": "web", "dc": "east"}.Serialize():  1,
+				api.TagSet{"app": "fun", "dc": "north"}.Serialize(): 5,
+			},
+		},
+		{
+			query: "select series_b | summarize.during_hours('coverage', '00:01', '23:00') from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"dc": "west"}.Serialize(): 1.0 / 3,
+				api.TagSet{"dc": "east"}.Serialize(): 1,
+				api.TagSet{"dc": "miss"}.Serialize(): 0,
+			},
+		},
+		{
+			query: "select summarize.correlation(series_a, series_b) from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"app": "web", "dc": "west"}.Serialize(): 1,
+				api.TagSet{"app": "web", "dc": "east"}.Serialize(): 0,
+			},
+		},
+		{
+			query: "select summarize.cross_correlation(series_c, series_a, 1m) from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"app": "web", "dc": "west", "correlation": "coefficient"}.Serialize(): 1,
+				api.TagSet{"app": "web", "dc": "west", "correlation": "lag"}.Serialize():         30000,
+			},
+		},
+		{
+			query: "select summarize.max(series_a) / summarize.mean(series_a) from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"app": "web", "dc": "west"}.Serialize():  2,
+				api.TagSet{"app": "web", "dc": "east"}.Serialize():  2,
+				api.TagSet{"app": "fun", "dc": "north"}.Serialize(): 1.2,
+			},
+		},
+		{
+			query: "select summarize.max(series_a) - summarize.max(series_c) from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"app": "web", "dc": "west"}.Serialize(): 2,
+			},
+		},
+		{
+			query: "select 2 * summarize.min(series_a) + 1 from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"app": "web", "dc": "west"}.Serialize():  1,
+				api.TagSet{"app": "web", "dc": "east"}.Serialize():  1,
+				api.TagSet{"app": "fun", "dc": "north"}.Serialize(): 9,
+			},
+		},
+		{
+			query: "select series_a | summarize.max | scalar.where('>', 4) from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"app": "web", "dc": "west"}.Serialize():  6,
+				api.TagSet{"app": "fun", "dc": "north"}.Serialize(): 6,
+			},
+		},
+		{
+			query: "select series_a | summarize.mean | scalar.highest(2) from 0 to 120000",
+			expected: map[string]float64{
+				api.TagSet{"app": "web", "dc": "west"}.Serialize():  3,
+				api.TagSet{"app": "fun", "dc": "north"}.Serialize(): 5,
+			},
+		},
 	}
 
 	for _, test := range tests {
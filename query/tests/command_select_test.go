@@ -85,6 +85,68 @@ func TestCommand_Select(t *testing.T) {
 				TagSet: api.NewTagSet(),
 			}},
 		}}},
+		{"select aggregate.median(series_3) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{{
+				Values: []float64{3, 3, 3, 3, 3},
+				TagSet: api.NewTagSet(),
+			}},
+		}}},
+		{"select series_3 | aggregate.quantile(0.25) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{{
+				Values: []float64{2, 2, 2, 2.5, 2.5},
+				TagSet: api.NewTagSet(),
+			}},
+		}}},
+		{"select aggregate.quantile(series_2 + series_3, 0.99 group by dc) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{2, 3, 4, 8, 9},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+				{
+					Values: []float64{8, 5, 8, 8, 4},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+			},
+		}}},
+		{"select aggregate.quantile(series_3, 1.5) from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select aggregate.variance(series_3) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{{
+				Values: []float64{8.0 / 3, 8.0 / 3, 8.0 / 3, 2.0 / 3, 2.0 / 3},
+				TagSet: api.NewTagSet(),
+			}},
+		}}},
+		{"select aggregate.stddev(series_2 collapse by dc) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{{
+				Values: []float64{1, 1, 0, 1, 1.5},
+				TagSet: api.NewTagSet(),
+			}},
+		}}},
+		{"select aggregate.count_distinct(series_2) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{{
+				Values: []float64{2, 2, 1, 2, 2},
+				TagSet: api.NewTagSet(),
+			}},
+		}}},
+		{"select transform.summarize(series_2, 60ms, 'max') from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{2, 2, 4, 4, 5},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+				{
+					Values: []float64{3, 3, 6, 6, 2},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+			},
+		}}},
+		{"select series_2[dc = 'east'] | transform.summarize(60ms, 'last') from 30 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{{
+				Values: []float64{0, 6, 6, 2},
+				TagSet: api.TagSet{"dc": "east"},
+			}},
+		}}},
+		{"select transform.summarize(series_2, 60ms, 'median') from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
 		{"select series_1 from 0 to 60 resolution 30ms", false, []api.SeriesList{{
 			Series: []api.Timeseries{{
 				Values: []float64{1, 2, 3},
@@ -313,6 +375,204 @@ func TestCommand_Select(t *testing.T) {
 				},
 			},
 		}}},
+		{"select aggregate.sum(series_3 group by dc) | filter.tag(\"dc != 'north' and dc match '^w'\") from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{1, 1, 1, 4, 4},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+			},
+		}}},
+		{"select series_3 | filter.tag(\"dc in ('east', 'north')\") from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{5, 5, 5, 2, 2},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+				{
+					Values: []float64{3, 3, 3, 3, 3},
+					TagSet: api.TagSet{"dc": "north"},
+				},
+			},
+		}}},
+		{"select series_3 | filter.tag('dc = ') from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select series_3 | filter.where('max', '>=', 4) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{1, 1, 1, 4, 4},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+				{
+					Values: []float64{5, 5, 5, 2, 2},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+			},
+		}}},
+		{"select series_3 | filter.where('summarize.min', '<', 3, 30ms) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{5, 5, 5, 2, 2},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+			},
+		}}},
+		{"select series_3 | filter.where('max', '~', 4) from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select series_3 | filter.where('transform.abs', '>', 4) from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select series_3 | filter.top_with_other(1, 'max', 'sum') from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{5, 5, 5, 2, 2},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+				{
+					Values: []float64{4, 4, 4, 7, 7},
+					TagSet: api.TagSet{"dc": "other"},
+				},
+			},
+		}}},
+		{"select filter.top_with_other(series_2 + series_3, 0, 'mean', 'max', 'rest' group by dc) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{2, 3, 4, 8, 9},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+				{
+					Values: []float64{8, 5, 8, 8, 4},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+			},
+		}}},
+		{"select series_3 | filter.top_with_other(1, 'max', 'sum', 'other', 30ms) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{1, 1, 1, 4, 4},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+				{
+					Values: []float64{8, 8, 8, 5, 5},
+					TagSet: api.TagSet{"dc": "other"},
+				},
+			},
+		}}},
+		{"select series_3 | filter.top_with_other(1, 'max', 'total') from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select series_3 | filter.top_with_other(-3, 'max', 'sum') from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select series_3 | anomaly.outliers(0.5) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{1, 1, 1, 4, 4},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+				{
+					Values: []float64{5, 5, 5, 2, 2},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+			},
+		}}},
+		{"select series_3 | anomaly.outliers(-1) from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select slo.burn_rate(series_1, series_2 + 1, 0.5, 30ms) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{1, 2.0 / 3, 0.5, 0.4, 1.0 / 3},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+			},
+		}}},
+		{"select slo.error_budget_remaining(series_1, series_2, 1, 30ms) from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select series_2 - summarize.min(series_2) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{0, 1, 2, 3, 4},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+				{
+					Values: []float64{3, 0, 3, 6, 2},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+			},
+		}}},
+		{"select 'a' + summarize.min(series_2) from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select series.union(series_1, series_2 {second}) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{1, 2, 3, 4, 5},
+					TagSet: api.TagSet{"dc": "west", "source": "series_1"},
+				},
+				{
+					Values: []float64{1, 2, 3, 4, 5},
+					TagSet: api.TagSet{"dc": "west", "source": "second"},
+				},
+				{
+					Values: []float64{3, 0, 3, 6, 2},
+					TagSet: api.TagSet{"dc": "east", "source": "second"},
+				},
+			},
+		}}},
+		{"select aggregate.sum(series.union(series_1, series_2) group by dc) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{2, 4, 6, 8, 10},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+				{
+					Values: []float64{3, 0, 3, 6, 2},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+			},
+		}}},
+		{"select series.dedup(series.union(series_1, series_2), 'last' group by dc) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{1, 2, 3, 4, 5},
+					TagSet: api.TagSet{"dc": "west", "source": "series_2"},
+				},
+				{
+					Values: []float64{3, 0, 3, 6, 2},
+					TagSet: api.TagSet{"dc": "east", "source": "series_2"},
+				},
+			},
+		}}},
+		{"select series.dedup(series_2, 'highest_mean' collapse by dc) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{1, 2, 3, 4, 5},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+			},
+		}}},
+		{"select series.dedup(series_2, 'random') from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select series.union(series_1, 'a') from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select series_3 | filter.correlated_with(aggregate.sum(series_1), 0.8) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{1, 1, 1, 4, 4},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+				{
+					Values: []float64{5, 5, 5, 2, 2},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+			},
+		}}},
+		{"select series_3 | filter.correlated_with(series_1, 0.9) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{},
+		}}},
+		{"select series_3 | filter.correlated_with(series_1, 2) from 0 to 120 resolution 30ms", true, []api.SeriesList{}},
+		{"select series_3 | anomaly.changepoints(30ms, 1) from 0 to 120 resolution 30ms", false, []api.SeriesList{{
+			Series: []api.Timeseries{
+				{
+					Values: []float64{0, 0, 0, 3, 0},
+					TagSet: api.TagSet{"dc": "west"},
+				},
+				{
+					Values: []float64{0, 0, 0, -3, 0},
+					TagSet: api.TagSet{"dc": "east"},
+				},
+				{
+					Values: []float64{0, 0, 0, 0, 0},
+					TagSet: api.TagSet{"dc": "north"},
+				},
+			},
+		}}},
 		{"select series_1 from -1000d to now resolution 30ms", true, []api.SeriesList{}},
 	} {
 		a := assert.New(t).Contextf("query=%s", test.query)
@@ -328,6 +588,7 @@ func TestCommand_Select(t *testing.T) {
 			MetricMetadataAPI:    comboAPI,
 			FetchLimit:           1000,
 			Timeout:              100 * time.Millisecond,
+			PredicateParser:      parser.ParsePredicate,
 			Ctx:                  context.Background(),
 		})
 		if test.expectError {
@@ -410,6 +671,131 @@ func TestCommand_Select(t *testing.T) {
 	})
 }
 
+func TestCommand_SelectTimezone(t *testing.T) {
+	a := assert.New(t)
+	day := int64(24 * time.Hour / time.Millisecond)
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC).Unix() * 1000
+	testTimerange, err := api.NewSnappedTimerange(start, start+9*day, day)
+	a.CheckError(err)
+	comboAPI := mocks.NewComboAPI(
+		testTimerange,
+		api.Timeseries{Values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, TagSet: api.TagSet{"metric": "series_1"}},
+	)
+	for _, test := range []struct {
+		query         string
+		expectedStart time.Time
+		expectedSlots int
+		expectError   bool
+	}{
+		{"select series_1 from '2016-1-4' to '2016-1-6' resolution '1d'", time.Date(2016, 1, 4, 0, 0, 0, 0, time.UTC), 3, false},
+		{"select series_1 from '2016-1-4' to '2016-1-6' resolution '1d' timezone 'America/New_York'", time.Date(2016, 1, 4, 5, 0, 0, 0, time.UTC), 3, false},
+		{"select series_1 timezone 'Asia/Tokyo' from '2016-1-4' to '2016-1-6' resolution '1d'", time.Date(2016, 1, 3, 15, 0, 0, 0, time.UTC), 3, false},
+		// Daylight saving time begins in New York on 2016-3-13, so daily slots cannot all start at local midnight.
+		{"select series_1 from '2016-3-12' to '2016-3-14' resolution '1d' timezone 'America/New_York'", time.Time{}, 0, true},
+		{"select series_1 from '2016-3-14' to '2016-3-16' resolution '1d' timezone 'America/New_York'", time.Date(2016, 3, 14, 4, 0, 0, 0, time.UTC), 3, false},
+	} {
+		a := a.Contextf("%s", test.query)
+		testCommand, err := parser.Parse(test.query)
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, err := testCommand.Execute(command.ExecutionContext{
+			TimeseriesStorageAPI: comboAPI,
+			MetricMetadataAPI:    comboAPI,
+			FetchLimit:           1000,
+			Ctx:                  context.Background(),
+		})
+		if test.expectError {
+			if err == nil {
+				a.Errorf("expected an error")
+			}
+			continue
+		}
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		timerange := result.Body.([]command.QueryResult)[0].Timerange
+		a.Eq(timerange.Start().UTC(), test.expectedStart)
+		a.EqInt(timerange.Slots(), test.expectedSlots)
+	}
+}
+
+func TestCommand_SelectArithmeticType(t *testing.T) {
+	a := assert.New(t)
+	testTimerange, err := api.NewSnappedTimerange(0, 120, 30)
+	a.CheckError(err)
+	comboAPI := mocks.NewComboAPI(
+		testTimerange,
+		api.Timeseries{Values: []float64{1, 2, 3, 4, 5}, TagSet: api.TagSet{"metric": "series_1"}},
+	)
+	for _, test := range []struct {
+		query        string
+		expectedType string
+	}{
+		{"select 1 + 2 from 0 to 120 resolution 30ms", "series"},
+		{"select series_1 + 2 from 0 to 120 resolution 30ms", "series"},
+		{"select summarize.min(series_1) + 2 from 0 to 120 resolution 30ms", "scalars"},
+		{"select 2 * summarize.min(series_1) from 0 to 120 resolution 30ms", "scalars"},
+	} {
+		a := a.Contextf("%s", test.query)
+		testCommand, err := parser.Parse(test.query)
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, err := testCommand.Execute(command.ExecutionContext{
+			TimeseriesStorageAPI: comboAPI,
+			MetricMetadataAPI:    comboAPI,
+			FetchLimit:           1000,
+			Ctx:                  context.Background(),
+		})
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		a.EqString(result.Body.([]command.QueryResult)[0].Type, test.expectedType)
+	}
+}
+
+func TestCommand_SelectOperatorName(t *testing.T) {
+	a := assert.New(t)
+	testTimerange, err := api.NewSnappedTimerange(0, 120, 30)
+	a.CheckError(err)
+	comboAPI := mocks.NewComboAPI(
+		testTimerange,
+		api.Timeseries{Values: []float64{1, 2, 3, 4, 5}, TagSet: api.TagSet{"metric": "series_1", "dc": "west"}},
+	)
+	for _, query := range []string{
+		"select series_1 | tag.alias('{{dc}} rate') * 100 from 0 to 120 resolution 30ms",
+		"select 100 * tag.alias(series_1, '{{dc}} rate') from 0 to 120 resolution 30ms",
+	} {
+		a := a.Contextf("%s", query)
+		testCommand, err := parser.Parse(query)
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		result, err := testCommand.Execute(command.ExecutionContext{
+			TimeseriesStorageAPI: comboAPI,
+			MetricMetadataAPI:    comboAPI,
+			FetchLimit:           1000,
+			Ctx:                  context.Background(),
+		})
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+		series := result.Body.([]command.QueryResult)[0].Series
+		a.EqInt(len(series), 1)
+		if len(series) == 1 {
+			a.EqString(series[0].Name, "west rate")
+			a.EqFloatArray(series[0].Values, []float64{100, 200, 300, 400, 500}, 1e-10)
+		}
+	}
+}
+
 func TestTag(t *testing.T) {
 	fakeAPI := mocks.NewFakeMetricMetadataAPI()
 	fakeAPI.AddPairWithoutGraphite(api.TaggedMetric{MetricKey: "series_1", TagSet: api.TagSet{"dc": "west", "env": "production"}})
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/square/metrics/api"
+	"github.com/square/metrics/function"
 	"github.com/square/metrics/metric_metadata"
 	"github.com/square/metrics/query/command"
 	"github.com/square/metrics/query/parser"
@@ -119,3 +120,44 @@ func TestCommand_DescribeAll(t *testing.T) {
 		a.Eq(rawResult.Body, test.expected)
 	}
 }
+
+func TestCommand_DescribeFunctions(t *testing.T) {
+	for _, test := range []struct {
+		query    string
+		expected []string
+	}{
+		{"describe functions match '^aggregate.sum$'", []string{"aggregate.sum(series list [group by ...]) -> series list"}},
+		{"describe functions match '^transform.(bound|timeshift)$'", []string{
+			"transform.bound(series list, scalar, scalar) -> series list",
+			"transform.timeshift(expression, duration) -> value",
+		}},
+		{"describe functions match 'summarize.mean'", []string{"summarize.mean(series list, [duration]) -> scalar set"}},
+		{"describe functions match '^series.union$'", []string{"series.union(series list...) -> series list"}},
+		{"describe functions match 'does_not_exist'", []string{}},
+	} {
+		a := assert.New(t).Contextf("query=%s", test.query)
+		testCommand, err := parser.Parse(test.query)
+		a.CheckError(err)
+		if err != nil {
+			continue
+		}
+
+		a.EqString(testCommand.Name(), "describe functions")
+		rawResult, err := testCommand.Execute(command.ExecutionContext{
+			TimeseriesStorageAPI: mocks.FakeTimeseriesStorageAPI{},
+			MetricMetadataAPI:    mocks.NewFakeMetricMetadataAPI(),
+			FetchLimit:           1000,
+			Ctx:                  context.Background(),
+		})
+		a.CheckError(err)
+		descriptions := rawResult.Body.([]function.Description)
+		usages := make([]string, len(descriptions))
+		for i, description := range descriptions {
+			usages[i] = description.Usage
+			if description.Description == "" {
+				a.Errorf("expected %s to have a description", description.Name)
+			}
+		}
+		a.Eq(usages, test.expected)
+	}
+}
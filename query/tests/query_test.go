@@ -26,8 +26,14 @@ import (
 // these queries should successfully parse,
 // with a corresponding command.
 var inputs = []string{
+	// explain
+	"explain select x from 0 to 0",
+	"explain x from 0 to 0",
+	"EXPLAIN select aggregate.sum(x group by dc) where dc = 'west' from 0 to 0",
 	// describe all
 	"describe all",
+	"describe functions",
+	"describe functions match 'aggregate'",
 	"describe all match 'abc'",
 	"describe all match \"abc\"",
 	// describes
@@ -104,6 +110,10 @@ var selects = []string{
 	"x * (y + 123), z from 0 to 10000",
 	"1 from -10m to now",
 	"1 from -10M to -10m",
+	"1 from yesterday to today",
+	"1 from startofmonth - 1mo to startofmonth timezone 'America/New_York'",
+	"1 from startofweek-1w to now resolution '1d' timezone 'Europe/London'",
+	"1 timezone 'Asia/Kolkata' from '2016-1-1' to '2016-2-1'",
 	// selects - function calls
 	"foo(x) from 0 to 0",
 	"bar(x, y) from 0 to 0",
@@ -189,6 +199,14 @@ var syntaxErrorQuery = []string{
 	"select c group by a from 0 to 0",
 	"select x[] from 0 to 0",
 	"select cpu | transform.moving_average(10qq) from 0 to 0",
+	"select x from 0 to 0 timezone 'Not/A_Zone'",
+	"select x from 0 to 0 timezone 'UTC' timezone 'UTC'",
+	"select x from startofmonth - 1 to 0",
+	"select x from startofdecade to 0",
+	"explain",
+	"describe functions where x = 'y'",
+	"explain describe all",
+	"explain explain select x from 0 to 0",
 }
 
 func TestParse_success(t *testing.T) {
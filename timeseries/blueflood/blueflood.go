@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +42,9 @@ type Blueflood struct {
 //Blueflood implements TimeseriesStorageAPI
 var _ timeseries.StorageAPI = (*Blueflood)(nil)
 
+// Blueflood can explain its fetches
+var _ timeseries.FetchPlanner = (*Blueflood)(nil)
+
 // TimeSource represents a source of time values.
 // Its zero value will give the current time.
 type TimeSource struct {
@@ -173,6 +177,33 @@ func (b *Blueflood) FetchMultipleTimeseries(request timeseries.FetchMultipleRequ
 	}, nil
 }
 
+// PlanFetch describes the intervals which will be requested from Blueflood for
+// each series fetched with the given request details, ordered by start time.
+func (b *Blueflood) PlanFetch(request timeseries.RequestDetails) ([]timeseries.PlannedFetch, error) {
+	plan, err := b.createPlan(request)
+	if err != nil {
+		return nil, err
+	}
+	result := []timeseries.PlannedFetch{}
+	for resolution, interval := range plan.intervals {
+		result = append(result, timeseries.PlannedFetch{
+			Name:       resolution.Name,
+			Resolution: resolution.Resolution,
+			Interval:   interval,
+		})
+	}
+	sort.Sort(plannedFetchesByStart(result))
+	return result, nil
+}
+
+type plannedFetchesByStart []timeseries.PlannedFetch
+
+func (p plannedFetchesByStart) Len() int      { return len(p) }
+func (p plannedFetchesByStart) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p plannedFetchesByStart) Less(i, j int) bool {
+	return p[i].Interval.Start.Before(p[j].Interval.Start)
+}
+
 // fetchPlan contains data required to fetch a timeseries by stitching together
 // multi-resolution data.
 type fetchPlan struct {
@@ -33,6 +33,20 @@ type StorageAPI interface {
 	CheckHealthy() error
 }
 
+// A FetchPlanner is a StorageAPI which can describe the requests it would make
+// to fulfill a fetch without performing them.
+type FetchPlanner interface {
+	PlanFetch(request RequestDetails) ([]PlannedFetch, error)
+}
+
+// PlannedFetch describes an interval which will be fetched (for each series) at a
+// particular resolution.
+type PlannedFetch struct {
+	Name       string        `json:"name,omitempty"` // name of the resolution, if any
+	Resolution time.Duration `json:"resolution"`
+	Interval   api.Interval  `json:"interval"`
+}
+
 type RequestDetails struct {
 	SampleMethod SampleMethod    // up/downsampling behavior.
 	Timerange    api.Timerange   // time range to fetch data from.